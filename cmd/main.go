@@ -3,6 +3,8 @@ package main
 import (
 	"github.com/khussa1n/todo-list/internal/app"
 	"github.com/khussa1n/todo-list/internal/config"
+	// База часовых поясов для времени выполнения задач: в образе alpine ее нет
+	_ "time/tzdata"
 )
 
 // @title           Todo List
@@ -14,6 +16,11 @@ import (
 
 // @host      localhost:8080
 // @BasePath  /api/todo-list
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and JWT token.
 func main() {
 	// Инициализация кофигурации
 	cfg, err := config.InitConfig("config.yaml")
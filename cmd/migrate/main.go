@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/repository/mongorepo"
+	"github.com/khussa1n/todo-list/pkg/client/mongodb"
+	"log"
+)
+
+// Одноразовая миграция существующих задач: activeAt из строки в BSON date
+// старый статус "active" в todo, префикс выходного дня из названия в флаг weekend
+// начальная версия и приоритет задачи, поля полнотекстового поиска
+func main() {
+	// Инициализация кофигурации
+	cfg, err := config.InitConfig("config.yaml")
+	if err != nil {
+		panic(err)
+	}
+
+	// Соеденение с базой
+	conn, err := mongodb.New(
+		mongodb.WithHost(cfg.DB.Host),
+		mongodb.WithPort(cfg.DB.Port),
+		mongodb.WithDBName(cfg.DB.DBName),
+		mongodb.WithUsername(cfg.DB.Username),
+		mongodb.WithPassword(cfg.DB.Password),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Client().Disconnect(context.Background())
+
+	db := mongorepo.New(conn, cfg.DB.Collections)
+
+	migrated, err := db.MigrateActiveAtToDate(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	reopened, err := db.MigrateActiveStatus(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	// Префикс, который раньше сохранялся в названии
+	unprefixed, err := db.MigrateWeekendPrefix(context.Background(), "ВЫХОДНОЙ - ")
+	if err != nil {
+		panic(err)
+	}
+
+	versioned, err := db.MigrateTaskVersion(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	indexed, err := db.MigrateSearchTerms(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	prioritized, err := db.MigrateTaskPriority(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	log.Printf("migration finished, %d tasks updated", migrated+reopened+unprefixed+versioned+indexed+prioritized)
+}
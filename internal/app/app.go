@@ -1,38 +1,135 @@
 package app
 
 import (
+	"context"
+	"fmt"
 	config "github.com/khussa1n/todo-list/internal/config"
 	"github.com/khussa1n/todo-list/internal/handler"
+	"github.com/khussa1n/todo-list/internal/repository"
+	"github.com/khussa1n/todo-list/internal/repository/memrepo"
 	"github.com/khussa1n/todo-list/internal/repository/mongorepo"
+	"github.com/khussa1n/todo-list/internal/repository/postgresrepo"
 	"github.com/khussa1n/todo-list/internal/service"
+	"github.com/khussa1n/todo-list/pkg/blobstorage"
 	"github.com/khussa1n/todo-list/pkg/client/mongodb"
+	"github.com/khussa1n/todo-list/pkg/client/postgres"
 	"github.com/khussa1n/todo-list/pkg/httpserver"
 	"log"
 	"os"
 	"os/signal"
 )
 
+// newRepository создает репозиторий для хранилища, указанного в db.driver
+func newRepository(cfg config.DBConfig) (repository.Repository, error) {
+	switch cfg.Driver {
+	case config.DriverMemory:
+		log.Println("using in-memory storage")
+		return memrepo.New(), nil
+	case config.DriverMongo, "":
+		// Соеденение с базой
+		conn, err := mongodb.New(
+			mongodb.WithHost(cfg.Host),
+			mongodb.WithPort(cfg.Port),
+			mongodb.WithDBName(cfg.DBName),
+			mongodb.WithUsername(cfg.Username),
+			mongodb.WithPassword(cfg.Password),
+		)
+		if err != nil {
+			log.Printf("connection to mongodb err: %s", err.Error())
+			return nil, err
+		}
+		log.Println("connection success")
+
+		// Получение базы mongodb <MongoDB struct>
+		db := mongorepo.New(conn, cfg.Collections)
+		// Задачи прежних версий сервиса приводятся к текущей схеме до создания индексов
+		err = db.Migrate(context.Background())
+		if err != nil {
+			log.Printf("migrate err: %s", err.Error())
+			return nil, err
+		}
+		// Создание индексов, в том числе уникальных
+		err = db.EnsureIndexes(context.Background())
+		if err != nil {
+			log.Printf("ensure indexes err: %s", err.Error())
+			return nil, err
+		}
+		return db, nil
+	case config.DriverPostgres:
+		conn, err := postgres.New(
+			postgres.WithHost(cfg.Host),
+			postgres.WithPort(cfg.Port),
+			postgres.WithDBName(cfg.DBName),
+			postgres.WithUsername(cfg.Username),
+			postgres.WithPassword(cfg.Password),
+			postgres.WithSSLMode(cfg.SSLMode),
+		)
+		if err != nil {
+			log.Printf("connection to postgres err: %s", err.Error())
+			return nil, err
+		}
+		log.Println("connection success")
+
+		db := postgresrepo.New(conn)
+		// Применение SQL миграций
+		err = db.Migrate(context.Background())
+		if err != nil {
+			log.Printf("migrate err: %s", err.Error())
+			return nil, err
+		}
+		return db, nil
+	default:
+		return nil, fmt.Errorf("unknown db driver: %q", cfg.Driver)
+	}
+}
+
+// newBlobStorage создает хранилище файлов вложений, указанное в attachment.storage.driver
+func newBlobStorage(cfg config.BlobConfig) (blobstorage.Storage, error) {
+	switch cfg.Driver {
+	case config.BlobDriverLocal, "":
+		log.Printf("attachments are stored in %s", cfg.Dir)
+		return blobstorage.NewLocal(cfg.Dir)
+	case config.BlobDriverS3:
+		log.Printf("attachments are stored in s3 bucket %s", cfg.Bucket)
+		return blobstorage.NewS3(
+			blobstorage.WithEndpoint(cfg.Endpoint),
+			blobstorage.WithRegion(cfg.Region),
+			blobstorage.WithBucket(cfg.Bucket),
+			blobstorage.WithCredentials(cfg.AccessKey, cfg.SecretKey),
+		)
+	default:
+		return nil, fmt.Errorf("unknown blob storage driver: %q", cfg.Driver)
+	}
+}
+
 func Run(cfg *config.Config) error {
-	// Соеденение с базой
-	conn, err := mongodb.New(
-		mongodb.WithHost(cfg.DB.Host),
-		mongodb.WithPort(cfg.DB.Port),
-		mongodb.WithDBName(cfg.DB.DBName),
-		mongodb.WithUsername(cfg.DB.Username),
-		mongodb.WithPassword(cfg.DB.Password),
-	)
+	// Без своего секрета токены может подписать кто угодно
+	err := cfg.Auth.Validate()
 	if err != nil {
-		log.Printf("connection to mongodb err: %s", err.Error())
+		log.Printf("auth config err: %s", err.Error())
+		return err
+	}
+	// Получение репозитория <Repository interface> выбранного хранилища
+	db, err := newRepository(cfg.DB)
+	if err != nil {
+		return err
+	}
+	// Хранилище файлов вложений
+	blobs, err := newBlobStorage(cfg.Attachment.Storage)
+	if err != nil {
+		log.Printf("blob storage err: %s", err.Error())
 		return err
 	}
-	log.Println("connection success")
-
-	// Получение репозитория <Repository interface> и базы mongodb <MongoDB struct>
-	db := mongorepo.New(conn, cfg.DB.Collections)
 	// Получение сервиса
 	srvs := service.New(db, cfg)
+	srvs.Blobs = blobs
+	// Фоновая очистка корзины
+	purgeCtx, cancelPurge := context.WithCancel(context.Background())
+	defer cancelPurge()
+	go srvs.RunTrashPurge(purgeCtx)
 	// Получение контроллера
 	hndlr := handler.New(srvs)
+	hndlr.MaxUploadSize = cfg.Attachment.MaxSize
 	// Создание http сервера
 	server := httpserver.New(
 		hndlr.InitRouter(),
@@ -1,14 +1,36 @@
 package config
 
 import (
+	"errors"
 	"github.com/ilyakaznacheev/cleanenv"
 	"time"
 )
 
+const (
+	DriverMongo    = "mongo"
+	DriverMemory   = "memory"
+	DriverPostgres = "postgres"
+
+	BlobDriverLocal = "local"
+	BlobDriverS3    = "s3"
+
+	// DefaultJWTSecret - секрет из config.yaml, годится только для тестов
+	DefaultJWTSecret = "change-me"
+)
+
 type Config struct {
 	HTTP ServerConfig `yaml:"http"`
 	DB   DBConfig     `yaml:"db"`
-	Test TestConfig   `json:"test"`
+	Auth AuthConfig   `yaml:"auth"`
+	// Decoration - оформление задач, выпадающих на выходные
+	Decoration DecorationConfig `yaml:"decoration"`
+	// Trash - хранение удаленных задач
+	Trash TrashConfig `yaml:"trash"`
+	// Workspace - рабочие пространства и приглашения
+	Workspace WorkspaceConfig `yaml:"workspace"`
+	// Attachment - вложения задач и хранилище их файлов
+	Attachment AttachmentConfig `yaml:"attachment"`
+	Test       TestConfig       `json:"test" env-prefix:"TEST_"`
 }
 
 type ServerConfig struct {
@@ -19,16 +41,92 @@ type ServerConfig struct {
 	WriteTimeout    time.Duration `yaml:"write_timeout"`
 }
 
+type AuthConfig struct {
+	JWTSecret string        `yaml:"jwt_secret" env:"JWT_SECRET"`
+	TokenTTL  time.Duration `yaml:"token_ttl"`
+}
+
+// Validate проверяет, что секрет токенов задан и это не секрет из config.yaml:
+// им может подписать токен любой, кто видел репозиторий
+func (c AuthConfig) Validate() error {
+	switch c.JWTSecret {
+	case "":
+		return errors.New("auth.jwt_secret is empty, set JWT_SECRET")
+	case DefaultJWTSecret:
+		return errors.New("auth.jwt_secret is the default one from config.yaml, set JWT_SECRET")
+	}
+	return nil
+}
+
+type DecorationConfig struct {
+	// Locale выбирает префикс выходного дня по умолчанию: ru или en
+	Locale string `yaml:"locale" env:"DECORATION_LOCALE"`
+	// WeekendPrefix заменяет префикс локали
+	WeekendPrefix string `yaml:"weekend_prefix" env:"DECORATION_WEEKEND_PREFIX"`
+	// WeekendDays - выходные дни недели: saturday, sunday, ...
+	WeekendDays []string `yaml:"weekend_days"`
+	// Holidays - праздничные дни в формате 2006-01-02, тоже считаются выходными
+	Holidays []string `yaml:"holidays"`
+}
+
+type TrashConfig struct {
+	// Retention - сколько задача хранится в корзине до окончательного удаления
+	Retention time.Duration `yaml:"retention" env:"TRASH_RETENTION"`
+	// PurgeInterval - как часто очищается корзина, 0 - не очищать
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"TRASH_PURGE_INTERVAL"`
+}
+
+type WorkspaceConfig struct {
+	// InvitationTTL - сколько действует приглашение в рабочее пространство
+	InvitationTTL time.Duration `yaml:"invitation_ttl" env:"WORKSPACE_INVITATION_TTL" env-default:"168h"`
+}
+
+type AttachmentConfig struct {
+	// MaxSize - наибольший размер файла в байтах
+	MaxSize int64 `yaml:"max_size" env:"ATTACHMENT_MAX_SIZE" env-default:"10485760"`
+	// AllowedTypes - разрешенные MIME-типы. Тип определяется по первым 512 байтам файла
+	AllowedTypes []string   `yaml:"allowed_types" env:"ATTACHMENT_ALLOWED_TYPES" env-default:"image/png,image/jpeg,image/gif,image/webp,application/pdf,text/plain"`
+	Storage      BlobConfig `yaml:"storage"`
+}
+
+type BlobConfig struct {
+	// Driver - хранилище файлов: local или s3
+	Driver string `yaml:"driver" env:"BLOB_DRIVER" env-default:"local"`
+	// Dir - каталог файлов драйвера local
+	Dir string `yaml:"dir" env:"BLOB_DIR" env-default:"attachments"`
+	// Endpoint, Region, Bucket, AccessKey и SecretKey используются только драйвером s3
+	Endpoint  string `yaml:"endpoint" env:"BLOB_S3_ENDPOINT"`
+	Region    string `yaml:"region" env:"BLOB_S3_REGION" env-default:"us-east-1"`
+	Bucket    string `yaml:"bucket" env:"BLOB_S3_BUCKET"`
+	AccessKey string `yaml:"access_key" env:"BLOB_S3_ACCESS_KEY"`
+	SecretKey string `yaml:"secret_key" env:"BLOB_S3_SECRET_KEY"`
+}
+
 type Collections struct {
-	Task string `yaml:"task"`
+	Task    string `yaml:"task"`
+	User    string `yaml:"user"`
+	List    string `yaml:"list" env-default:"lists"`
+	Comment string `yaml:"comment" env-default:"comments"`
+	// Attachment - описания вложений, сами файлы лежат в attachment.storage
+	Attachment string `yaml:"attachment" env-default:"attachments"`
+	// History - история изменений задач
+	History string `yaml:"history" env-default:"task_history"`
+	// Workspace, Member и Invitation - рабочие пространства, их участники и приглашения
+	Workspace  string `yaml:"workspace" env-default:"workspaces"`
+	Member     string `yaml:"member" env-default:"workspace_members"`
+	Invitation string `yaml:"invitation" env-default:"invitations"`
 }
 
 type DBConfig struct {
-	Host        string      `yaml:"host"`
-	Port        string      `yaml:"port"`
-	DBName      string      `yaml:"db_name"`
-	Username    string      `yaml:"username"`
-	Password    string      `yaml:"password"`
+	// Driver - хранилище задач: mongo, postgres или memory
+	Driver   string `yaml:"driver" env:"DB_DRIVER"`
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     string `yaml:"port" env:"DB_PORT"`
+	DBName   string `yaml:"db_name" env:"DB_NAME"`
+	Username string `yaml:"username" env:"DB_USERNAME"`
+	Password string `yaml:"password" env:"DB_PASSWORD"`
+	// SSLMode используется только драйвером postgres
+	SSLMode     string      `yaml:"ssl_mode" env:"DB_SSL_MODE"`
 	Collections Collections `yaml:"collections"`
 }
 
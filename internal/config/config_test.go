@@ -0,0 +1,44 @@
+package config
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_AuthValidate(t *testing.T) {
+	table := []struct {
+		name      string
+		secret    string
+		expectErr bool
+	}{
+		{
+			name:   "ok",
+			secret: "4f1c0b7e9a2d4c6e8b0a1f3d5c7e9b2a",
+		},
+		{
+			name:      "empty secret",
+			expectErr: true,
+		},
+		{
+			name:      "default secret",
+			secret:    DefaultJWTSecret,
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := AuthConfig{JWTSecret: testCase.secret}.Validate()
+			require.Equal(t, testCase.expectErr, err != nil)
+		})
+	}
+}
+
+func Test_InitConfigSecretFromEnv(t *testing.T) {
+	// config.yaml хранит только заглушку, настоящий секрет приходит из JWT_SECRET
+	t.Setenv("JWT_SECRET", "4f1c0b7e9a2d4c6e8b0a1f3d5c7e9b2a")
+
+	cfg, err := InitConfig("../../config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Auth.Validate())
+}
@@ -7,7 +7,61 @@ var (
 	ErrInvalidIDParameter    = errors.New("invalid id param")
 	ErrTaskNotFound          = errors.New("task not found")
 	ErrMessageTooLong        = errors.New("more than 200 char")
+	ErrEmptyTitle            = errors.New("title can not be empty")
 	ErrInvalidActiveAtFormat = errors.New("activeAt invalid format")
 	ErrDuplicateTask         = errors.New("a task with the same title already exists")
+	ErrVersionMismatch       = errors.New("task was modified, its version does not match If-Match")
+	ErrInvalidIfMatch        = errors.New("If-Match must be a task version ETag or *")
 	ErrInvalidInputBody      = errors.New("invalid input body")
+	ErrInvalidQueryParams    = errors.New("invalid query params")
+	ErrInvalidLimit          = errors.New("limit must be between 1 and 100")
+	ErrInvalidSort           = errors.New("sort must be one of activeAt, title, priority, createdAt")
+	ErrInvalidCursor         = errors.New("invalid cursor")
+	ErrInvalidDateRange      = errors.New("from and to must be dates in 2006-01-02 format, from not after to")
+	ErrInvalidPeriod         = errors.New("period must be one of overdue, today, week and can not be combined with from/to")
+	ErrInvalidSearchQuery    = errors.New("q must contain from 1 to 10 words")
+	ErrInvalidPriority       = errors.New("priority must be one of low, medium, high, urgent")
+	ErrInvalidDueTime        = errors.New("due time must be HH:MM with an IANA time zone")
+	ErrDescriptionTooLong    = errors.New("description is longer than 10000 characters")
+	ErrInvalidTag            = errors.New("tags must be 1 to 30 letters, digits, - or _, at most 20 per task")
+	ErrInvalidTagMatch       = errors.New("tagMatch must be one of all, any")
+	ErrTagNotFound           = errors.New("tag not found")
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrChecklistFull         = errors.New("checklist can not have more than 100 items")
+	ErrInvalidChecklistOrder = errors.New("ids must list every checklist item exactly once")
+	ErrInvalidRecurrence     = errors.New("recurrence must be an RRULE with FREQ=DAILY, WEEKLY or MONTHLY and optional INTERVAL, BYDAY, BYMONTHDAY, UNTIL")
+	ErrBlockerNotFound       = errors.New("blocking task not found")
+	ErrDependencyNotFound    = errors.New("task is not blocked by this task")
+	ErrDependencyCycle       = errors.New("dependency would create a cycle")
+	ErrTooManyDependencies   = errors.New("task can not be blocked by more than 50 tasks")
+	ErrTaskBlocked           = errors.New("task is blocked by tasks that are not done")
+	ErrListNotFound          = errors.New("list not found")
+	ErrInvalidListID         = errors.New("listId must be a list ID")
+	ErrDuplicateList         = errors.New("a list with the same title already exists")
+	ErrListNotEmpty          = errors.New("list has tasks, move or delete them first")
+	ErrCommentNotFound       = errors.New("comment not found")
+	ErrNotCommentAuthor      = errors.New("only the author can edit the comment")
+	ErrEmptyComment          = errors.New("comment can not be empty")
+	ErrAttachmentNotFound    = errors.New("attachment not found")
+	ErrMissingFile           = errors.New("multipart form field file is required")
+	ErrAttachmentTooLarge    = errors.New("file is larger than the attachment size limit")
+	ErrAttachmentType        = errors.New("file type is not allowed for attachments")
+	ErrAttachmentCorrupted   = errors.New("attachment content does not match its checksum")
+	ErrHistoryNotFound       = errors.New("task version not found in history")
+	ErrInvalidHistoryVersion = errors.New("version must be a positive number")
+	ErrWorkspaceNotFound     = errors.New("workspace not found")
+	ErrInvalidWorkspaceID    = errors.New("X-Workspace-ID must be a workspace ID")
+	ErrForbidden             = errors.New("your workspace role does not allow this operation")
+	ErrMemberNotFound        = errors.New("member not found")
+	ErrAlreadyMember         = errors.New("user is already a member of the workspace")
+	ErrWorkspaceOwner        = errors.New("workspace owner can not leave or change role")
+	ErrInvitationNotFound    = errors.New("invitation not found, used or expired")
+	ErrInvalidStatus         = errors.New("status must be one of todo, in_progress, done, archived")
+	ErrInvalidTransition     = errors.New("status transition is not allowed")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrDuplicateUser         = errors.New("a user with the same username already exists")
+	ErrInvalidCredentials    = errors.New("invalid username or password")
+	ErrEmptyAuthHeader       = errors.New("empty auth header")
+	ErrInvalidAuthHeader     = errors.New("invalid auth header")
+	ErrInvalidToken          = errors.New("invalid or expired token")
 )
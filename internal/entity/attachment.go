@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// Attachment - файл, прикрепленный к задаче. Содержимое лежит в хранилище файлов под ключом Key
+type Attachment struct {
+	ID     primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TaskID primitive.ObjectID `json:"taskId" bson:"taskId"`
+	// Owner - владелец задачи: пользователь или рабочее пространство
+	Owner      primitive.ObjectID `json:"-" bson:"owner"`
+	UploadedBy primitive.ObjectID `json:"uploadedBy" bson:"uploadedBy"`
+	FileName   string             `json:"fileName" bson:"fileName" example:"screenshot.png"`
+	// ContentType определяется по содержимому файла, а не по заголовку запроса
+	ContentType string `json:"contentType" bson:"contentType" example:"image/png"`
+	Size        int64  `json:"size" bson:"size" example:"48213"`
+	// Checksum - SHA-256 содержимого в hex, сверяется при каждом скачивании
+	Checksum  string    `json:"checksum" bson:"checksum"`
+	Key       string    `json:"-" bson:"key"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// MaxChecklistItems - сколько пунктов может быть в чек-листе задачи
+const MaxChecklistItems = 100
+
+// ChecklistItem - пункт чек-листа задачи
+type ChecklistItem struct {
+	ID    primitive.ObjectID `json:"id" bson:"id"`
+	Title string             `json:"title" bson:"title" example:"Паспорт"`
+	Done  bool               `json:"done" bson:"done"`
+}
+
+// ChecklistProgress - процент выполненных пунктов чек-листа, nil без чек-листа
+func (t *Tasks) ChecklistProgress() *int {
+	if len(t.Checklist) == 0 {
+		return nil
+	}
+
+	done := 0
+	for _, item := range t.Checklist {
+		if item.Done {
+			done++
+		}
+	}
+
+	progress := done * 100 / len(t.Checklist)
+	return &progress
+}
+
+// ChecklistDone сообщает, что в чек-листе есть пункты и все они выполнены
+func (t *Tasks) ChecklistDone() bool {
+	progress := t.ChecklistProgress()
+	return progress != nil && *progress == 100
+}
+
+// ChecklistItemIndex возвращает позицию пункта id в чек-листе, -1 - пункта нет
+func (t *Tasks) ChecklistItemIndex(id primitive.ObjectID) int {
+	for i, item := range t.Checklist {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// MaxCommentEdits - сколько прошлых версий комментария хранится, старые версии отбрасываются
+const MaxCommentEdits = 50
+
+// Comment - комментарий к задаче в markdown. Хранится отдельно от задачи
+type Comment struct {
+	ID     primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TaskID primitive.ObjectID `json:"taskId" bson:"taskId"`
+	// Owner - владелец задачи: пользователь или рабочее пространство
+	Owner      primitive.ObjectID `json:"-" bson:"owner"`
+	Author     primitive.ObjectID `json:"author" bson:"author"`
+	AuthorName string             `json:"authorName" bson:"authorName" example:"dana"`
+	Body       string             `json:"body" bson:"body" example:"Нужен **паспорт**"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"createdAt"`
+	// UpdatedAt - время последней правки, nil у неизмененного комментария
+	UpdatedAt *time.Time `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+	// Edits - прошлые версии комментария от старых к новым
+	Edits []CommentEdit `json:"edits,omitempty" bson:"edits,omitempty"`
+}
+
+// CommentEdit - версия комментария до правки
+type CommentEdit struct {
+	Body string `json:"body" bson:"body"`
+	// EditedAt - когда эту версию заменила правка
+	EditedAt time.Time `json:"editedAt" bson:"editedAt"`
+}
+
+// Edit заменяет текст комментария, сохраняя прежний в истории правок
+func (c *Comment) Edit(body string, at time.Time) {
+	c.Edits = append(c.Edits, CommentEdit{Body: c.Body, EditedAt: at})
+	if len(c.Edits) > MaxCommentEdits {
+		c.Edits = c.Edits[len(c.Edits)-MaxCommentEdits:]
+	}
+
+	c.Body = body
+	c.UpdatedAt = &at
+}
@@ -0,0 +1,125 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+// Date - календарная дата без времени. В JSON передается строкой "2006-01-02",
+// в MongoDB хранится как BSON date (полночь UTC), чтобы работали диапазоны и индексы
+type Date struct {
+	time.Time
+}
+
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{Time: t}, nil
+}
+
+// DateOf возвращает дату, на которую приходится t в его часовом поясе
+func DateOf(t time.Time) Date {
+	return Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// AddDays возвращает дату, сдвинутую на n дней
+func (d Date) AddDays(n int) Date {
+	return Date{Time: d.Time.AddDate(0, 0, n)}
+}
+
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+
+	return nil
+}
+
+func (d Date) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(d.Time)
+}
+
+// UnmarshalBSONValue читает BSON date, а также строки "2006-01-02",
+// которые остаются в документах, созданных до миграции activeAt
+func (d *Date) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	switch t {
+	case bsontype.DateTime:
+		var tm time.Time
+		if err := bson.UnmarshalValue(t, data, &tm); err != nil {
+			return err
+		}
+		*d = DateOf(tm.UTC())
+		return nil
+	case bsontype.String:
+		var s string
+		if err := bson.UnmarshalValue(t, data, &s); err != nil {
+			return err
+		}
+		parsed, err := ParseDate(s)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	case bsontype.Null, bsontype.Undefined:
+		*d = Date{}
+		return nil
+	default:
+		return fmt.Errorf("cannot decode %v into entity.Date", t)
+	}
+}
+
+// Value сохраняет дату в SQL как строку "2006-01-02", которая приводится к типу DATE
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan читает SQL DATE, который драйвер возвращает как time.Time или строку
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	case string:
+		return d.scanString(v)
+	case []byte:
+		return d.scanString(string(v))
+	case nil:
+		*d = Date{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into entity.Date", src)
+	}
+}
+
+func (d *Date) scanString(s string) error {
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
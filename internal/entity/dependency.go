@@ -0,0 +1,48 @@
+package entity
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// MaxTaskDependencies - сколько задач может блокировать одну задачу
+const MaxTaskDependencies = 50
+
+// DependencyGraph - задачи, которые блокируют задачу Root напрямую или через другие задачи.
+// Задача входит в Nodes один раз, даже если блокирует несколько задач графа:
+// в виде дерева общие блокирующие задачи повторялись бы на каждом пути к ним
+type DependencyGraph struct {
+	Root  primitive.ObjectID `json:"root" swaggertype:"string"`
+	Nodes []DependencyNode   `json:"nodes"`
+	Edges []DependencyEdge   `json:"edges"`
+}
+
+// DependencyNode - задача графа зависимостей
+type DependencyNode struct {
+	ID     primitive.ObjectID `json:"id" swaggertype:"string"`
+	Title  string             `json:"title"`
+	Status string             `json:"status"`
+}
+
+// DependencyEdge - задачу Task блокирует задача BlockedBy
+type DependencyEdge struct {
+	Task      primitive.ObjectID `json:"task" swaggertype:"string"`
+	BlockedBy primitive.ObjectID `json:"blockedBy" swaggertype:"string"`
+}
+
+// DependsOn сообщает, блокирует ли задача id эту задачу напрямую
+func (t *Tasks) DependsOn(id primitive.ObjectID) bool {
+	for _, dependency := range t.Dependencies {
+		if dependency == id {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen сообщает, что задача еще не выполнена и не в архиве
+func (t *Tasks) IsOpen() bool {
+	for _, status := range OpenStatuses {
+		if t.Status == status {
+			return true
+		}
+	}
+	return false
+}
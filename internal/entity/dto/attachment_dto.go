@@ -0,0 +1,7 @@
+package dto
+
+import "github.com/khussa1n/todo-list/internal/entity"
+
+type AttachmentsDTO struct {
+	Attachments []entity.Attachment `json:"attachments"`
+}
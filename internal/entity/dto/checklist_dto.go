@@ -0,0 +1,19 @@
+package dto
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// ChecklistItemDTO - новый пункт чек-листа, добавляется в конец
+type ChecklistItemDTO struct {
+	Title string `json:"title" binding:"required,max=200" example:"Паспорт"`
+}
+
+// ChecklistItemPatchDTO - изменение пункта чек-листа, отсутствующие поля не меняются
+type ChecklistItemPatchDTO struct {
+	Title *string `json:"title" binding:"omitempty,min=1,max=200" example:"Паспорт"`
+	Done  *bool   `json:"done" example:"true"`
+}
+
+// ChecklistOrderDTO - новый порядок пунктов: все ID чек-листа, каждый по одному разу
+type ChecklistOrderDTO struct {
+	IDs []primitive.ObjectID `json:"ids" binding:"required" swaggertype:"array,string"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "github.com/khussa1n/todo-list/internal/entity"
+
+// CommentDTO - текст комментария в markdown
+type CommentDTO struct {
+	Body string `json:"body" binding:"required,max=10000" example:"Нужен **паспорт**"`
+}
+
+type CommentsDTO struct {
+	Comments []entity.Comment `json:"comments"`
+}
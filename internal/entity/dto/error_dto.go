@@ -0,0 +1,14 @@
+package dto
+
+// Error - тело ответа с ошибкой. Code - стабильный машиночитаемый код,
+// Details - ошибки отдельных полей запроса
+type Error struct {
+	Code    string       `json:"code" example:"task_not_found"`
+	Message string       `json:"message" example:"task not found"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field" example:"title"`
+	Message string `json:"message" example:"is required"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+import "github.com/khussa1n/todo-list/internal/entity"
+
+type HistoryDTO struct {
+	History []entity.HistoryEntry `json:"history"`
+}
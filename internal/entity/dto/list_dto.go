@@ -0,0 +1,16 @@
+package dto
+
+import "github.com/khussa1n/todo-list/internal/entity"
+
+type ListDTO struct {
+	Title string `json:"title" binding:"required,max=100" example:"Sprint backlog"`
+}
+
+type ListsDTO struct {
+	Lists []entity.List `json:"lists"`
+}
+
+// MoveTaskDTO - список, в который переносится задача. Пустой listId - во входящие
+type MoveTaskDTO struct {
+	ListID string `json:"listId" example:"64d0f5a1c2b3a4d5e6f70819"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/khussa1n/todo-list/internal/entity"
+
+type TagsDTO struct {
+	Tags []entity.TagStat `json:"tags"`
+}
+
+// RenameTagDTO - новое название метки. Если такая метка уже есть, метки сливаются
+type RenameTagDTO struct {
+	Name string `json:"name" binding:"required" example:"ops"`
+}
+
+type RenameTagResultDTO struct {
+	// Tasks - сколько задач изменено
+	Tasks int64 `json:"tasks"`
+}
@@ -1,6 +1,95 @@
 package dto
 
+import (
+	"encoding/json"
+	"reflect"
+)
+
 type TasksDTO struct {
-	Title    string `json:"title" binding:"required"`
-	ActiveAt string `json:"activeAt" binding:"required"`
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description" example:"**Молоко** и хлеб"`
+	ActiveAt    string `json:"activeAt" binding:"required"`
+	// Priority - low, medium, high или urgent, по умолчанию medium
+	Priority string  `json:"priority" enums:"low,medium,high,urgent" example:"high"`
+	Due      *DueDTO `json:"due"`
+	// Tags - метки: буквы, цифры, - и _, до 30 символов, не больше 20 меток
+	Tags []string `json:"tags" example:"backend"`
+	// AutoComplete - перевести задачу в done, когда выполнены все пункты чек-листа
+	AutoComplete bool `json:"autoComplete"`
+	// Recurrence - правило повторения RRULE, учитывается только при создании задачи,
+	// дальше меняется через /tasks/{id}/recurrence
+	Recurrence string `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,WE"`
+	// ListID - список задачи, пустой - входящие. Учитывается только при создании задачи,
+	// дальше задача переносится через /tasks/{id}/list
+	ListID string `json:"listId" example:"64d0f5a1c2b3a4d5e6f70819"`
+}
+
+// RecurrenceDTO - новое правило повторения задачи
+type RecurrenceDTO struct {
+	Recurrence string `json:"recurrence" binding:"required" example:"FREQ=MONTHLY;BYMONTHDAY=1"`
+}
+
+// DueDTO - время выполнения задачи в день activeAt
+type DueDTO struct {
+	Time string `json:"time" example:"18:30"`
+	// TimeZone - часовой пояс IANA, по умолчанию UTC
+	TimeZone string `json:"timeZone" example:"Asia/Almaty"`
+}
+
+// TaskStatusDTO - целевой статус задачи
+type TaskStatusDTO struct {
+	Status string `json:"status" binding:"required,oneof=todo in_progress done archived" example:"in_progress"`
+}
+
+// TasksPatchDTO - JSON Merge Patch (RFC 7396) задачи: переданные поля заменяются,
+// отсутствующие остаются без изменений. null удаляет description, due и tags,
+// для остальных полей null - ошибка
+type TasksPatchDTO struct {
+	Title       *string `json:"title" example:"Купить"`
+	Description *string `json:"description" example:"**Молоко** и хлеб"`
+	ActiveAt    *string `json:"activeAt" example:"2023-08-04"`
+	Priority    *string `json:"priority" enums:"low,medium,high,urgent" example:"high"`
+	Due         *DueDTO `json:"due"`
+	// Tags заменяет все метки задачи
+	Tags         *[]string `json:"tags" example:"backend"`
+	AutoComplete *bool     `json:"autoComplete"`
+	// ClearDue - в запросе передан "due": null
+	ClearDue bool `json:"-"`
+}
+
+func (p *TasksPatchDTO) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+
+	// Обязательные поля задачи не удаляются, null для них - ошибка
+	for _, field := range []string{"title", "activeAt", "priority"} {
+		if value, ok := fields[field]; ok && string(value) == "null" {
+			return &json.UnmarshalTypeError{Value: "null", Type: reflect.TypeOf(""), Field: field}
+		}
+	}
+	if value, ok := fields["autoComplete"]; ok && string(value) == "null" {
+		return &json.UnmarshalTypeError{Value: "null", Type: reflect.TypeOf(false), Field: "autoComplete"}
+	}
+
+	type patch TasksPatchDTO
+	err = json.Unmarshal(data, (*patch)(p))
+	if err != nil {
+		return err
+	}
+
+	if value, ok := fields["description"]; ok && string(value) == "null" {
+		empty := ""
+		p.Description = &empty
+	}
+	if value, ok := fields["due"]; ok && string(value) == "null" {
+		p.ClearDue = true
+	}
+	if value, ok := fields["tags"]; ok && string(value) == "null" {
+		p.Tags = &[]string{}
+	}
+
+	return nil
 }
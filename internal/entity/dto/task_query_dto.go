@@ -0,0 +1,38 @@
+package dto
+
+import "github.com/khussa1n/todo-list/internal/entity"
+
+type TasksQueryDTO struct {
+	Status string `form:"status"`
+	// Priority - один или несколько приоритетов через запятую
+	Priority string `form:"priority"`
+	// Tags - метки, параметр tag можно повторять
+	Tags []string `form:"tag"`
+	// TagMatch - all: задачи со всеми метками, any: с любой из них
+	TagMatch string `form:"tagMatch"`
+	From     string `form:"from"`
+	To       string `form:"to"`
+	Period   string `form:"period"`
+	Limit    int64  `form:"limit"`
+	Cursor   string `form:"cursor"`
+	Sort     string `form:"sort"`
+}
+
+// TasksSearchDTO - полнотекстовый поиск задач с фильтрами по статусу и activeAt
+type TasksSearchDTO struct {
+	Q      string `form:"q" binding:"required"`
+	Status string `form:"status"`
+	// Priority - один или несколько приоритетов через запятую
+	Priority string `form:"priority"`
+	From     string `form:"from"`
+	To       string `form:"to"`
+	Period   string `form:"period"`
+	Limit    int64  `form:"limit"`
+	Cursor   string `form:"cursor"`
+}
+
+type TasksPageDTO struct {
+	Tasks      []entity.Tasks `json:"tasks"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+	Total      int64          `json:"total"`
+}
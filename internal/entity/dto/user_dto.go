@@ -0,0 +1,10 @@
+package dto
+
+type UserDTO struct {
+	Username string `json:"username" binding:"required,min=3,max=50"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+type TokenDTO struct {
+	Token string `json:"token"`
+}
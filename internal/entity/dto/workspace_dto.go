@@ -0,0 +1,25 @@
+package dto
+
+import "github.com/khussa1n/todo-list/internal/entity"
+
+type WorkspaceDTO struct {
+	Title string `json:"title" binding:"required,max=100" example:"Backend team"`
+}
+
+type WorkspacesDTO struct {
+	Workspaces []entity.Workspace `json:"workspaces"`
+}
+
+type MembersDTO struct {
+	Members []entity.Member `json:"members"`
+}
+
+// MemberRoleDTO - новая роль участника. Роль владельца не передается
+type MemberRoleDTO struct {
+	Role entity.Role `json:"role" binding:"required,oneof=editor viewer" swaggertype:"string" enums:"editor,viewer" example:"viewer"`
+}
+
+// InvitationDTO - роль, которую получит принявший приглашение
+type InvitationDTO struct {
+	Role entity.Role `json:"role" binding:"required,oneof=editor viewer" swaggertype:"string" enums:"editor,viewer" example:"editor"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+const DueTimeLayout = "15:04"
+
+// DueTime - время дня, к которому задача должна быть выполнена в день activeAt,
+// в часовом поясе IANA, например 18:30 Asia/Almaty
+type DueTime struct {
+	Time     string `json:"time" bson:"time" example:"18:30"`
+	TimeZone string `json:"timeZone" bson:"timeZone" example:"Asia/Almaty"`
+}
+
+// ParseDueTime проверяет время "15:04" и часовой пояс, пустой пояс - UTC
+func ParseDueTime(clock, zone string) (DueTime, error) {
+	if _, err := time.Parse(DueTimeLayout, clock); err != nil || len(clock) != len(DueTimeLayout) {
+		return DueTime{}, fmt.Errorf("invalid due time %q", clock)
+	}
+
+	if zone == "" {
+		zone = "UTC"
+	}
+
+	// Local зависит от сервера, а не от пользователя
+	if _, err := time.LoadLocation(zone); err != nil || zone == "Local" {
+		return DueTime{}, fmt.Errorf("invalid time zone %q", zone)
+	}
+
+	return DueTime{Time: clock, TimeZone: zone}, nil
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// Действия, которые попадают в историю задачи
+const (
+	HistoryCreate  = "create"
+	HistoryUpdate  = "update"
+	HistoryStatus  = "status"
+	HistoryDelete  = "delete"
+	HistoryRestore = "restore"
+	HistoryRevert  = "revert"
+)
+
+// historyFields - поля задачи, изменения которых видны в истории, в порядке вывода
+var historyFields = []string{
+	"title",
+	"listId",
+	"description",
+	"activeAt",
+	"status",
+	"priority",
+	"due",
+	"tags",
+	"checklist",
+	"autoComplete",
+	"dependencies",
+	"recurrence",
+	"occurrence",
+	"deletedAt",
+}
+
+// HistoryEntry - неизменяемая запись об изменении задачи
+type HistoryEntry struct {
+	ID     primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TaskID primitive.ObjectID `json:"taskId" bson:"taskId"`
+	// Owner - владелец задачи: пользователь или рабочее пространство
+	Owner primitive.ObjectID `json:"-" bson:"owner"`
+	// Version - версия задачи после изменения
+	Version int64  `json:"version" bson:"version" example:"3"`
+	Action  string `json:"action" bson:"action" enums:"create,update,status,delete,restore,revert" example:"update"`
+	// Actor - пользователь, который изменил задачу
+	Actor primitive.ObjectID `json:"actor" bson:"actor"`
+	At    time.Time          `json:"at" bson:"at"`
+	// RevertedTo - версия, к которой вернулась задача, только у отката
+	RevertedTo *int64        `json:"revertedTo,omitempty" bson:"revertedTo,omitempty" example:"1"`
+	Changes    []FieldChange `json:"changes" bson:"changes"`
+	// Snapshot - состояние задачи после изменения, из него восстанавливается версия при откате
+	Snapshot Tasks `json:"-" bson:"snapshot"`
+}
+
+// TaskRevision - задача до и после изменения, которое хранилище сделало само:
+// переименование метки, перенос задачи из удаленного списка
+type TaskRevision struct {
+	Before Tasks
+	After  Tasks
+}
+
+// FieldChange - значение поля задачи в JSON до и после изменения, null - поле не задано
+type FieldChange struct {
+	Field  string          `json:"field" bson:"field" example:"title"`
+	Before json.RawMessage `json:"before" bson:"before" swaggertype:"object"`
+	After  json.RawMessage `json:"after" bson:"after" swaggertype:"object"`
+}
+
+// TaskChanges возвращает поля, которые отличаются у before и after.
+// before == nil - задача только создана, все ее заданные поля считаются измененными
+func TaskChanges(before, after *Tasks) []FieldChange {
+	old := taskFields(before)
+	cur := taskFields(after)
+
+	changes := make([]FieldChange, 0)
+	for _, field := range historyFields {
+		b, a := fieldValue(old, field), fieldValue(cur, field)
+		if !bytes.Equal(b, a) {
+			changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+		}
+	}
+	return changes
+}
+
+// taskFields разбирает задачу на поля так же, как она отдается в ответе
+func taskFields(t *Tasks) map[string]json.RawMessage {
+	fields := make(map[string]json.RawMessage)
+	if t == nil {
+		return fields
+	}
+
+	// Задача всегда кодируется в JSON, ошибки здесь быть не может
+	b, _ := json.Marshal(t)
+	_ = json.Unmarshal(b, &fields)
+
+	return fields
+}
+
+func fieldValue(fields map[string]json.RawMessage, field string) json.RawMessage {
+	value, ok := fields[field]
+	if !ok {
+		return json.RawMessage("null")
+	}
+	return value
+}
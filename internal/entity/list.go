@@ -0,0 +1,11 @@
+package entity
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// List - именованный список задач пользователя (проект). Задачи без списка
+// находятся во входящих. Названия задач уникальны в пределах списка
+type List struct {
+	ID    primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Title string             `json:"title" bson:"title" example:"Sprint backlog"`
+	Owner primitive.ObjectID `json:"owner" bson:"owner"`
+}
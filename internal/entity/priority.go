@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Priority - приоритет задачи. В JSON передается строкой, в хранилище - числом,
+// чтобы задачи сортировались от low к urgent. Нулевое значение - medium,
+// приоритет по умолчанию
+type Priority int
+
+const (
+	PriorityLow Priority = iota - 1
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = map[Priority]string{
+	PriorityLow:    "low",
+	PriorityMedium: "medium",
+	PriorityHigh:   "high",
+	PriorityUrgent: "urgent",
+}
+
+// ParsePriority возвращает приоритет по названию: low, medium, high или urgent
+func ParsePriority(s string) (Priority, bool) {
+	for p, name := range priorityNames {
+		if name == s {
+			return p, true
+		}
+	}
+	return PriorityMedium, false
+}
+
+func (p Priority) String() string {
+	if name, ok := priorityNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Priority(%d)", int(p))
+}
+
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, ok := ParsePriority(s)
+	if !ok {
+		return fmt.Errorf("unknown priority %q", s)
+	}
+	*p = parsed
+
+	return nil
+}
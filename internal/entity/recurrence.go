@@ -0,0 +1,239 @@
+package entity
+
+import (
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Частоты повторения задачи
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+const (
+	// maxRecurrenceInterval - самый большой INTERVAL правила
+	maxRecurrenceInterval = 365
+	untilLayout           = "20060102"
+)
+
+// weekdayCodes - дни недели BYDAY в порядке RFC 5545, неделя начинается с понедельника
+var weekdayCodes = []struct {
+	code string
+	day  time.Weekday
+}{
+	{"MO", time.Monday},
+	{"TU", time.Tuesday},
+	{"WE", time.Wednesday},
+	{"TH", time.Thursday},
+	{"FR", time.Friday},
+	{"SA", time.Saturday},
+	{"SU", time.Sunday},
+}
+
+// RRule - правило повторения задачи, подмножество RRULE из RFC 5545:
+// FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY (только для WEEKLY, без номеров недель),
+// BYMONTHDAY (только для MONTHLY, один день 1..31) и UNTIL (дата YYYYMMDD)
+type RRule struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	// ByMonthDay - день месяца, в коротких месяцах задача переносится на последний день
+	ByMonthDay int
+	// Until - последняя дата повторения включительно, нулевая - без ограничения
+	Until Date
+}
+
+// ParseRRule разбирает правило вида "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", префикс RRULE: не обязателен
+func ParseRRule(s string) (RRule, error) {
+	r := RRule{Interval: 1}
+
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return r, fmt.Errorf("empty rule")
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return r, fmt.Errorf("invalid rule part %q", part)
+		}
+		if seen[key] {
+			return r, fmt.Errorf("duplicate %s", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			if value != FreqDaily && value != FreqWeekly && value != FreqMonthly {
+				return r, fmt.Errorf("unsupported FREQ %q", value)
+			}
+			r.Freq = value
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 || interval > maxRecurrenceInterval {
+				return r, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			r.Interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := parseWeekdayCode(code)
+				if !ok {
+					return r, fmt.Errorf("invalid BYDAY %q", code)
+				}
+				if !containsWeekday(r.ByDay, day) {
+					r.ByDay = append(r.ByDay, day)
+				}
+			}
+		case "BYMONTHDAY":
+			day, err := strconv.Atoi(value)
+			if err != nil || day < 1 || day > 31 {
+				return r, fmt.Errorf("invalid BYMONTHDAY %q", value)
+			}
+			r.ByMonthDay = day
+		case "UNTIL":
+			// Время UNTIL вида 20231231T235959Z отбрасывается, задачи повторяются по датам
+			until, err := time.Parse(untilLayout, strings.SplitN(value, "T", 2)[0])
+			if err != nil {
+				return r, fmt.Errorf("invalid UNTIL %q", value)
+			}
+			r.Until = Date{Time: until}
+		default:
+			return r, fmt.Errorf("unsupported rule part %s", key)
+		}
+	}
+
+	if r.Freq == "" {
+		return r, fmt.Errorf("FREQ is required")
+	}
+	if len(r.ByDay) > 0 && r.Freq != FreqWeekly {
+		return r, fmt.Errorf("BYDAY is supported only with FREQ=WEEKLY")
+	}
+	if r.ByMonthDay > 0 && r.Freq != FreqMonthly {
+		return r, fmt.Errorf("BYMONTHDAY is supported only with FREQ=MONTHLY")
+	}
+
+	return r, nil
+}
+
+// Anchor дополняет правило днями первого повторения start: днем недели для WEEKLY
+// без BYDAY и днем месяца для MONTHLY без BYMONTHDAY. Так правило не зависит от того,
+// на какую дату пришлось очередное повторение
+func (r RRule) Anchor(start Date) RRule {
+	if r.Freq == FreqWeekly && len(r.ByDay) == 0 {
+		r.ByDay = []time.Weekday{start.Weekday()}
+	}
+	if r.Freq == FreqMonthly && r.ByMonthDay == 0 {
+		r.ByMonthDay = start.Day()
+	}
+	return r
+}
+
+// Next возвращает дату повторения, следующую за after. Недели и месяцы INTERVAL
+// отсчитываются от after. false - повторений после UNTIL нет
+func (r RRule) Next(after Date) (Date, bool) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var next Date
+	switch r.Freq {
+	case FreqDaily:
+		next = after.AddDays(interval)
+	case FreqWeekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{after.Weekday()}
+		}
+
+		weekStart := after.AddDays(-mondayOffset(after.Weekday()))
+		for next = after.AddDays(1); ; next = next.AddDays(1) {
+			weeks := int(next.Sub(weekStart.Time)/(24*time.Hour)) / 7
+			if weeks%interval == 0 && containsWeekday(days, next.Weekday()) {
+				break
+			}
+		}
+	case FreqMonthly:
+		day := r.ByMonthDay
+		if day == 0 {
+			day = after.Day()
+		}
+
+		first := time.Date(after.Year(), after.Month()+time.Month(interval), 1, 0, 0, 0, 0, time.UTC)
+		if last := first.AddDate(0, 1, -1).Day(); day > last {
+			day = last
+		}
+		next = Date{Time: time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)}
+	default:
+		return Date{}, false
+	}
+
+	if !r.Until.IsZero() && next.After(r.Until.Time) {
+		return Date{}, false
+	}
+
+	return next, true
+}
+
+// String возвращает правило в каноническом виде, INTERVAL=1 опускается
+func (r RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		var codes []string
+		for _, wc := range weekdayCodes {
+			if containsWeekday(r.ByDay, wc.day) {
+				codes = append(codes, wc.code)
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.ByMonthDay > 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.ByMonthDay))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format(untilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Series возвращает ID серии повторений задачи: первая задача серии - сама себе серия
+func (t *Tasks) Series() primitive.ObjectID {
+	if t.SeriesID != nil {
+		return *t.SeriesID
+	}
+	return t.ID
+}
+
+func parseWeekdayCode(code string) (time.Weekday, bool) {
+	for _, wc := range weekdayCodes {
+		if wc.code == strings.TrimSpace(code) {
+			return wc.day, true
+		}
+	}
+	return 0, false
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// mondayOffset - сколько дней прошло с понедельника
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchTerms разбивает текст на слова для полнотекстового поиска:
+// буквы и цифры в нижнем регистре, без повторов
+func SearchTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	seen := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		if _, ok := seen[word]; ok {
+			continue
+		}
+		seen[word] = struct{}{}
+		terms = append(terms, word)
+	}
+
+	return terms
+}
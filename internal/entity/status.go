@@ -0,0 +1,36 @@
+package entity
+
+// Статусы задачи
+const (
+	StatusTodo       = "todo"
+	StatusInProgress = "in_progress"
+	StatusDone       = "done"
+	StatusArchived   = "archived"
+)
+
+// statusTransitions - разрешенные переходы: из статуса в набор целевых статусов
+var statusTransitions = map[string][]string{
+	StatusTodo:       {StatusInProgress, StatusDone, StatusArchived},
+	StatusInProgress: {StatusTodo, StatusDone, StatusArchived},
+	StatusDone:       {StatusTodo, StatusArchived},
+	StatusArchived:   {StatusTodo},
+}
+
+// OpenStatuses - статусы незавершенных задач, выводятся в списке по умолчанию
+var OpenStatuses = []string{StatusTodo, StatusInProgress}
+
+// IsValidStatus сообщает, входит ли status в набор статусов задачи
+func IsValidStatus(status string) bool {
+	_, ok := statusTransitions[status]
+	return ok
+}
+
+// CanTransition сообщает, разрешен ли переход задачи из статуса from в статус to
+func CanTransition(from, to string) bool {
+	for _, status := range statusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTagLength - длина метки в символах
+	MaxTagLength = 30
+	// MaxTaskTags - сколько меток может быть у задачи
+	MaxTaskTags = 20
+)
+
+// TagStat - метка и число задач с ней
+type TagStat struct {
+	Tag   string `json:"tag" bson:"_id" example:"backend"`
+	Count int64  `json:"count" bson:"count" example:"3"`
+}
+
+// RenameTag возвращает метки tags, в которых from заменена на to. Если to уже есть,
+// from просто убирается
+func RenameTag(tags []string, from, to string) []string {
+	merge := containsTag(tags, to)
+
+	renamed := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		switch {
+		case tag != from:
+			renamed = append(renamed, tag)
+		case !merge:
+			renamed = append(renamed, to)
+		}
+	}
+	return renamed
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeTag приводит метку к нижнему регистру без пробелов по краям.
+// Метка состоит из букв, цифр, - и _ и не длиннее MaxTagLength
+func NormalizeTag(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+
+	if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", false
+	}
+
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return "", false
+		}
+	}
+
+	return tag, true
+}
+
+// NormalizeTags нормализует метки и убирает повторы, сохраняя порядок
+func NormalizeTags(tags []string) ([]string, bool) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag, ok := NormalizeTag(tag)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxTaskTags {
+		return nil, false
+	}
+
+	return normalized, true
+}
@@ -1,10 +1,47 @@
 package entity
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
 
 type Tasks struct {
-	ID       primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Title    string             `json:"title" bson:"title"`
-	ActiveAt string             `json:"activeAt" bson:"activeAt"`
-	Status   string             `json:"status" bson:"status"`
+	ID    primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Title string             `json:"title" bson:"title"`
+	// ListID - список задачи, nil - входящие
+	ListID *primitive.ObjectID `json:"listId,omitempty" bson:"listId,omitempty" swaggertype:"string"`
+	// Description - описание в markdown
+	Description string   `json:"description,omitempty" bson:"description,omitempty"`
+	ActiveAt    Date     `json:"activeAt" bson:"activeAt" swaggertype:"string" example:"2023-08-04"`
+	Status      string   `json:"status" bson:"status"`
+	Priority    Priority `json:"priority" bson:"priority" swaggertype:"string" enums:"low,medium,high,urgent" example:"medium"`
+	// Due - необязательное время выполнения в день activeAt
+	Due *DueTime `json:"due,omitempty" bson:"due,omitempty"`
+	// Tags - метки задачи в нижнем регистре
+	Tags []string `json:"tags,omitempty" bson:"tags,omitempty" example:"backend"`
+	// Checklist - пункты чек-листа в порядке вывода
+	Checklist []ChecklistItem `json:"checklist,omitempty" bson:"checklist,omitempty"`
+	// Progress - процент выполненных пунктов чек-листа, вычисляется при ответе
+	Progress *int `json:"progress,omitempty" bson:"-" example:"50"`
+	// AutoComplete - перевести задачу в done, когда выполнены все пункты чек-листа
+	AutoComplete bool `json:"autoComplete,omitempty" bson:"autoComplete,omitempty"`
+	// Dependencies - ID задач, которые блокируют эту задачу: пока они открыты, ее нельзя выполнить
+	Dependencies []primitive.ObjectID `json:"dependencies,omitempty" bson:"dependencies,omitempty" swaggertype:"array,string"`
+	// Recurrence - правило повторения RRULE, есть только у открытого повторения серии
+	Recurrence string `json:"recurrence,omitempty" bson:"recurrence,omitempty" example:"FREQ=WEEKLY;BYDAY=MO,WE"`
+	// SeriesID - ID первой задачи серии повторений
+	SeriesID *primitive.ObjectID `json:"seriesId,omitempty" bson:"seriesId,omitempty" swaggertype:"string"`
+	// Occurrence - дата завершенного повторения серии. Название такой задачи
+	// может совпадать с названием следующего повторения
+	Occurrence *Date `json:"occurrence,omitempty" bson:"occurrence,omitempty" swaggertype:"string" example:"2023-08-04"`
+	// Weekend - activeAt выпадает на выходной или праздничный день
+	Weekend bool `json:"weekend" bson:"weekend"`
+	// Owner - пользователь или рабочее пространство, которому принадлежит задача
+	Owner primitive.ObjectID `json:"owner" bson:"owner"`
+	// Workspace - рабочее пространство задачи, nil - личная задача
+	Workspace *primitive.ObjectID `json:"workspaceId,omitempty" bson:"workspaceId,omitempty" swaggertype:"string"`
+	// Version увеличивается при каждом изменении задачи, отдается как ETag
+	Version int64 `json:"version" bson:"version"`
+	// DeletedAt - время перемещения задачи в корзину, nil для обычных задач
+	DeletedAt *time.Time `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
 }
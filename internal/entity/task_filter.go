@@ -0,0 +1,33 @@
+package entity
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// TaskFilter описывает выборку задач: фильтр, сортировку и страницу
+type TaskFilter struct {
+	Owner primitive.ObjectID
+	// ListID - задачи одного списка, нулевой - задачи всех списков и входящих
+	ListID primitive.ObjectID
+	// Statuses - задачи с любым из перечисленных статусов
+	Statuses []string
+	// Priorities - задачи с любым из перечисленных приоритетов
+	Priorities []Priority
+	// Tags - задачи с метками: со всеми, если TagsAll, иначе с любой из них
+	Tags    []string
+	TagsAll bool
+	// Deleted выбирает задачи из корзины вместо обычных
+	Deleted bool
+	// ActiveFrom и ActiveTo - границы activeAt включительно, нулевое значение - без границы
+	ActiveFrom Date
+	ActiveTo   Date
+	// Search - слова поиска: в задаче должно быть слово, начинающееся с каждого из них.
+	// Задачи упорядочиваются по релевантности, SortField и After не используются
+	Search []string
+	// SortField - поле сортировки: activeAt, title, priority или createdAt
+	SortField string
+	SortDesc  bool
+	// After - ID последней задачи предыдущей страницы (курсор)
+	After primitive.ObjectID
+	// Offset - сколько задач пропустить, используется для страниц поиска
+	Offset int64
+	Limit  int64
+}
@@ -0,0 +1,54 @@
+package entity
+
+// TaskPatch - частичное изменение задачи, nil поля не меняются
+type TaskPatch struct {
+	Title       *string
+	Description *string
+	ActiveAt    *Date
+	Weekend     *bool
+	Priority    *Priority
+	Due         *DueTime
+	// Tags заменяет все метки задачи, пустой список убирает их
+	Tags         *[]string
+	AutoComplete *bool
+	// ClearDue убирает время выполнения, Due при этом не используется
+	ClearDue bool
+}
+
+// IsEmpty сообщает, что изменение не затрагивает ни одного поля
+func (p *TaskPatch) IsEmpty() bool {
+	return p.Title == nil && p.Description == nil && p.ActiveAt == nil && p.Weekend == nil &&
+		p.Priority == nil && p.Due == nil && !p.ClearDue && p.Tags == nil && p.AutoComplete == nil
+}
+
+// Apply применяет изменение к задаче
+func (p *TaskPatch) Apply(t *Tasks) {
+	if p.Title != nil {
+		t.Title = *p.Title
+	}
+	if p.ActiveAt != nil {
+		t.ActiveAt = *p.ActiveAt
+	}
+	if p.Weekend != nil {
+		t.Weekend = *p.Weekend
+	}
+	if p.Description != nil {
+		t.Description = *p.Description
+	}
+	if p.Priority != nil {
+		t.Priority = *p.Priority
+	}
+	if p.Due != nil {
+		due := *p.Due
+		t.Due = &due
+	}
+	if p.ClearDue {
+		t.Due = nil
+	}
+	if p.Tags != nil {
+		t.Tags = append([]string(nil), *p.Tags...)
+	}
+	if p.AutoComplete != nil {
+		t.AutoComplete = *p.AutoComplete
+	}
+}
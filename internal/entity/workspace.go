@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// Role - роль участника рабочего пространства
+type Role string
+
+// Роли участников: владелец управляет пространством и участниками,
+// редактор меняет задачи и списки, читатель только просматривает их
+const (
+	RoleOwner  Role = "owner"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+// roleRanks - старшинство ролей: роль разрешает все, что разрешают младшие роли
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// Allows сообщает, разрешает ли роль r действия роли min
+func (r Role) Allows(min Role) bool {
+	return roleRanks[r] >= roleRanks[min]
+}
+
+// Workspace - рабочее пространство, общее для нескольких пользователей.
+// Задачи и списки пространства хранятся с владельцем - ID пространства
+type Workspace struct {
+	ID    primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Title string             `json:"title" bson:"title" example:"Backend team"`
+	// Owner - пользователь, создавший пространство
+	Owner primitive.ObjectID `json:"owner" bson:"owner"`
+	// Role - роль текущего пользователя, заполняется при ответе
+	Role Role `json:"role,omitempty" bson:"-" swaggertype:"string" enums:"owner,editor,viewer"`
+}
+
+// Member - участник рабочего пространства
+type Member struct {
+	Workspace primitive.ObjectID `json:"workspaceId" bson:"workspace"`
+	UserID    primitive.ObjectID `json:"userId" bson:"user"`
+	Username  string             `json:"username" bson:"username"`
+	Role      Role               `json:"role" bson:"role" swaggertype:"string" enums:"owner,editor,viewer"`
+}
+
+// Invitation - приглашение в рабочее пространство. Хранится только хэш токена,
+// сам токен отдается один раз при создании приглашения
+type Invitation struct {
+	Token     string             `json:"token" bson:"-"`
+	TokenHash string             `json:"-" bson:"_id"`
+	Workspace primitive.ObjectID `json:"workspaceId" bson:"workspace"`
+	Role      Role               `json:"role" bson:"role" swaggertype:"string" enums:"editor,viewer"`
+	CreatedBy primitive.ObjectID `json:"createdBy" bson:"createdBy"`
+	ExpiresAt time.Time          `json:"expiresAt" bson:"expiresAt"`
+}
+
+// Access - права пользователя на задачи и списки: свои или рабочего пространства
+type Access struct {
+	UserID primitive.ObjectID
+	// Owner - владелец задач: сам пользователь или рабочее пространство
+	Owner primitive.ObjectID
+	// Workspace - рабочее пространство, nil - личные задачи пользователя
+	Workspace *primitive.ObjectID
+	Role      Role
+}
+
+// PersonalAccess - права пользователя на свои задачи
+func PersonalAccess(userID primitive.ObjectID) *Access {
+	return &Access{UserID: userID, Owner: userID, Role: RoleOwner}
+}
+
+// Can сообщает, разрешены ли пользователю действия роли role
+func (a *Access) Can(role Role) bool {
+	return a.Role.Allows(role)
+}
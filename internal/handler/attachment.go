@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"log"
+	"mime"
+	"net/http"
+)
+
+// multipartOverhead - запас на заголовки multipart сверх размера файла
+const multipartOverhead = 64 << 10
+
+// uploadAttachment 	Upload attachment
+// @Summary      Upload attachment
+// @Description  Attach a file to the task. The file type is detected from its content and must be one of attachment.allowed_types, the size is limited by attachment.max_size
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         attachment
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param 		 id    path      string  true  "Task ID"
+// @Param 		 file  formData  file    true  "File"
+// @Success      201  {object}  entity.Attachment
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      403  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      413  {object}  dto.Error
+// @Failure      415  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/attachments [post]
+func (h *Handler) uploadAttachment(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	if h.MaxUploadSize > 0 {
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.MaxUploadSize+multipartOverhead)
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		log.Printf("read file err: %s \n", err.Error())
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			abortWithError(ctx, custom_error.ErrAttachmentTooLarge)
+			return
+		}
+		abortWithError(ctx, custom_error.ErrMissingFile)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		log.Printf("open file err: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.srvs.UploadAttachment(ctx, getAccess(ctx), id, header.Filename, header.Size, file)
+	if err != nil {
+		log.Printf("can not upload attachment: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, attachment)
+}
+
+// getAttachments 	Get attachments
+// @Summary      Get attachments
+// @Description  Get attachments of the task in the order they were uploaded
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         attachment
+// @Produce      json
+// @Param 		 id   path      string  true  "Task ID"
+// @Success      200  {object}  dto.AttachmentsDTO
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/attachments [get]
+func (h *Handler) getAttachments(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	attachments, err := h.srvs.GetAttachments(ctx, getAccess(ctx), id)
+	if err != nil {
+		log.Printf("can not get attachments: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	if attachments == nil {
+		attachments = make([]entity.Attachment, 0)
+	}
+
+	ctx.JSON(http.StatusOK, dto.AttachmentsDTO{Attachments: attachments})
+}
+
+// downloadAttachment 	Download attachment
+// @Summary      Download attachment
+// @Description  Download the attachment file. The content is checked against the checksum saved on upload, a damaged file is not sent
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         attachment
+// @Produce      octet-stream
+// @Param 		 id            path      string  true  "Task ID"
+// @Param 		 attachmentId  path      string  true  "Attachment ID"
+// @Success      200  {file}    file
+// @Header       200  {string}  Digest  "sha-256 of the content, base64"
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/attachments/{attachmentId} [get]
+func (h *Handler) downloadAttachment(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	attachmentID, err := parseIdFromPath(ctx, "attachmentId")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	attachment, content, err := h.srvs.GetAttachmentContent(ctx, getAccess(ctx), id, attachmentID)
+	if err != nil {
+		log.Printf("can not get attachment: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	if sum, err := hex.DecodeString(attachment.Checksum); err == nil {
+		ctx.Header("Digest", "sha-256="+base64.StdEncoding.EncodeToString(sum))
+	}
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Data(http.StatusOK, attachment.ContentType, content)
+}
+
+// deleteAttachment 	Delete attachment
+// @Summary      Delete attachment
+// @Description  Delete the attachment and its file
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         attachment
+// @Param 		 id            path      string  true  "Task ID"
+// @Param 		 attachmentId  path      string  true  "Attachment ID"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      403  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/attachments/{attachmentId} [delete]
+func (h *Handler) deleteAttachment(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	attachmentID, err := parseIdFromPath(ctx, "attachmentId")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	err = h.srvs.DeleteAttachment(ctx, getAccess(ctx), id, attachmentID)
+	if err != nil {
+		log.Printf("can not delete attachment: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
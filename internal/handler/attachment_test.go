@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	mock_service "github.com/khussa1n/todo-list/internal/service/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func multipartBody(t *testing.T, field, fileName string, content []byte) (*bytes.Buffer, string) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile(field, fileName)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return body, writer.FormDataContentType()
+}
+
+func Test_uploadAttachment(t *testing.T) {
+	taskID := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		field           string
+		content         []byte
+		maxUploadSize   int64
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:       "ok",
+			field:      "file",
+			content:    []byte("hello"),
+			httpStatus: http.StatusCreated,
+		},
+		{
+			name:         "no file field",
+			field:        "document",
+			content:      []byte("hello"),
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"missing_file","message":"multipart form field file is required","details":[{"field":"file","message":"multipart form field file is required"}]}`,
+		},
+		{
+			name:          "body over the limit",
+			field:         "file",
+			content:       bytes.Repeat([]byte("a"), 2*multipartOverhead),
+			maxUploadSize: 1,
+			httpStatus:    http.StatusRequestEntityTooLarge,
+			responseBody:  `{"code":"attachment_too_large","message":"file is larger than the attachment size limit","details":[{"field":"file","message":"file is larger than the attachment size limit"}]}`,
+		},
+		{
+			name:            "type not allowed",
+			field:           "file",
+			content:         []byte("PK\x03\x04"),
+			expectedSrvcErr: custom_error.ErrAttachmentType,
+			httpStatus:      http.StatusUnsupportedMediaType,
+			responseBody:    `{"code":"unsupported_attachment_type","message":"file type is not allowed for attachments","details":[{"field":"file","message":"file type is not allowed for attachments"}]}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+			handler.MaxUploadSize = testCase.maxUploadSize
+
+			recorder := httptest.NewRecorder()
+
+			access := entity.PersonalAccess(testUserID)
+
+			switch testCase.name {
+			case "ok":
+				mockService.EXPECT().UploadAttachment(gomock.Any(), access, taskID, "notes.txt", int64(5), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *entity.Access, _ primitive.ObjectID, name string, size int64, r io.Reader) (*entity.Attachment, error) {
+						got, err := io.ReadAll(r)
+						require.NoError(t, err)
+						require.Equal(t, testCase.content, got)
+						return &entity.Attachment{TaskID: taskID, FileName: name, ContentType: "text/plain", Size: size}, nil
+					}).Times(1)
+				break
+			case "no file field", "body over the limit":
+				mockService.EXPECT().UploadAttachment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "type not allowed":
+				mockService.EXPECT().UploadAttachment(gomock.Any(), access, taskID, "notes.txt", int64(4), gomock.Any()).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			}
+
+			body, contentType := multipartBody(t, testCase.field, "notes.txt", testCase.content)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/todo-list/tasks/"+taskID.Hex()+"/attachments", body)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+			request.Header.Set("Content-Type", contentType)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			if testCase.responseBody != "" {
+				require.Equal(t, testCase.responseBody, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func Test_downloadAttachment(t *testing.T) {
+	taskID := primitive.NewObjectID()
+	id := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:         "ok",
+			httpStatus:   http.StatusOK,
+			responseBody: "hello",
+		},
+		{
+			name:            "corrupted",
+			expectedSrvcErr: custom_error.ErrAttachmentCorrupted,
+			httpStatus:      http.StatusInternalServerError,
+			responseBody:    `{"code":"attachment_corrupted","message":"attachment content does not match its checksum"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			access := entity.PersonalAccess(testUserID)
+
+			switch testCase.name {
+			case "ok":
+				attachment := &entity.Attachment{
+					ID:          id,
+					TaskID:      taskID,
+					FileName:    "отчет.txt",
+					ContentType: "text/plain",
+					Size:        5,
+					Checksum:    "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+				}
+				mockService.EXPECT().GetAttachmentContent(gomock.Any(), access, taskID, id).Return(attachment, []byte("hello"), nil).Times(1)
+				break
+			case "corrupted":
+				mockService.EXPECT().GetAttachmentContent(gomock.Any(), access, taskID, id).Return(nil, nil, testCase.expectedSrvcErr).Times(1)
+				break
+			}
+
+			request, err := http.NewRequest(http.MethodGet, "/api/todo-list/tasks/"+taskID.Hex()+"/attachments/"+id.Hex(), nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+
+			if testCase.name == "ok" {
+				require.Equal(t, "text/plain", recorder.Header().Get("Content-Type"))
+				require.Equal(t, "sha-256=LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=", recorder.Header().Get("Digest"))
+				require.Equal(t, "attachment; filename*=utf-8''%D0%BE%D1%82%D1%87%D0%B5%D1%82.txt", recorder.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"log"
+	"net/http"
+)
+
+// signUp 	Register new user
+// @Summary      Sign up
+// @Description  Register new user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param request body dto.UserDTO true "req body"
+// @Success      201  {object}  entity.User
+// @Failure      400  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /auth/sign-up [post]
+func (h *Handler) signUp(ctx *gin.Context) {
+	var req dto.UserDTO
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	user, err := h.srvs.SignUp(ctx, &req)
+	if err != nil {
+		log.Printf("can not sign up: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, user)
+}
+
+// signIn 	Get access token
+// @Summary      Sign in
+// @Description  Get JWT access token for the user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param request body dto.UserDTO true "req body"
+// @Success      200  {object}  dto.TokenDTO
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /auth/sign-in [post]
+func (h *Handler) signIn(ctx *gin.Context) {
+	var req dto.UserDTO
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	token, err := h.srvs.SignIn(ctx, &req)
+	if err != nil {
+		log.Printf("can not sign in: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, token)
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_service "github.com/khussa1n/todo-list/internal/service/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_signUp(t *testing.T) {
+	id := primitive.NewObjectID()
+	table := []struct {
+		name            string
+		dtoJson         string
+		dto             dto.UserDTO
+		expectedSrvc    entity.User
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:         "ok",
+			dtoJson:      `{"username":"khussain","password":"password123"}`,
+			dto:          dto.UserDTO{Username: "khussain", Password: "password123"},
+			expectedSrvc: entity.User{ID: id, Username: "khussain", Password: "hash"},
+			httpStatus:   http.StatusCreated,
+			responseBody: fmt.Sprintf(`{"id":"%s","username":"khussain"}`, id.Hex()),
+		},
+		{
+			name:            "duplicate user",
+			dtoJson:         `{"username":"khussain","password":"password123"}`,
+			dto:             dto.UserDTO{Username: "khussain", Password: "password123"},
+			expectedSrvcErr: custom_error.ErrDuplicateUser,
+			httpStatus:      http.StatusConflict,
+			responseBody:    `{"code":"duplicate_user","message":"a user with the same username already exists","details":[{"field":"username","message":"a user with the same username already exists"}]}`,
+		},
+		{
+			name:         "validation failed",
+			dtoJson:      `{"username":"kh","password":"short"}`,
+			httpStatus:   http.StatusUnprocessableEntity,
+			responseBody: `{"code":"validation_failed","message":"request validation failed","details":[{"field":"username","message":"must be at least 3"},{"field":"password","message":"must be at least 8"}]}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok":
+				mockService.EXPECT().SignUp(gomock.Any(), &testCase.dto).Return(&testCase.expectedSrvc, nil).Times(1)
+				break
+			case "duplicate user":
+				mockService.EXPECT().SignUp(gomock.Any(), &testCase.dto).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			case "validation failed":
+				mockService.EXPECT().SignUp(gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			request, err := http.NewRequest(http.MethodPost, "/api/todo-list/auth/sign-up", bytes.NewBufferString(testCase.dtoJson))
+			require.NoError(t, err)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_signIn(t *testing.T) {
+	table := []struct {
+		name            string
+		dto             dto.UserDTO
+		expectedSrvc    dto.TokenDTO
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:         "ok",
+			dto:          dto.UserDTO{Username: "khussain", Password: "password123"},
+			expectedSrvc: dto.TokenDTO{Token: "token"},
+			httpStatus:   http.StatusOK,
+			responseBody: `{"token":"token"}`,
+		},
+		{
+			name:            "invalid credentials",
+			dto:             dto.UserDTO{Username: "khussain", Password: "password321"},
+			expectedSrvcErr: custom_error.ErrInvalidCredentials,
+			httpStatus:      http.StatusUnauthorized,
+			responseBody:    `{"code":"invalid_credentials","message":"invalid username or password"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok":
+				mockService.EXPECT().SignIn(gomock.Any(), &testCase.dto).Return(&testCase.expectedSrvc, nil).Times(1)
+				break
+			case "invalid credentials":
+				mockService.EXPECT().SignIn(gomock.Any(), &testCase.dto).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			}
+
+			body := fmt.Sprintf(`{"username":"%s","password":"%s"}`, testCase.dto.Username, testCase.dto.Password)
+			request, err := http.NewRequest(http.MethodPost, "/api/todo-list/auth/sign-in", bytes.NewBufferString(body))
+			require.NoError(t, err)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_userIdentity(t *testing.T) {
+	table := []struct {
+		name         string
+		header       string
+		httpStatus   int
+		responseBody string
+	}{
+		{
+			name:         "empty auth header",
+			httpStatus:   http.StatusUnauthorized,
+			responseBody: `{"code":"empty_auth_header","message":"empty auth header"}`,
+		},
+		{
+			name:         "invalid auth header",
+			header:       "Token abc",
+			httpStatus:   http.StatusUnauthorized,
+			responseBody: `{"code":"invalid_auth_header","message":"invalid auth header"}`,
+		},
+		{
+			name:         "invalid token",
+			header:       "Bearer abc",
+			httpStatus:   http.StatusUnauthorized,
+			responseBody: `{"code":"invalid_token","message":"invalid or expired token"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			if testCase.name == "invalid token" {
+				mockService.EXPECT().ParseToken(gomock.Any(), "abc").Return(primitive.NilObjectID, custom_error.ErrInvalidToken).Times(1)
+			}
+			mockService.EXPECT().GetTaskByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			request, err := http.NewRequest(http.MethodGet, "/api/todo-list/tasks/"+primitive.NewObjectID().Hex(), nil)
+			require.NoError(t, err)
+			if testCase.header != "" {
+				request.Header.Set("Authorization", testCase.header)
+			}
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"log"
+	"net/http"
+)
+
+// addChecklistItem 	Add checklist item
+// @Summary      Add checklist item
+// @Description  Add an item to the end of the task checklist. If autoComplete is on and every item is done, the task becomes done
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         checklist
+// @Accept       json
+// @Produce      json
+// @Param 		 id   path      string  true  "Task ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Param req body dto.ChecklistItemDTO true "req body"
+// @Success      201  {object}  entity.Tasks
+// @Header       201  {string}  ETag  "task version"
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/checklist [post]
+func (h *Handler) addChecklistItem(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.ChecklistItemDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	task, err := h.srvs.AddChecklistItem(ctx, getOwner(ctx), id, &req, version)
+	if err != nil {
+		log.Printf("can not add checklist item: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	setETag(ctx, task.Version)
+	ctx.JSON(http.StatusCreated, task)
+}
+
+// updateChecklistItem 	Update checklist item
+// @Summary      Update checklist item
+// @Description  Rename a checklist item or mark it done or not done. If autoComplete is on and every item is done, the task becomes done
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         checklist
+// @Accept       json
+// @Produce      json
+// @Param 		 id      path      string  true  "Task ID"
+// @Param 		 itemId  path      string  true  "Checklist item ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Param req body dto.ChecklistItemPatchDTO true "req body"
+// @Success      200  {object}  entity.Tasks
+// @Header       200  {string}  ETag  "task version"
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/checklist/{itemId} [patch]
+func (h *Handler) updateChecklistItem(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	itemID, err := parseIdFromPath(ctx, "itemId")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.ChecklistItemPatchDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	task, err := h.srvs.UpdateChecklistItem(ctx, getOwner(ctx), id, itemID, &req, version)
+	if err != nil {
+		log.Printf("can not update checklist item: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	setETag(ctx, task.Version)
+	ctx.JSON(http.StatusOK, task)
+}
+
+// deleteChecklistItem 	Delete checklist item
+// @Summary      Delete checklist item
+// @Description  Delete a checklist item
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         checklist
+// @Produce      json
+// @Param 		 id      path      string  true  "Task ID"
+// @Param 		 itemId  path      string  true  "Checklist item ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Success      200  {object}  entity.Tasks
+// @Header       200  {string}  ETag  "task version"
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/checklist/{itemId} [delete]
+func (h *Handler) deleteChecklistItem(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	itemID, err := parseIdFromPath(ctx, "itemId")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	task, err := h.srvs.DeleteChecklistItem(ctx, getOwner(ctx), id, itemID, version)
+	if err != nil {
+		log.Printf("can not delete checklist item: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	setETag(ctx, task.Version)
+	ctx.JSON(http.StatusOK, task)
+}
+
+// reorderChecklist 	Reorder checklist
+// @Summary      Reorder checklist
+// @Description  Put checklist items in the given order. ids must list every item of the checklist exactly once
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         checklist
+// @Accept       json
+// @Produce      json
+// @Param 		 id   path      string  true  "Task ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Param req body dto.ChecklistOrderDTO true "req body"
+// @Success      200  {object}  entity.Tasks
+// @Header       200  {string}  ETag  "task version"
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/checklist/order [put]
+func (h *Handler) reorderChecklist(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.ChecklistOrderDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	task, err := h.srvs.ReorderChecklist(ctx, getOwner(ctx), id, &req, version)
+	if err != nil {
+		log.Printf("can not reorder checklist: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	setETag(ctx, task.Version)
+	ctx.JSON(http.StatusOK, task)
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_service "github.com/khussa1n/todo-list/internal/service/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_addChecklistItem(t *testing.T) {
+	id, itemID := primitive.NewObjectID(), primitive.NewObjectID()
+	progress := 0
+
+	table := []struct {
+		name            string
+		body            string
+		expectedService entity.Tasks
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name: "ok",
+			body: `{"title":"Паспорт"}`,
+			expectedService: entity.Tasks{ID: id, Title: "Поездка", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: testUserID, Version: 2,
+				Checklist: []entity.ChecklistItem{{ID: itemID, Title: "Паспорт"}}, Progress: &progress},
+			httpStatus: http.StatusCreated,
+			responseBody: fmt.Sprintf(`{"id":"%s","title":"Поездка","activeAt":"2023-08-04","status":"todo","priority":"medium","checklist":[{"id":"%s","title":"Паспорт","done":false}],"progress":0,"weekend":false,"owner":"%s","version":2}`,
+				id.Hex(), itemID.Hex(), testUserID.Hex()),
+		},
+		{
+			name:         "empty title",
+			body:         `{}`,
+			httpStatus:   http.StatusUnprocessableEntity,
+			responseBody: `{"code":"validation_failed","message":"request validation failed","details":[{"field":"title","message":"is required"}]}`,
+		},
+		{
+			name:            "checklist full",
+			body:            `{"title":"Паспорт"}`,
+			expectedSrvcErr: custom_error.ErrChecklistFull,
+			httpStatus:      http.StatusUnprocessableEntity,
+			responseBody:    `{"code":"checklist_full","message":"checklist can not have more than 100 items"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok":
+				mockService.EXPECT().AddChecklistItem(gomock.Any(), testUserID, id, &dto.ChecklistItemDTO{Title: "Паспорт"}, int64(0)).Return(&testCase.expectedService, nil).Times(1)
+				break
+			case "checklist full":
+				mockService.EXPECT().AddChecklistItem(gomock.Any(), testUserID, id, gomock.Any(), int64(0)).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			case "empty title":
+				mockService.EXPECT().AddChecklistItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			request, err := http.NewRequest(http.MethodPost, "/api/todo-list/tasks/"+id.Hex()+"/checklist", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+			if testCase.httpStatus == http.StatusCreated {
+				require.Equal(t, `"2"`, recorder.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func Test_updateChecklistItem(t *testing.T) {
+	id, itemID := primitive.NewObjectID(), primitive.NewObjectID()
+	done := true
+
+	table := []struct {
+		name            string
+		itemID          string
+		ifMatch         string
+		expectedSrvcErr error
+		httpStatus      int
+	}{
+		{
+			name:       "ok",
+			itemID:     itemID.Hex(),
+			ifMatch:    `"3"`,
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid item id",
+			itemID:     "1234",
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:            "item not found",
+			itemID:          itemID.Hex(),
+			expectedSrvcErr: custom_error.ErrChecklistItemNotFound,
+			httpStatus:      http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok":
+				mockService.EXPECT().UpdateChecklistItem(gomock.Any(), testUserID, id, itemID, &dto.ChecklistItemPatchDTO{Done: &done}, int64(3)).
+					Return(&entity.Tasks{ID: id, Version: 4}, nil).Times(1)
+				break
+			case "item not found":
+				mockService.EXPECT().UpdateChecklistItem(gomock.Any(), testUserID, id, itemID, gomock.Any(), int64(0)).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			case "invalid item id":
+				mockService.EXPECT().UpdateChecklistItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			url := "/api/todo-list/tasks/" + id.Hex() + "/checklist/" + testCase.itemID
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewBufferString(`{"done":true}`))
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+			if testCase.ifMatch != "" {
+				request.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			if testCase.httpStatus == http.StatusOK {
+				require.Equal(t, `"4"`, recorder.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func Test_reorderChecklist(t *testing.T) {
+	id, item1, item2 := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		body            string
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:       "ok",
+			body:       fmt.Sprintf(`{"ids":["%s","%s"]}`, item2.Hex(), item1.Hex()),
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:         "invalid id",
+			body:         `{"ids":["1234"]}`,
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"invalid_input_body","message":"invalid input body"}`,
+		},
+		{
+			name:            "invalid order",
+			body:            fmt.Sprintf(`{"ids":["%s"]}`, item2.Hex()),
+			expectedSrvcErr: custom_error.ErrInvalidChecklistOrder,
+			httpStatus:      http.StatusUnprocessableEntity,
+			responseBody:    `{"code":"invalid_checklist_order","message":"ids must list every checklist item exactly once","details":[{"field":"ids","message":"ids must list every checklist item exactly once"}]}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok":
+				mockService.EXPECT().ReorderChecklist(gomock.Any(), testUserID, id, &dto.ChecklistOrderDTO{IDs: []primitive.ObjectID{item2, item1}}, int64(0)).
+					Return(&entity.Tasks{ID: id, Version: 2}, nil).Times(1)
+				break
+			case "invalid order":
+				mockService.EXPECT().ReorderChecklist(gomock.Any(), testUserID, id, gomock.Any(), int64(0)).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			case "invalid id":
+				mockService.EXPECT().ReorderChecklist(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			request, err := http.NewRequest(http.MethodPut, "/api/todo-list/tasks/"+id.Hex()+"/checklist/order", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			if testCase.responseBody != "" {
+				require.Equal(t, testCase.responseBody, recorder.Body.String())
+			}
+		})
+	}
+}
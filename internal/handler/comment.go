@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"log"
+	"net/http"
+)
+
+// getComments 	Get comments
+// @Summary      Get comments
+// @Description  Get comments of the task in the order they were written
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         comment
+// @Produce      json
+// @Param 		 id   path      string  true  "Task ID"
+// @Success      200  {object}  dto.CommentsDTO
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/comments [get]
+func (h *Handler) getComments(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	comments, err := h.srvs.GetComments(ctx, getAccess(ctx), id)
+	if err != nil {
+		log.Printf("can not get comments: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	if comments == nil {
+		comments = make([]entity.Comment, 0)
+	}
+
+	ctx.JSON(http.StatusOK, dto.CommentsDTO{Comments: comments})
+}
+
+// createComment 	Create comment
+// @Summary      Create comment
+// @Description  Add a markdown comment to the task on behalf of the user
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         comment
+// @Accept       json
+// @Produce      json
+// @Param 		 id   path      string  true  "Task ID"
+// @Param request body dto.CommentDTO true "req body"
+// @Success      201  {object}  entity.Comment
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      403  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/comments [post]
+func (h *Handler) createComment(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.CommentDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	comment, err := h.srvs.CreateComment(ctx, getAccess(ctx), id, &req)
+	if err != nil {
+		log.Printf("can not create comment: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, comment)
+}
+
+// updateComment 	Update comment
+// @Summary      Update comment
+// @Description  Replace the comment body, the previous body is kept in the edit history. Only the author can do it
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         comment
+// @Accept       json
+// @Produce      json
+// @Param 		 id         path      string  true  "Task ID"
+// @Param 		 commentId  path      string  true  "Comment ID"
+// @Param request body dto.CommentDTO true "req body"
+// @Success      200  {object}  entity.Comment
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      403  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/comments/{commentId} [put]
+func (h *Handler) updateComment(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	commentID, err := parseIdFromPath(ctx, "commentId")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.CommentDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	comment, err := h.srvs.UpdateComment(ctx, getAccess(ctx), id, commentID, &req)
+	if err != nil {
+		log.Printf("can not update comment: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comment)
+}
+
+// deleteComment 	Delete comment
+// @Summary      Delete comment
+// @Description  Delete a comment. The author or the workspace owner can do it
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         comment
+// @Param 		 id         path      string  true  "Task ID"
+// @Param 		 commentId  path      string  true  "Comment ID"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      403  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/comments/{commentId} [delete]
+func (h *Handler) deleteComment(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	commentID, err := parseIdFromPath(ctx, "commentId")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	err = h.srvs.DeleteComment(ctx, getAccess(ctx), id, commentID)
+	if err != nil {
+		log.Printf("can not delete comment: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
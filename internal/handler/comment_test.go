@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_service "github.com/khussa1n/todo-list/internal/service/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_getComments(t *testing.T) {
+	taskID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+	created := time.Date(2023, 8, 4, 12, 0, 0, 0, time.UTC)
+
+	table := []struct {
+		name            string
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:         "ok",
+			httpStatus:   http.StatusOK,
+			responseBody: fmt.Sprintf(`{"comments":[{"id":"%s","taskId":"%s","author":"%s","authorName":"dana","body":"Нужен **паспорт**","createdAt":"2023-08-04T12:00:00Z"}]}`, commentID.Hex(), taskID.Hex(), testUserID.Hex()),
+		},
+		{
+			name:         "no comments",
+			httpStatus:   http.StatusOK,
+			responseBody: `{"comments":[]}`,
+		},
+		{
+			name:            "task not found",
+			expectedSrvcErr: custom_error.ErrTaskNotFound,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"task_not_found","message":"task not found"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			access := entity.PersonalAccess(testUserID)
+
+			switch testCase.name {
+			case "ok":
+				comments := []entity.Comment{{ID: commentID, TaskID: taskID, Owner: testUserID, Author: testUserID, AuthorName: "dana", Body: "Нужен **паспорт**", CreatedAt: created}}
+				mockService.EXPECT().GetComments(gomock.Any(), access, taskID).Return(comments, nil).Times(1)
+				break
+			case "no comments":
+				mockService.EXPECT().GetComments(gomock.Any(), access, taskID).Return(nil, nil).Times(1)
+				break
+			case "task not found":
+				mockService.EXPECT().GetComments(gomock.Any(), access, taskID).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			}
+
+			request, err := http.NewRequest(http.MethodGet, "/api/todo-list/tasks/"+taskID.Hex()+"/comments", nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_updateComment(t *testing.T) {
+	taskID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		body            string
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:         "empty body",
+			body:         `{"body":""}`,
+			httpStatus:   http.StatusUnprocessableEntity,
+			responseBody: `{"code":"validation_failed","message":"request validation failed","details":[{"field":"body","message":"is required"}]}`,
+		},
+		{
+			name:            "blank body",
+			body:            `{"body":"   "}`,
+			expectedSrvcErr: custom_error.ErrEmptyComment,
+			httpStatus:      http.StatusUnprocessableEntity,
+			responseBody:    `{"code":"empty_comment","message":"comment can not be empty","details":[{"field":"body","message":"comment can not be empty"}]}`,
+		},
+		{
+			name:            "not the author",
+			body:            `{"body":"Нужен паспорт"}`,
+			expectedSrvcErr: custom_error.ErrNotCommentAuthor,
+			httpStatus:      http.StatusForbidden,
+			responseBody:    `{"code":"not_comment_author","message":"only the author can edit the comment"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			access := entity.PersonalAccess(testUserID)
+
+			switch testCase.name {
+			case "empty body":
+				mockService.EXPECT().UpdateComment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "blank body":
+				req := dto.CommentDTO{Body: "   "}
+				mockService.EXPECT().UpdateComment(gomock.Any(), access, taskID, commentID, &req).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			case "not the author":
+				req := dto.CommentDTO{Body: "Нужен паспорт"}
+				mockService.EXPECT().UpdateComment(gomock.Any(), access, taskID, commentID, &req).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			}
+
+			request, err := http.NewRequest(http.MethodPut, "/api/todo-list/tasks/"+taskID.Hex()+"/comments/"+commentID.Hex(), bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
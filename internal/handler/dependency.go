@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+// addDependency 	Add dependency
+// @Summary      Add dependency
+// @Description  Mark the task as blocked by another task. A blocked task can not be marked done while its blockers are open. Dependencies that would form a cycle are rejected
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         dependency
+// @Param 		 id         path      string  true  "Task ID"
+// @Param 		 blockerId  path      string  true  "ID of the blocking task"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/dependencies/{blockerId} [put]
+func (h *Handler) addDependency(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	blockerID, err := parseIdFromPath(ctx, "blockerId")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	err = h.srvs.AddDependency(ctx, getOwner(ctx), id, blockerID, version)
+	if err != nil {
+		log.Printf("can not add dependency: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// removeDependency 	Remove dependency
+// @Summary      Remove dependency
+// @Description  The task is no longer blocked by the given task
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         dependency
+// @Param 		 id         path      string  true  "Task ID"
+// @Param 		 blockerId  path      string  true  "ID of the blocking task"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/dependencies/{blockerId} [delete]
+func (h *Handler) removeDependency(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	blockerID, err := parseIdFromPath(ctx, "blockerId")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	err = h.srvs.RemoveDependency(ctx, getOwner(ctx), id, blockerID, version)
+	if err != nil {
+		log.Printf("can not remove dependency: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// getDependencyGraph 	Get dependency graph
+// @Summary      Get dependency graph
+// @Description  Tasks that block the task directly or through other tasks, each one once, and the edges between them. Tasks in trash are left out
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         dependency
+// @Produce      json
+// @Param 		 id   path      string  true  "Task ID"
+// @Success      200  {object}  entity.DependencyGraph
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/graph [get]
+func (h *Handler) getDependencyGraph(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	graph, err := h.srvs.GetDependencyGraph(ctx, getOwner(ctx), id)
+	if err != nil {
+		log.Printf("can not get dependency graph: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, graph)
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	mock_service "github.com/khussa1n/todo-list/internal/service/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_addDependency(t *testing.T) {
+	id, blockerID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		blockerID       string
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:       "ok",
+			blockerID:  blockerID.Hex(),
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:            "cycle",
+			blockerID:       blockerID.Hex(),
+			expectedSrvcErr: custom_error.ErrDependencyCycle,
+			httpStatus:      http.StatusConflict,
+			responseBody:    `{"code":"dependency_cycle","message":"dependency would create a cycle"}`,
+		},
+		{
+			name:            "blocker not found",
+			blockerID:       blockerID.Hex(),
+			expectedSrvcErr: custom_error.ErrBlockerNotFound,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"blocker_not_found","message":"blocking task not found"}`,
+		},
+		{
+			name:         "invalid blocker id",
+			blockerID:    "123",
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"invalid_id","message":"invalid id param"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok", "cycle", "blocker not found":
+				mockService.EXPECT().AddDependency(gomock.Any(), testUserID, id, blockerID, int64(0)).Return(testCase.expectedSrvcErr).Times(1)
+				break
+			case "invalid blocker id":
+				mockService.EXPECT().AddDependency(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			request, err := http.NewRequest(http.MethodPut, "/api/todo-list/tasks/"+id.Hex()+"/dependencies/"+testCase.blockerID, nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_getDependencyGraph(t *testing.T) {
+	id, blockerID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := mock_service.NewMockService(controller)
+	mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+	mockService.EXPECT().GetDependencyGraph(gomock.Any(), testUserID, id).Return(&entity.DependencyGraph{
+		Root:  id,
+		Nodes: []entity.DependencyNode{{ID: id, Title: "Релиз", Status: "todo"}, {ID: blockerID, Title: "Тесты", Status: "in_progress"}},
+		Edges: []entity.DependencyEdge{{Task: id, BlockedBy: blockerID}},
+	}, nil).Times(1)
+
+	handler := New(mockService)
+
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/todo-list/tasks/"+id.Hex()+"/graph", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer "+testToken)
+
+	handler.InitRouter().ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, fmt.Sprintf(`{"root":"%[1]s","nodes":[{"id":"%[1]s","title":"Релиз","status":"todo"},{"id":"%[2]s","title":"Тесты","status":"in_progress"}],"edges":[{"task":"%[1]s","blockedBy":"%[2]s"}]}`,
+		id.Hex(), blockerID.Hex()), recorder.Body.String())
+}
@@ -3,12 +3,17 @@ package handler
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
 	"github.com/khussa1n/todo-list/internal/service"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strconv"
+	"strings"
 )
 
 type Handler struct {
 	srvs service.Service
+	// MaxUploadSize - наибольший размер файла вложения, ограничивает тело запроса загрузки. 0 - без ограничения
+	MaxUploadSize int64
 }
 
 func New(srvs service.Service) *Handler {
@@ -30,3 +35,43 @@ func parseIdFromPath(c *gin.Context, param string) (primitive.ObjectID, error) {
 
 	return id, nil
 }
+
+// getUserID возвращает ID пользователя, сохраненный userIdentity
+func getUserID(c *gin.Context) primitive.ObjectID {
+	return c.MustGet(userCtx).(primitive.ObjectID)
+}
+
+// getAccess возвращает права на задачи, сохраненные workspaceAccess
+func getAccess(c *gin.Context) *entity.Access {
+	return c.MustGet(accessCtx).(*entity.Access)
+}
+
+// getOwner возвращает владельца запрашиваемых задач: пользователя или рабочее пространство
+func getOwner(c *gin.Context) primitive.ObjectID {
+	return getAccess(c).Owner
+}
+
+// parseIfMatch возвращает версию задачи из заголовка If-Match.
+// Без заголовка или со значением * возвращает 0 - любая версия
+func parseIfMatch(c *gin.Context) (int64, error) {
+	header := strings.TrimSpace(c.GetHeader(ifMatchHeader))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, custom_error.ErrInvalidIfMatch
+	}
+
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, custom_error.ErrInvalidIfMatch
+	}
+
+	return version, nil
+}
+
+// setETag отдает версию задачи в заголовке ETag
+func setETag(c *gin.Context, version int64) {
+	c.Header(etagHeader, `"`+strconv.FormatInt(version, 10)+`"`)
+}
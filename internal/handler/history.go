@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// getHistory 	Get task history
+// @Summary      Get task history
+// @Description  Get every change of the task from the oldest version to the newest: who changed it, when, and the fields before and after the change. The history of a task in the trash is available too
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         history
+// @Produce      json
+// @Param 		 id   path      string  true  "Task ID"
+// @Success      200  {object}  dto.HistoryDTO
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/history [get]
+func (h *Handler) getHistory(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	history, err := h.srvs.GetHistory(ctx, getOwner(ctx), id)
+	if err != nil {
+		log.Printf("can not get task history: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	if history == nil {
+		history = make([]entity.HistoryEntry, 0)
+	}
+
+	ctx.JSON(http.StatusOK, dto.HistoryDTO{History: history})
+}
+
+// revertTask 	Revert task
+// @Summary      Revert task
+// @Description  Restore title, description, activeAt, priority, due, tags and autoComplete of the task from a version of its history. The revert is saved as a new version, status, checklist, list, recurrence and dependencies stay as they are
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         history
+// @Produce      json
+// @Param 		 id       path      string  true  "Task ID"
+// @Param 		 version  path      int     true  "Task version from the history"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Success      200  {object}  entity.Tasks
+// @Header       200  {string}  ETag  "task version"
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/revert/{version} [post]
+func (h *Handler) revertTask(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	target, err := strconv.ParseInt(ctx.Param("version"), 10, 64)
+	if err != nil || target <= 0 {
+		abortWithError(ctx, custom_error.ErrInvalidHistoryVersion)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	task, err := h.srvs.RevertTask(ctx, getOwner(ctx), id, target, version)
+	if err != nil {
+		log.Printf("can not revert task: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	setETag(ctx, task.Version)
+	ctx.JSON(http.StatusOK, task)
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	mock_service "github.com/khussa1n/todo-list/internal/service/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_getHistory(t *testing.T) {
+	id, entryID := primitive.NewObjectID(), primitive.NewObjectID()
+	at := time.Date(2023, 8, 4, 12, 0, 0, 0, time.UTC)
+
+	table := []struct {
+		name            string
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:       "ok",
+			httpStatus: http.StatusOK,
+			responseBody: fmt.Sprintf(`{"history":[{"id":"%s","taskId":"%s","version":2,"action":"update","actor":"%s","at":"2023-08-04T12:00:00Z","changes":[{"field":"title","before":"Купить","after":"Продать"}]}]}`,
+				entryID.Hex(), id.Hex(), testUserID.Hex()),
+		},
+		{
+			name:         "no history",
+			httpStatus:   http.StatusOK,
+			responseBody: `{"history":[]}`,
+		},
+		{
+			name:            "task not found",
+			expectedSrvcErr: custom_error.ErrTaskNotFound,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"task_not_found","message":"task not found"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok":
+				history := []entity.HistoryEntry{{ID: entryID, TaskID: id, Owner: testUserID, Version: 2, Action: entity.HistoryUpdate, Actor: testUserID, At: at,
+					Changes: []entity.FieldChange{{Field: "title", Before: json.RawMessage(`"Купить"`), After: json.RawMessage(`"Продать"`)}}}}
+				mockService.EXPECT().GetHistory(gomock.Any(), testUserID, id).Return(history, nil).Times(1)
+				break
+			case "no history":
+				mockService.EXPECT().GetHistory(gomock.Any(), testUserID, id).Return(nil, nil).Times(1)
+				break
+			case "task not found":
+				mockService.EXPECT().GetHistory(gomock.Any(), testUserID, id).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			}
+
+			request, err := http.NewRequest(http.MethodGet, "/api/todo-list/tasks/"+id.Hex()+"/history", nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_revertTask(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		version         string
+		ifMatch         string
+		expectedService entity.Tasks
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:            "ok",
+			version:         "1",
+			ifMatch:         `"3"`,
+			expectedService: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Priority: entity.PriorityMedium, Owner: testUserID, Version: 4},
+			httpStatus:      http.StatusOK,
+			responseBody: fmt.Sprintf(`{"id":"%s","title":"Купить","activeAt":"2023-08-04","status":"todo","priority":"medium","weekend":false,"owner":"%s","version":4}`,
+				id.Hex(), testUserID.Hex()),
+		},
+		{
+			name:         "invalid version",
+			version:      "0",
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"invalid_version","message":"version must be a positive number"}`,
+		},
+		{
+			name:            "version not in history",
+			version:         "7",
+			expectedSrvcErr: custom_error.ErrHistoryNotFound,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"history_not_found","message":"task version not found in history"}`,
+		},
+		{
+			name:            "stale version",
+			version:         "1",
+			ifMatch:         `"2"`,
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+			httpStatus:      http.StatusPreconditionFailed,
+			responseBody:    `{"code":"precondition_failed","message":"task was modified, its version does not match If-Match"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok":
+				mockService.EXPECT().RevertTask(gomock.Any(), testUserID, id, int64(1), int64(3)).Return(&testCase.expectedService, nil).Times(1)
+				break
+			case "invalid version":
+				mockService.EXPECT().RevertTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "version not in history":
+				mockService.EXPECT().RevertTask(gomock.Any(), testUserID, id, int64(7), int64(0)).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			case "stale version":
+				mockService.EXPECT().RevertTask(gomock.Any(), testUserID, id, int64(1), int64(2)).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			}
+
+			request, err := http.NewRequest(http.MethodPost, "/api/todo-list/tasks/"+id.Hex()+"/revert/"+testCase.version, nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+			if testCase.ifMatch != "" {
+				request.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+			if testCase.httpStatus == http.StatusOK {
+				require.Equal(t, `"4"`, recorder.Header().Get("ETag"))
+			}
+		})
+	}
+}
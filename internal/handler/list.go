@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"log"
+	"net/http"
+)
+
+// createList 	Create list
+// @Summary      Create list
+// @Description  Create a named task list (project). Task titles are unique within a list
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         list
+// @Accept       json
+// @Produce      json
+// @Param request body dto.ListDTO true "req body"
+// @Success      201  {object}  entity.List
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /lists [post]
+func (h *Handler) createList(ctx *gin.Context) {
+	var req dto.ListDTO
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	list, err := h.srvs.CreateList(ctx, getOwner(ctx), &req)
+	if err != nil {
+		log.Printf("can not create list: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, list)
+}
+
+// getLists 	Get lists
+// @Summary      Get lists
+// @Description  Get the user's task lists in the order they were created
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         list
+// @Produce      json
+// @Success      200  {object}  dto.ListsDTO
+// @Failure      401  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /lists [get]
+func (h *Handler) getLists(ctx *gin.Context) {
+	lists, err := h.srvs.GetLists(ctx, getOwner(ctx))
+	if err != nil {
+		log.Printf("can not get lists: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	if lists == nil {
+		lists = make([]entity.List, 0)
+	}
+
+	ctx.JSON(http.StatusOK, dto.ListsDTO{Lists: lists})
+}
+
+// getListByID 	Get list by id
+// @Summary      Get list by id
+// @Description  Get list by id
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         list
+// @Produce      json
+// @Param 		 id   path      string  true  "List ID"
+// @Success      200  {object}  entity.List
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /lists/{id} [get]
+func (h *Handler) getListByID(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	list, err := h.srvs.GetListByID(ctx, getOwner(ctx), id)
+	if err != nil {
+		log.Printf("can not get list: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, list)
+}
+
+// updateList 	Update list
+// @Summary      Update list
+// @Description  Rename a list
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         list
+// @Accept       json
+// @Param 		 id   path      string  true  "List ID"
+// @Param request body dto.ListDTO true "req body"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /lists/{id} [put]
+func (h *Handler) updateList(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.ListDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	err = h.srvs.UpdateList(ctx, getOwner(ctx), id, &req)
+	if err != nil {
+		log.Printf("can not update list: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// deleteList 	Delete list
+// @Summary      Delete list
+// @Description  Delete a list without tasks. Tasks of the list in trash are removed for good
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         list
+// @Param 		 id   path      string  true  "List ID"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /lists/{id} [delete]
+func (h *Handler) deleteList(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	err = h.srvs.DeleteList(ctx, getOwner(ctx), id)
+	if err != nil {
+		log.Printf("can not delete list: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// getListTasks 	Get list tasks
+// @Summary      Get list tasks
+// @Description  Get a page of the list's tasks with the same filters as GET /tasks
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         list
+// @Produce      json
+// @Param 		 id        path      string  true  "List ID"
+// @Param		 status    query     string false "todo, in_progress, done or archived (default todo and in_progress)"
+// @Param		 priority  query     string false "low, medium, high or urgent, several separated by comma"
+// @Param		 tag       query     []string false "tags, repeat the parameter for several" collectionFormat(multi)
+// @Param		 tagMatch  query     string false "all - tasks with every tag, any - with at least one (default all)"
+// @Param		 from      query     string false "activeAt from, inclusive (2006-01-02)"
+// @Param		 to        query     string false "activeAt to, inclusive (2006-01-02)"
+// @Param		 period    query     string false "overdue, today or week, instead of from/to"
+// @Param		 limit     query     int    false "page size, 1..100 (default 20)"
+// @Param		 cursor    query     string false "nextCursor from the previous page"
+// @Param		 sort      query     string false "activeAt, title, priority or createdAt, prefix with - for descending (default createdAt)"
+// @Success      200  {object}  dto.TasksPageDTO
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /lists/{id}/tasks [get]
+func (h *Handler) getListTasks(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var query dto.TasksQueryDTO
+	err = ctx.ShouldBindQuery(&query)
+	if err != nil {
+		log.Printf("bind query err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidQueryParams, err)
+		return
+	}
+
+	page, err := h.srvs.GetListTasks(ctx, getOwner(ctx), id, &query)
+	if err != nil {
+		log.Printf("can not get list tasks: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// moveTask 	Move task
+// @Summary      Move task to another list
+// @Description  Move the task to a list, an empty listId moves it to the inbox. The title must be free in the target list
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         list
+// @Accept       json
+// @Param 		 id   path      string  true  "Task ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Param request body dto.MoveTaskDTO true "req body"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/list [put]
+func (h *Handler) moveTask(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.MoveTaskDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	err = h.srvs.MoveTask(ctx, getOwner(ctx), id, &req, version)
+	if err != nil {
+		log.Printf("can not move task: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
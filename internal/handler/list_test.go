@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_service "github.com/khussa1n/todo-list/internal/service/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_createList(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		body            interface{}
+		expectedSrvc    *entity.List
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:         "ok",
+			body:         dto.ListDTO{Title: "Ops"},
+			expectedSrvc: &entity.List{ID: id, Title: "Ops", Owner: testUserID},
+			httpStatus:   http.StatusCreated,
+			responseBody: `{"id":"` + id.Hex() + `","title":"Ops","owner":"` + testUserID.Hex() + `"}`,
+		},
+		{
+			name:         "title too long",
+			body:         dto.ListDTO{Title: strings.Repeat("я", 101)},
+			httpStatus:   http.StatusUnprocessableEntity,
+			responseBody: `{"code":"validation_failed","message":"request validation failed","details":[{"field":"title","message":"must be at most 100"}]}`,
+		},
+		{
+			name:            "duplicate",
+			body:            dto.ListDTO{Title: "Ops"},
+			expectedSrvcErr: custom_error.ErrDuplicateList,
+			httpStatus:      http.StatusConflict,
+			responseBody:    `{"code":"duplicate_list","message":"a list with the same title already exists","details":[{"field":"title","message":"a list with the same title already exists"}]}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok", "duplicate":
+				req := testCase.body.(dto.ListDTO)
+				mockService.EXPECT().CreateList(gomock.Any(), testUserID, &req).Return(testCase.expectedSrvc, testCase.expectedSrvcErr).Times(1)
+				break
+			case "title too long":
+				mockService.EXPECT().CreateList(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			body, err := json.Marshal(testCase.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/todo-list/lists/", bytes.NewReader(body))
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_getLists(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := mock_service.NewMockService(controller)
+	mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+	mockService.EXPECT().GetLists(gomock.Any(), testUserID).Return(nil, nil).Times(1)
+
+	handler := New(mockService)
+
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/todo-list/lists/", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer "+testToken)
+
+	handler.InitRouter().ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, `{"lists":[]}`, recorder.Body.String())
+}
+
+func Test_deleteList(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := mock_service.NewMockService(controller)
+	mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+	mockService.EXPECT().DeleteList(gomock.Any(), testUserID, id).Return(custom_error.ErrListNotEmpty).Times(1)
+
+	handler := New(mockService)
+
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodDelete, "/api/todo-list/lists/"+id.Hex(), nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer "+testToken)
+
+	handler.InitRouter().ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusConflict, recorder.Code)
+	require.Equal(t, `{"code":"list_not_empty","message":"list has tasks, move or delete them first"}`, recorder.Body.String())
+}
+
+func Test_moveTask(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		body            string
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:       "ok",
+			body:       `{"listId":"` + primitive.NewObjectID().Hex() + `"}`,
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:            "invalid list id",
+			body:            `{"listId":"ops"}`,
+			expectedSrvcErr: custom_error.ErrInvalidListID,
+			httpStatus:      http.StatusBadRequest,
+			responseBody:    `{"code":"invalid_list_id","message":"listId must be a list ID","details":[{"field":"listId","message":"listId must be a list ID"}]}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			var req dto.MoveTaskDTO
+			err := json.Unmarshal([]byte(testCase.body), &req)
+			require.NoError(t, err)
+			mockService.EXPECT().MoveTask(gomock.Any(), testUserID, id, &req, int64(0)).Return(testCase.expectedSrvcErr).Times(1)
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPut, "/api/todo-list/tasks/"+id.Hex()+"/list", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
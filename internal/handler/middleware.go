@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"github.com/khussa1n/todo-list/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	ifMatchHeader       = "If-Match"
+	etagHeader          = "ETag"
+	workspaceHeader     = "X-Workspace-ID"
+	userCtx             = "userID"
+	accessCtx           = "access"
+
+	codeValidationFailed = "validation_failed"
+	codeInternalError    = "internal_error"
+)
+
+// errorKind - HTTP статус и код ошибки для sentinel ошибки из custom_error.
+// Если field не пустой, ошибка относится к полю запроса
+type errorKind struct {
+	err    error
+	status int
+	code   string
+	field  string
+}
+
+var errorKinds = []errorKind{
+	{err: custom_error.ErrEmptyID, status: http.StatusBadRequest, code: "empty_id"},
+	{err: custom_error.ErrInvalidIDParameter, status: http.StatusBadRequest, code: "invalid_id"},
+	{err: custom_error.ErrInvalidInputBody, status: http.StatusBadRequest, code: "invalid_input_body"},
+	{err: custom_error.ErrInvalidQueryParams, status: http.StatusBadRequest, code: "invalid_query_params"},
+	{err: custom_error.ErrInvalidLimit, status: http.StatusBadRequest, code: "invalid_limit", field: "limit"},
+	{err: custom_error.ErrInvalidSort, status: http.StatusBadRequest, code: "invalid_sort", field: "sort"},
+	{err: custom_error.ErrInvalidCursor, status: http.StatusBadRequest, code: "invalid_cursor", field: "cursor"},
+	{err: custom_error.ErrInvalidDateRange, status: http.StatusBadRequest, code: "invalid_date_range"},
+	{err: custom_error.ErrInvalidPeriod, status: http.StatusBadRequest, code: "invalid_period", field: "period"},
+	{err: custom_error.ErrInvalidSearchQuery, status: http.StatusBadRequest, code: "invalid_search_query", field: "q"},
+	{err: custom_error.ErrInvalidPriority, status: http.StatusBadRequest, code: "invalid_priority", field: "priority"},
+	{err: custom_error.ErrInvalidTag, status: http.StatusBadRequest, code: "invalid_tag", field: "tags"},
+	{err: custom_error.ErrInvalidTagMatch, status: http.StatusBadRequest, code: "invalid_tag_match", field: "tagMatch"},
+	{err: custom_error.ErrInvalidStatus, status: http.StatusBadRequest, code: "invalid_status", field: "status"},
+	{err: custom_error.ErrInvalidListID, status: http.StatusBadRequest, code: "invalid_list_id", field: "listId"},
+	{err: custom_error.ErrInvalidWorkspaceID, status: http.StatusBadRequest, code: "invalid_workspace_id"},
+	{err: custom_error.ErrInvalidIfMatch, status: http.StatusBadRequest, code: "invalid_if_match"},
+	{err: custom_error.ErrInvalidHistoryVersion, status: http.StatusBadRequest, code: "invalid_version"},
+	{err: custom_error.ErrMissingFile, status: http.StatusBadRequest, code: "missing_file", field: "file"},
+	{err: custom_error.ErrEmptyAuthHeader, status: http.StatusUnauthorized, code: "empty_auth_header"},
+	{err: custom_error.ErrInvalidAuthHeader, status: http.StatusUnauthorized, code: "invalid_auth_header"},
+	{err: custom_error.ErrInvalidToken, status: http.StatusUnauthorized, code: "invalid_token"},
+	{err: custom_error.ErrInvalidCredentials, status: http.StatusUnauthorized, code: "invalid_credentials"},
+	{err: custom_error.ErrForbidden, status: http.StatusForbidden, code: "forbidden"},
+	{err: custom_error.ErrNotCommentAuthor, status: http.StatusForbidden, code: "not_comment_author"},
+	{err: custom_error.ErrTaskNotFound, status: http.StatusNotFound, code: "task_not_found"},
+	{err: custom_error.ErrTagNotFound, status: http.StatusNotFound, code: "tag_not_found"},
+	{err: custom_error.ErrChecklistItemNotFound, status: http.StatusNotFound, code: "checklist_item_not_found"},
+	{err: custom_error.ErrBlockerNotFound, status: http.StatusNotFound, code: "blocker_not_found"},
+	{err: custom_error.ErrDependencyNotFound, status: http.StatusNotFound, code: "dependency_not_found"},
+	{err: custom_error.ErrListNotFound, status: http.StatusNotFound, code: "list_not_found"},
+	{err: custom_error.ErrCommentNotFound, status: http.StatusNotFound, code: "comment_not_found"},
+	{err: custom_error.ErrAttachmentNotFound, status: http.StatusNotFound, code: "attachment_not_found"},
+	{err: custom_error.ErrHistoryNotFound, status: http.StatusNotFound, code: "history_not_found"},
+	{err: custom_error.ErrWorkspaceNotFound, status: http.StatusNotFound, code: "workspace_not_found"},
+	{err: custom_error.ErrMemberNotFound, status: http.StatusNotFound, code: "member_not_found"},
+	{err: custom_error.ErrInvitationNotFound, status: http.StatusNotFound, code: "invitation_not_found"},
+	{err: custom_error.ErrVersionMismatch, status: http.StatusPreconditionFailed, code: "precondition_failed"},
+	{err: custom_error.ErrDuplicateTask, status: http.StatusConflict, code: "duplicate_task", field: "title"},
+	{err: custom_error.ErrInvalidTransition, status: http.StatusConflict, code: "invalid_status_transition", field: "status"},
+	{err: custom_error.ErrDependencyCycle, status: http.StatusConflict, code: "dependency_cycle"},
+	{err: custom_error.ErrTaskBlocked, status: http.StatusConflict, code: "task_blocked", field: "status"},
+	{err: custom_error.ErrDuplicateList, status: http.StatusConflict, code: "duplicate_list", field: "title"},
+	{err: custom_error.ErrListNotEmpty, status: http.StatusConflict, code: "list_not_empty"},
+	{err: custom_error.ErrAlreadyMember, status: http.StatusConflict, code: "already_member"},
+	{err: custom_error.ErrWorkspaceOwner, status: http.StatusConflict, code: "workspace_owner"},
+	{err: custom_error.ErrDuplicateUser, status: http.StatusConflict, code: "duplicate_user", field: "username"},
+	{err: custom_error.ErrAttachmentTooLarge, status: http.StatusRequestEntityTooLarge, code: "attachment_too_large", field: "file"},
+	{err: custom_error.ErrAttachmentType, status: http.StatusUnsupportedMediaType, code: "unsupported_attachment_type", field: "file"},
+	{err: custom_error.ErrMessageTooLong, status: http.StatusUnprocessableEntity, code: "title_too_long", field: "title"},
+	{err: custom_error.ErrEmptyTitle, status: http.StatusUnprocessableEntity, code: "empty_title", field: "title"},
+	{err: custom_error.ErrDescriptionTooLong, status: http.StatusUnprocessableEntity, code: "description_too_long", field: "description"},
+	{err: custom_error.ErrInvalidDueTime, status: http.StatusUnprocessableEntity, code: "invalid_due", field: "due"},
+	{err: custom_error.ErrEmptyComment, status: http.StatusUnprocessableEntity, code: "empty_comment", field: "body"},
+	{err: custom_error.ErrChecklistFull, status: http.StatusUnprocessableEntity, code: "checklist_full"},
+	{err: custom_error.ErrInvalidChecklistOrder, status: http.StatusUnprocessableEntity, code: "invalid_checklist_order", field: "ids"},
+	{err: custom_error.ErrTooManyDependencies, status: http.StatusUnprocessableEntity, code: "too_many_dependencies"},
+	{err: custom_error.ErrInvalidRecurrence, status: http.StatusUnprocessableEntity, code: "invalid_recurrence", field: "recurrence"},
+	{err: custom_error.ErrInvalidActiveAtFormat, status: http.StatusUnprocessableEntity, code: "invalid_active_at", field: "activeAt"},
+	{err: custom_error.ErrAttachmentCorrupted, status: http.StatusInternalServerError, code: "attachment_corrupted"},
+}
+
+func init() {
+	// В ошибках валидации поля называются так же, как в JSON и query запроса
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			for _, tag := range []string{"json", "form"} {
+				name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+				if name == "-" {
+					return ""
+				}
+				if name != "" {
+					return name
+				}
+			}
+			return field.Name
+		})
+	}
+}
+
+// errorHandler превращает ошибку, переданную обработчиком через ctx.Error, в ответ dto.Error
+func errorHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+
+		status, body := errorResponse(ctx.Errors.Last())
+		ctx.JSON(status, body)
+	}
+}
+
+// userIdentity проверяет токен из заголовка Authorization: Bearer <token>
+// и сохраняет ID пользователя в контексте запроса
+func (h *Handler) userIdentity(ctx *gin.Context) {
+	header := ctx.GetHeader(authorizationHeader)
+	if header == "" {
+		abortWithError(ctx, custom_error.ErrEmptyAuthHeader)
+		return
+	}
+
+	headerParts := strings.Split(header, " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" || headerParts[1] == "" {
+		abortWithError(ctx, custom_error.ErrInvalidAuthHeader)
+		return
+	}
+
+	userID, err := h.srvs.ParseToken(ctx, headerParts[1])
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.Set(userCtx, userID)
+	// Сервис берет из контекста запроса пользователя, который меняет задачи
+	ctx.Request = ctx.Request.WithContext(service.WithActor(ctx.Request.Context(), userID))
+}
+
+// workspaceAccess определяет, чьи задачи и списки запрашиваются: без заголовка X-Workspace-ID -
+// свои задачи пользователя, с ним - задачи рабочего пространства. Читатель пространства
+// может только просматривать их, изменять - редактор и владелец. Работает после userIdentity
+func (h *Handler) workspaceAccess(ctx *gin.Context) {
+	userID := getUserID(ctx)
+
+	header := strings.TrimSpace(ctx.GetHeader(workspaceHeader))
+	if header == "" {
+		ctx.Set(accessCtx, entity.PersonalAccess(userID))
+		return
+	}
+
+	workspace, err := primitive.ObjectIDFromHex(header)
+	if err != nil {
+		abortWithError(ctx, custom_error.ErrInvalidWorkspaceID)
+		return
+	}
+
+	access, err := h.srvs.GetAccess(ctx, userID, workspace)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	if !access.Can(methodRole(ctx.Request.Method)) {
+		abortWithError(ctx, custom_error.ErrForbidden)
+		return
+	}
+
+	ctx.Set(accessCtx, access)
+}
+
+// methodRole - роль, нужная для запроса к задачам: чтение доступно читателю, остальное - редактору
+func methodRole(method string) entity.Role {
+	if method == http.MethodGet || method == http.MethodHead {
+		return entity.RoleViewer
+	}
+	return entity.RoleEditor
+}
+
+// abortWithError прерывает обработку запроса, ответ сформирует errorHandler
+func abortWithError(ctx *gin.Context, err error) {
+	_ = ctx.Error(err)
+	ctx.Abort()
+}
+
+// abortWithBindError прерывает обработку запроса с ошибкой ShouldBind*.
+// base - ошибка из custom_error для запроса, который не удалось разобрать
+func abortWithBindError(ctx *gin.Context, base error, err error) {
+	_ = ctx.Error(err).SetType(gin.ErrorTypeBind).SetMeta(base)
+	ctx.Abort()
+}
+
+func errorResponse(e *gin.Error) (int, dto.Error) {
+	err := e.Err
+
+	if e.IsType(gin.ErrorTypeBind) {
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return http.StatusUnprocessableEntity, dto.Error{
+				Code:    codeValidationFailed,
+				Message: "request validation failed",
+				Details: validationDetails(validationErrs),
+			}
+		}
+
+		base, ok := e.Meta.(error)
+		if !ok {
+			base = custom_error.ErrInvalidInputBody
+		}
+
+		kind := lookupErrorKind(base)
+		body := dto.Error{Code: kind.code, Message: base.Error()}
+
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			body.Details = []dto.FieldError{{Field: typeErr.Field, Message: "must be " + typeErr.Type.String()}}
+		}
+
+		return kind.status, body
+	}
+
+	kind := lookupErrorKind(err)
+	if kind.code == codeInternalError {
+		return kind.status, dto.Error{Code: kind.code, Message: http.StatusText(kind.status)}
+	}
+
+	body := dto.Error{Code: kind.code, Message: err.Error()}
+	if kind.field != "" {
+		body.Details = []dto.FieldError{{Field: kind.field, Message: err.Error()}}
+	}
+
+	return kind.status, body
+}
+
+func lookupErrorKind(err error) errorKind {
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind.err) {
+			return kind
+		}
+	}
+	return errorKind{status: http.StatusInternalServerError, code: codeInternalError}
+}
+
+func validationDetails(errs validator.ValidationErrors) []dto.FieldError {
+	details := make([]dto.FieldError, 0, len(errs))
+	for _, fe := range errs {
+		details = append(details, dto.FieldError{Field: fe.Field(), Message: validationMessage(fe)})
+	}
+	return details
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "max":
+		return "must be at most " + fe.Param()
+	case "min":
+		return "must be at least " + fe.Param()
+	case "oneof":
+		return "must be one of " + fe.Param()
+	default:
+		return "failed on the '" + fe.Tag() + "' rule"
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"log"
+	"net/http"
+)
+
+// setRecurrence 	Set task recurrence
+// @Summary      Set task recurrence
+// @Description  Make the task recurring or change its rule. The rule is an RRULE subset: FREQ=DAILY, WEEKLY or MONTHLY with optional INTERVAL, BYDAY (WEEKLY), BYMONTHDAY (MONTHLY) and UNTIL (YYYYMMDD). When the task is marked done the next occurrence is created
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         recurrence
+// @Accept       json
+// @Param 		 id   path      string  true  "Task ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Param req body dto.RecurrenceDTO true "req body"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/recurrence [put]
+func (h *Handler) setRecurrence(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.RecurrenceDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	err = h.srvs.SetRecurrence(ctx, getOwner(ctx), id, &req, version)
+	if err != nil {
+		log.Printf("can not set recurrence: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// stopRecurrence 	Stop task recurrence
+// @Summary      Stop task recurrence
+// @Description  Stop the series: the task stays, but no next occurrence is created when it is done
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         recurrence
+// @Param 		 id   path      string  true  "Task ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/recurrence [delete]
+func (h *Handler) stopRecurrence(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	err = h.srvs.StopRecurrence(ctx, getOwner(ctx), id, version)
+	if err != nil {
+		log.Printf("can not stop recurrence: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
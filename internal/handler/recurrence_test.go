@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_service "github.com/khussa1n/todo-list/internal/service/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_setRecurrence(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		body            interface{}
+		ifMatch         string
+		version         int64
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:       "ok",
+			body:       dto.RecurrenceDTO{Recurrence: "FREQ=WEEKLY;BYDAY=MO"},
+			ifMatch:    `"4"`,
+			version:    4,
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:         "empty recurrence",
+			body:         map[string]string{},
+			httpStatus:   http.StatusUnprocessableEntity,
+			responseBody: `{"code":"validation_failed","message":"request validation failed","details":[{"field":"recurrence","message":"is required"}]}`,
+		},
+		{
+			name:            "invalid recurrence",
+			body:            dto.RecurrenceDTO{Recurrence: "FREQ=HOURLY"},
+			expectedSrvcErr: custom_error.ErrInvalidRecurrence,
+			httpStatus:      http.StatusUnprocessableEntity,
+			responseBody: `{"code":"invalid_recurrence","message":"recurrence must be an RRULE with FREQ=DAILY, WEEKLY or MONTHLY and optional INTERVAL, BYDAY, BYMONTHDAY, UNTIL",` +
+				`"details":[{"field":"recurrence","message":"recurrence must be an RRULE with FREQ=DAILY, WEEKLY or MONTHLY and optional INTERVAL, BYDAY, BYMONTHDAY, UNTIL"}]}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok", "invalid recurrence":
+				req := testCase.body.(dto.RecurrenceDTO)
+				mockService.EXPECT().SetRecurrence(gomock.Any(), testUserID, id, &req, testCase.version).Return(testCase.expectedSrvcErr).Times(1)
+				break
+			case "empty recurrence":
+				mockService.EXPECT().SetRecurrence(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			body, err := json.Marshal(testCase.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, "/api/todo-list/tasks/"+id.Hex()+"/recurrence", bytes.NewReader(body))
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+			if testCase.ifMatch != "" {
+				request.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_stopRecurrence(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := mock_service.NewMockService(controller)
+	mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+	mockService.EXPECT().StopRecurrence(gomock.Any(), testUserID, id, int64(0)).Return(custom_error.ErrTaskNotFound).Times(1)
+
+	handler := New(mockService)
+
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodDelete, "/api/todo-list/tasks/"+id.Hex()+"/recurrence", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer "+testToken)
+
+	handler.InitRouter().ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	require.Equal(t, `{"code":"task_not_found","message":"task not found"}`, recorder.Body.String())
+}
@@ -9,17 +9,73 @@ import (
 
 func (h *Handler) InitRouter() *gin.Engine {
 	router := gin.Default()
+	// Контекст gin отдает значения контекста запроса, например пользователя из userIdentity
+	router.ContextWithFallback = true
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	api := router.Group("/api/todo-list")
+	api := router.Group("/api/todo-list", errorHandler())
 
-	task := api.Group("/tasks")
+	auth := api.Group("/auth")
+	auth.POST("/sign-up", h.signUp)
+	auth.POST("/sign-in", h.signIn)
+
+	task := api.Group("/tasks", h.userIdentity, h.workspaceAccess)
 	task.POST("/", h.createTask)
 	task.PUT("/:id", h.updateTask)
+	task.PATCH("/:id", h.patchTask)
 	task.DELETE("/:id", h.deleteTask)
-	task.PUT("/:id/done", h.updateTaskStatus)
+	task.PUT("/:id/done", h.markTaskDone)
+	task.PATCH("/:id/status", h.updateTaskStatus)
+	task.POST("/:id/restore", h.restoreTask)
+	task.POST("/:id/checklist", h.addChecklistItem)
+	task.PUT("/:id/checklist/order", h.reorderChecklist)
+	task.PATCH("/:id/checklist/:itemId", h.updateChecklistItem)
+	task.DELETE("/:id/checklist/:itemId", h.deleteChecklistItem)
+	task.PUT("/:id/recurrence", h.setRecurrence)
+	task.DELETE("/:id/recurrence", h.stopRecurrence)
+	task.PUT("/:id/dependencies/:blockerId", h.addDependency)
+	task.DELETE("/:id/dependencies/:blockerId", h.removeDependency)
+	task.PUT("/:id/list", h.moveTask)
+	task.POST("/:id/comments", h.createComment)
+	task.PUT("/:id/comments/:commentId", h.updateComment)
+	task.DELETE("/:id/comments/:commentId", h.deleteComment)
+	task.POST("/:id/attachments", h.uploadAttachment)
+	task.DELETE("/:id/attachments/:attachmentId", h.deleteAttachment)
+	task.POST("/:id/revert/:version", h.revertTask)
 	task.GET("/", h.getAllTasks)
+	task.GET("/trash", h.getTrash)
+	task.GET("/search", h.searchTasks)
+	task.GET("/tags", h.getTags)
+	task.PUT("/tags/:tag", h.renameTag)
+	task.GET("/:id", h.getTaskByID)
+	task.GET("/:id/graph", h.getDependencyGraph)
+	task.GET("/:id/comments", h.getComments)
+	task.GET("/:id/attachments", h.getAttachments)
+	task.GET("/:id/attachments/:attachmentId", h.downloadAttachment)
+	task.GET("/:id/history", h.getHistory)
+
+	list := api.Group("/lists", h.userIdentity, h.workspaceAccess)
+	list.POST("/", h.createList)
+	list.GET("/", h.getLists)
+	list.GET("/:id", h.getListByID)
+	list.PUT("/:id", h.updateList)
+	list.DELETE("/:id", h.deleteList)
+	list.GET("/:id/tasks", h.getListTasks)
+
+	workspace := api.Group("/workspaces", h.userIdentity)
+	workspace.POST("/", h.createWorkspace)
+	workspace.GET("/", h.getWorkspaces)
+	workspace.GET("/:id", h.getWorkspaceByID)
+	workspace.PUT("/:id", h.updateWorkspace)
+	workspace.DELETE("/:id", h.deleteWorkspace)
+	workspace.GET("/:id/members", h.getMembers)
+	workspace.PUT("/:id/members/:userId", h.updateMemberRole)
+	workspace.DELETE("/:id/members/:userId", h.removeMember)
+	workspace.POST("/:id/invitations", h.createInvitation)
+
+	invitation := api.Group("/invitations", h.userIdentity)
+	invitation.POST("/:token/accept", h.acceptInvitation)
 
 	return router
 }
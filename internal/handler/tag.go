@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"log"
+	"net/http"
+)
+
+// getTags 	Get tags
+// @Summary      Get tags
+// @Description  Get tags of the user's tasks with the number of tasks for each, the most used first. Tasks in trash are not counted
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         tag
+// @Produce      json
+// @Success      200  {object}  dto.TagsDTO
+// @Failure      401  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/tags [get]
+func (h *Handler) getTags(ctx *gin.Context) {
+	tags, err := h.srvs.GetTags(ctx, getOwner(ctx))
+	if err != nil {
+		log.Printf("can not get tags: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.TagsDTO{Tags: tags})
+}
+
+// renameTag 	Rename tag
+// @Summary      Rename or merge tag
+// @Description  Rename the tag on all tasks of the user, including trash. If a task already has the new tag, the two tags are merged into one
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         tag
+// @Accept       json
+// @Produce      json
+// @Param 		 tag  path      string  true  "Tag"
+// @Param req body dto.RenameTagDTO true "req body"
+// @Success      200  {object}  dto.RenameTagResultDTO
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/tags/{tag} [put]
+func (h *Handler) renameTag(ctx *gin.Context) {
+	var req dto.RenameTagDTO
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	updated, err := h.srvs.RenameTag(ctx, getOwner(ctx), ctx.Param("tag"), &req)
+	if err != nil {
+		log.Printf("can not rename tag: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.RenameTagResultDTO{Tasks: updated})
+}
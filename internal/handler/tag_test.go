@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_service "github.com/khussa1n/todo-list/internal/service/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_getTags(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := mock_service.NewMockService(controller)
+	mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+	mockService.EXPECT().GetTags(gomock.Any(), testUserID).Return([]entity.TagStat{{Tag: "backend", Count: 2}, {Tag: "ops", Count: 1}}, nil).Times(1)
+
+	handler := New(mockService)
+
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/todo-list/tasks/tags", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer "+testToken)
+
+	handler.InitRouter().ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, `{"tags":[{"tag":"backend","count":2},{"tag":"ops","count":1}]}`, recorder.Body.String())
+}
+
+func Test_renameTag(t *testing.T) {
+	table := []struct {
+		name            string
+		tag             string
+		body            interface{}
+		expectedSrvc    int64
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:         "ok",
+			tag:          "backend",
+			body:         dto.RenameTagDTO{Name: "api"},
+			expectedSrvc: 3,
+			httpStatus:   http.StatusOK,
+			responseBody: `{"tasks":3}`,
+		},
+		{
+			name:         "empty name",
+			tag:          "backend",
+			body:         map[string]string{},
+			httpStatus:   http.StatusUnprocessableEntity,
+			responseBody: `{"code":"validation_failed","message":"request validation failed","details":[{"field":"name","message":"is required"}]}`,
+		},
+		{
+			name:            "tag not found",
+			tag:             "backend",
+			body:            dto.RenameTagDTO{Name: "api"},
+			expectedSrvcErr: custom_error.ErrTagNotFound,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"tag_not_found","message":"tag not found"}`,
+		},
+		{
+			name:            "invalid tag",
+			tag:             "backend",
+			body:            dto.RenameTagDTO{Name: "back end"},
+			expectedSrvcErr: custom_error.ErrInvalidTag,
+			httpStatus:      http.StatusBadRequest,
+			responseBody:    `{"code":"invalid_tag","message":"tags must be 1 to 30 letters, digits, - or _, at most 20 per task","details":[{"field":"tags","message":"tags must be 1 to 30 letters, digits, - or _, at most 20 per task"}]}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok", "tag not found", "invalid tag":
+				req := testCase.body.(dto.RenameTagDTO)
+				mockService.EXPECT().RenameTag(gomock.Any(), testUserID, testCase.tag, &req).Return(testCase.expectedSrvc, testCase.expectedSrvcErr).Times(1)
+				break
+			case "empty name":
+				mockService.EXPECT().RenameTag(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			body, err := json.Marshal(testCase.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, "/api/todo-list/tasks/tags/"+testCase.tag, bytes.NewReader(body))
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
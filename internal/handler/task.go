@@ -3,8 +3,8 @@ package handler
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
 	"github.com/khussa1n/todo-list/internal/entity/dto"
-	"go.mongodb.org/mongo-driver/mongo"
 	"log"
 	"net/http"
 )
@@ -12,12 +12,18 @@ import (
 // createTask 	Create new task
 // @Summary      Create task
 // @Description  Create new task
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
 // @Tags         task
 // @Accept       json
 // @Produce      json
 // @Param request body dto.TasksDTO true "req body"
 // @Success      201  {object}  entity.Tasks
+// @Header       201  {string}  ETag  "task version"
 // @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
 // @Failure      500  {object}  dto.Error
 // @Router       /tasks [post]
 func (h *Handler) createTask(ctx *gin.Context) {
@@ -25,40 +31,50 @@ func (h *Handler) createTask(ctx *gin.Context) {
 	err := ctx.ShouldBindJSON(&req)
 	if err != nil {
 		log.Printf("bind json err: %s \n", err.Error())
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, custom_error.ErrInvalidInputBody.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
 		return
 	}
 
-	task, err := h.srvs.CreateTask(ctx, &req)
+	task, err := h.srvs.CreateTask(ctx, getAccess(ctx), &req)
 	if err != nil {
 		log.Printf("can not create task: %s \n", err.Error())
-		switch err {
-		case custom_error.ErrMessageTooLong, custom_error.ErrInvalidActiveAtFormat, custom_error.ErrDuplicateTask:
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
-			return
-		default:
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
-			return
-		}
+		abortWithError(ctx, err)
+		return
 	}
 
+	setETag(ctx, task.Version)
 	ctx.JSON(http.StatusCreated, task)
 }
 
 // updateTask 	Update task
 // @Summary      Update task
 // @Description  Update task
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
 // @Tags         task
 // @Accept       json
+// @Param 		 id   path      string  true  "Task ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
 // @Param req body dto.TasksDTO true "req body"
 // @Success      204
 // @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
 // @Failure      500  {object}  dto.Error
 // @Router       /tasks/{id} [put]
 func (h *Handler) updateTask(ctx *gin.Context) {
 	id, err := parseIdFromPath(ctx, "id")
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
 		return
 	}
 
@@ -66,56 +82,154 @@ func (h *Handler) updateTask(ctx *gin.Context) {
 	err = ctx.ShouldBindJSON(&req)
 	if err != nil {
 		log.Printf("bind json err: %s \n", err.Error())
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, custom_error.ErrInvalidInputBody.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
 		return
 	}
 
-	err = h.srvs.UpdateTask(ctx, &req, id)
+	err = h.srvs.UpdateTask(ctx, getOwner(ctx), &req, id, version)
 	if err != nil {
 		log.Printf("can not update task: %s \n", err.Error())
-		switch err {
-		case custom_error.ErrTaskNotFound, custom_error.ErrInvalidActiveAtFormat, custom_error.ErrDuplicateTask:
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
-			return
-		case custom_error.ErrMessageTooLong:
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
-			return
-		default:
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
-			return
-		}
+		abortWithError(ctx, err)
+		return
 	}
 
 	ctx.JSON(http.StatusNoContent, "")
 }
 
-// updateTaskStatus 	Update task status
+// patchTask 	Partially update task
+// @Summary      Partially update task
+// @Description  JSON Merge Patch (RFC 7396): only the passed fields are changed. null removes description and due, other fields can not be removed
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         task
+// @Accept       json
+// @Param 		 id   path      string  true  "Task ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Param req body dto.TasksPatchDTO true "req body"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id} [patch]
+func (h *Handler) patchTask(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.TasksPatchDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	err = h.srvs.PatchTask(ctx, getOwner(ctx), &req, id, version)
+	if err != nil {
+		log.Printf("can not patch task: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// markTaskDone 	Mark task as done
 // @Summary      Update task status to done
-// @Description  Update task status to done
+// @Description  Update task status to done. Same as PATCH /tasks/{id}/status with status done
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
 // @Tags         task
 // @Param 		 id   path      string  true  "Task ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
 // @Success      204
 // @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
 // @Failure      500  {object}  dto.Error
 // @Router       /tasks/{id}/done [put]
+func (h *Handler) markTaskDone(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	err = h.srvs.UpdateTaskStatus(ctx, getOwner(ctx), id, entity.StatusDone, version)
+	if err != nil {
+		log.Printf("can not update status task: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// updateTaskStatus 	Update task status
+// @Summary      Update task status
+// @Description  Move task to another status: todo, in_progress, done or archived. Allowed transitions: todo -> in_progress, done, archived; in_progress -> todo, done, archived; done -> todo, archived; archived -> todo
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         task
+// @Accept       json
+// @Param 		 id   path      string  true  "Task ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
+// @Param req body dto.TaskStatusDTO true "req body"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/status [patch]
 func (h *Handler) updateTaskStatus(ctx *gin.Context) {
 	id, err := parseIdFromPath(ctx, "id")
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		abortWithError(ctx, err)
 		return
 	}
 
-	err = h.srvs.UpdateTaskStatus(ctx, id, "done")
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.TaskStatusDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	err = h.srvs.UpdateTaskStatus(ctx, getOwner(ctx), id, req.Status, version)
 	if err != nil {
 		log.Printf("can not update status task: %s \n", err.Error())
-		switch err {
-		case mongo.ErrNoDocuments:
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
-			return
-		default:
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
-			return
-		}
+		abortWithError(ctx, err)
+		return
 	}
 
 	ctx.JSON(http.StatusNoContent, "")
@@ -123,31 +237,109 @@ func (h *Handler) updateTaskStatus(ctx *gin.Context) {
 
 // deleteTask 	Delete task
 // @Summary      Delete task
-// @Description  Delete task
+// @Description  Move task to trash. The task can be restored with its comments and attachments until the trash is purged, purging removes them for good
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
 // @Tags         task
 // @Param 		 id   path      string  true  "Task ID"
+// @Param 		 If-Match  header   string  false  "ETag of the task version the change is based on"
 // @Success      204
 // @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      412  {object}  dto.Error
 // @Failure      500  {object}  dto.Error
 // @Router       /tasks/{id} [delete]
 func (h *Handler) deleteTask(ctx *gin.Context) {
 	id, err := parseIdFromPath(ctx, "id")
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		abortWithError(ctx, err)
 		return
 	}
 
-	err = h.srvs.DeleteTask(ctx, id)
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	err = h.srvs.DeleteTask(ctx, getOwner(ctx), id, version)
 	if err != nil {
 		log.Printf("can not delete task: %s \n", err.Error())
-		switch err {
-		case custom_error.ErrTaskNotFound:
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
-			return
-		default:
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
-			return
-		}
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// getTrash 	Get deleted tasks
+// @Summary      Get deleted tasks
+// @Description  Get a page of tasks in trash. Accepts the same query as GET /tasks, status defaults to all
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         task
+// @Produce      json
+// @Param		 status    query     string false "todo, in_progress, done or archived (default all)"
+// @Param		 priority  query     string false "low, medium, high or urgent, several separated by comma"
+// @Param		 tag       query     []string false "tags, repeat the parameter for several" collectionFormat(multi)
+// @Param		 tagMatch  query     string false "all - tasks with every tag, any - with at least one (default all)"
+// @Param		 from      query     string false "activeAt from, inclusive (2006-01-02)"
+// @Param		 to        query     string false "activeAt to, inclusive (2006-01-02)"
+// @Param		 period    query     string false "overdue, today or week, instead of from/to"
+// @Param		 limit     query     int    false "page size, 1..100 (default 20)"
+// @Param		 cursor    query     string false "nextCursor from the previous page"
+// @Param		 sort      query     string false "activeAt, title, priority or createdAt, prefix with - for descending (default createdAt)"
+// @Success      200  {object}  dto.TasksPageDTO
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/trash [get]
+func (h *Handler) getTrash(ctx *gin.Context) {
+	var query dto.TasksQueryDTO
+	err := ctx.ShouldBindQuery(&query)
+	if err != nil {
+		log.Printf("bind query err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidQueryParams, err)
+		return
+	}
+
+	page, err := h.srvs.GetTrash(ctx, getOwner(ctx), &query)
+	if err != nil {
+		log.Printf("can not get trash: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// restoreTask 	Restore task
+// @Summary      Restore task
+// @Description  Restore task from trash
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         task
+// @Param 		 id   path      string  true  "Task ID"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id}/restore [post]
+func (h *Handler) restoreTask(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	err = h.srvs.RestoreTask(ctx, getOwner(ctx), id)
+	if err != nil {
+		log.Printf("can not restore task: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
 	}
 
 	ctx.JSON(http.StatusNoContent, "")
@@ -155,23 +347,113 @@ func (h *Handler) deleteTask(ctx *gin.Context) {
 
 // getAllTasks 	Get all tasks
 // @Summary      Get all tasks by status
-// @Description  Get all tasks by status
+// @Description  Get a page of tasks by status. Pass nextCursor from the previous page as cursor to get the next one
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
 // @Tags         task
 // @Produce      json
-// @Param		 status    query     string false "name search by status"
-// @Success      200  {array}  entity.Tasks
+// @Param		 status    query     string false "todo, in_progress, done or archived (default todo and in_progress)"
+// @Param		 priority  query     string false "low, medium, high or urgent, several separated by comma"
+// @Param		 tag       query     []string false "tags, repeat the parameter for several" collectionFormat(multi)
+// @Param		 tagMatch  query     string false "all - tasks with every tag, any - with at least one (default all)"
+// @Param		 from      query     string false "activeAt from, inclusive (2006-01-02)"
+// @Param		 to        query     string false "activeAt to, inclusive (2006-01-02)"
+// @Param		 period    query     string false "overdue, today or week, instead of from/to"
+// @Param		 limit     query     int    false "page size, 1..100 (default 20)"
+// @Param		 cursor    query     string false "nextCursor from the previous page"
+// @Param		 sort      query     string false "activeAt, title, priority or createdAt, prefix with - for descending (default createdAt)"
+// @Success      200  {object}  dto.TasksPageDTO
 // @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
 // @Failure      500  {object}  dto.Error
 // @Router       /tasks [get]
 func (h *Handler) getAllTasks(ctx *gin.Context) {
-	status := ctx.Query("status")
+	var query dto.TasksQueryDTO
+	err := ctx.ShouldBindQuery(&query)
+	if err != nil {
+		log.Printf("bind query err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidQueryParams, err)
+		return
+	}
+
+	page, err := h.srvs.GetAllTasks(ctx, getOwner(ctx), &query)
+	if err != nil {
+		log.Printf("can not get task: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// searchTasks 	Search tasks
+// @Summary      Search tasks
+// @Description  Full-text search over task titles. Every word of q must match a word of the task or its beginning, the most relevant tasks come first. Searches all statuses unless status is set
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         task
+// @Produce      json
+// @Param		 q         query     string true  "search words, 1..10"
+// @Param		 status    query     string false "todo, in_progress, done or archived (default all)"
+// @Param		 priority  query     string false "low, medium, high or urgent, several separated by comma"
+// @Param		 from      query     string false "activeAt from, inclusive (2006-01-02)"
+// @Param		 to        query     string false "activeAt to, inclusive (2006-01-02)"
+// @Param		 period    query     string false "overdue, today or week, instead of from/to"
+// @Param		 limit     query     int    false "page size, 1..100 (default 20)"
+// @Param		 cursor    query     string false "nextCursor from the previous page"
+// @Success      200  {object}  dto.TasksPageDTO
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/search [get]
+func (h *Handler) searchTasks(ctx *gin.Context) {
+	var query dto.TasksSearchDTO
+	err := ctx.ShouldBindQuery(&query)
+	if err != nil {
+		log.Printf("bind query err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidQueryParams, err)
+		return
+	}
+
+	page, err := h.srvs.SearchTasks(ctx, getOwner(ctx), &query)
+	if err != nil {
+		log.Printf("can not search tasks: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// getTaskByID 	Get task by id
+// @Summary      Get task by id
+// @Description  Get task by id
+// @Security     ApiKeyAuth
+// @Param 		 X-Workspace-ID  header  string  false  "Workspace ID, the user's own tasks without it"
+// @Tags         task
+// @Produce      json
+// @Param 		 id   path      string  true  "Task ID"
+// @Success      200  {object}  entity.Tasks
+// @Header       200  {string}  ETag  "task version, pass it in If-Match to PUT, PATCH and DELETE"
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /tasks/{id} [get]
+func (h *Handler) getTaskByID(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
 
-	tasks, err := h.srvs.GetAllTasks(ctx, status)
+	task, err := h.srvs.GetTaskByID(ctx, getOwner(ctx), id)
 	if err != nil {
 		log.Printf("can not get task: %s \n", err.Error())
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		abortWithError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, tasks)
+	setETag(ctx, task.Version)
+	ctx.JSON(http.StatusOK, task)
 }
@@ -3,6 +3,7 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/khussa1n/todo-list/internal/custom_error"
@@ -11,12 +12,16 @@ import (
 	mock_service "github.com/khussa1n/todo-list/internal/service/mock"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
+const testToken = "token"
+
+var testUserID = primitive.NewObjectID()
+
 func Test_createTask(t *testing.T) {
 	id := primitive.NewObjectID()
 	table := []struct {
@@ -31,30 +36,64 @@ func Test_createTask(t *testing.T) {
 		{
 			name:         "ok ВЫХОДНОЙ",
 			dto:          dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-05"},
-			expectedSrvc: entity.Tasks{ID: id, Title: "ВЫХОДНОЙ - Купить", ActiveAt: "2023-08-05", Status: "active"},
+			expectedSrvc: entity.Tasks{ID: id, Title: "ВЫХОДНОЙ - Купить", ActiveAt: mustDate("2023-08-05"), Status: "todo", Weekend: true, Owner: testUserID, Version: 1},
 			httpStatus:   http.StatusCreated,
-			responseBody: fmt.Sprintf(`{"id":"%s","title":"ВЫХОДНОЙ - Купить","activeAt":"2023-08-05","status":"active"}`, id.Hex()),
+			responseBody: fmt.Sprintf(`{"id":"%s","title":"ВЫХОДНОЙ - Купить","activeAt":"2023-08-05","status":"todo","priority":"medium","weekend":true,"owner":"%s","version":1}`, id.Hex(), testUserID.Hex()),
 		},
 		{
 			name:         "ok",
 			dto:          dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04"},
-			expectedSrvc: entity.Tasks{ID: id, Title: "Купить", ActiveAt: "2023-08-04", Status: "active"},
+			expectedSrvc: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: testUserID, Version: 1},
+			httpStatus:   http.StatusCreated,
+			responseBody: fmt.Sprintf(`{"id":"%s","title":"Купить","activeAt":"2023-08-04","status":"todo","priority":"medium","weekend":false,"owner":"%s","version":1}`, id.Hex(), testUserID.Hex()),
+		},
+		{
+			name:         "ok details",
+			dto:          dto.TasksDTO{Title: "Купить", Description: "**Молоко**", ActiveAt: "2023-08-04", Priority: "high", Due: &dto.DueDTO{Time: "18:30", TimeZone: "Asia/Almaty"}},
+			expectedSrvc: entity.Tasks{ID: id, Title: "Купить", Description: "**Молоко**", ActiveAt: mustDate("2023-08-04"), Status: "todo", Priority: entity.PriorityHigh, Due: &entity.DueTime{Time: "18:30", TimeZone: "Asia/Almaty"}, Owner: testUserID, Version: 1},
 			httpStatus:   http.StatusCreated,
-			responseBody: fmt.Sprintf(`{"id":"%s","title":"Купить","activeAt":"2023-08-04","status":"active"}`, id.Hex()),
+			responseBody: fmt.Sprintf(`{"id":"%s","title":"Купить","description":"**Молоко**","activeAt":"2023-08-04","status":"todo","priority":"high","due":{"time":"18:30","timeZone":"Asia/Almaty"},"weekend":false,"owner":"%s","version":1}`, id.Hex(), testUserID.Hex()),
+		},
+		{
+			name:            "invalid priority",
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", Priority: "critical"},
+			expectedSrvcErr: custom_error.ErrInvalidPriority,
+			httpStatus:      http.StatusBadRequest,
+			responseBody:    `{"code":"invalid_priority","message":"priority must be one of low, medium, high, urgent","details":[{"field":"priority","message":"priority must be one of low, medium, high, urgent"}]}`,
+		},
+		{
+			name:            "invalid due",
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", Due: &dto.DueDTO{Time: "25:00"}},
+			expectedSrvcErr: custom_error.ErrInvalidDueTime,
+			httpStatus:      http.StatusUnprocessableEntity,
+			responseBody:    `{"code":"invalid_due","message":"due time must be HH:MM with an IANA time zone","details":[{"field":"due","message":"due time must be HH:MM with an IANA time zone"}]}`,
 		},
 		{
 			name:            "activeAt invalid format",
 			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-32"},
 			expectedSrvcErr: custom_error.ErrInvalidActiveAtFormat,
-			httpStatus:      http.StatusBadRequest,
-			responseBody:    `"activeAt invalid format"`,
+			httpStatus:      http.StatusUnprocessableEntity,
+			responseBody:    `{"code":"invalid_active_at","message":"activeAt invalid format","details":[{"field":"activeAt","message":"activeAt invalid format"}]}`,
 		},
 		{
 			name:            "invalid input body",
 			dtoJson:         `{wrong}`,
 			expectedSrvcErr: custom_error.ErrInvalidInputBody,
 			httpStatus:      http.StatusBadRequest,
-			responseBody:    `"invalid input body"`,
+			responseBody:    `{"code":"invalid_input_body","message":"invalid input body"}`,
+		},
+		{
+			name:         "validation failed",
+			dtoJson:      `{"title":""}`,
+			httpStatus:   http.StatusUnprocessableEntity,
+			responseBody: `{"code":"validation_failed","message":"request validation failed","details":[{"field":"title","message":"is required"},{"field":"activeAt","message":"is required"}]}`,
+		},
+		{
+			name:            "duplicate task",
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04"},
+			expectedSrvcErr: custom_error.ErrDuplicateTask,
+			httpStatus:      http.StatusConflict,
+			responseBody:    `{"code":"duplicate_task","message":"a task with the same title already exists","details":[{"field":"title","message":"a task with the same title already exists"}]}`,
 		},
 		{
 			name: "more than 200 char",
@@ -62,8 +101,8 @@ func Test_createTask(t *testing.T) {
 				"ddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddd" +
 				"dddddddddddddddddddddddddddddddddddddddddddddddadsfdd", ActiveAt: "2023-08-04"},
 			expectedSrvcErr: custom_error.ErrMessageTooLong,
-			httpStatus:      http.StatusBadRequest,
-			responseBody:    `"more than 200 char"`,
+			httpStatus:      http.StatusUnprocessableEntity,
+			responseBody:    `{"code":"title_too_long","message":"more than 200 char","details":[{"field":"title","message":"more than 200 char"}]}`,
 		},
 	}
 
@@ -73,20 +112,21 @@ func Test_createTask(t *testing.T) {
 			defer controller.Finish()
 
 			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
 
 			handler := New(mockService)
 
 			recorder := httptest.NewRecorder()
 
 			switch testCase.name {
-			case "ok", "ok ВЫХОДНОЙ":
-				mockService.EXPECT().CreateTask(gomock.Any(), &testCase.dto).Return(&testCase.expectedSrvc, nil).Times(1)
+			case "ok", "ok ВЫХОДНОЙ", "ok details":
+				mockService.EXPECT().CreateTask(gomock.Any(), entity.PersonalAccess(testUserID), &testCase.dto).Return(&testCase.expectedSrvc, nil).Times(1)
 				break
-			case "activeAt invalid format", "more than 200 char":
-				mockService.EXPECT().CreateTask(gomock.Any(), &testCase.dto).Return(nil, testCase.expectedSrvcErr).Times(1)
+			case "activeAt invalid format", "more than 200 char", "duplicate task", "invalid priority", "invalid due":
+				mockService.EXPECT().CreateTask(gomock.Any(), entity.PersonalAccess(testUserID), &testCase.dto).Return(nil, testCase.expectedSrvcErr).Times(1)
 				break
-			case "invalid input body":
-				mockService.EXPECT().CreateTask(gomock.Any(), &testCase.dto).Return(nil, testCase.expectedSrvcErr).Times(0)
+			case "invalid input body", "validation failed":
+				mockService.EXPECT().CreateTask(gomock.Any(), entity.PersonalAccess(testUserID), &testCase.dto).Return(nil, testCase.expectedSrvcErr).Times(0)
 				break
 			}
 
@@ -96,12 +136,13 @@ func Test_createTask(t *testing.T) {
 
 			url := fmt.Sprintf("/api/todo-list/tasks/")
 			var request *http.Request
-			if &testCase.dto == nil {
+			if testCase.dtoJson != "" {
 				request, err = http.NewRequest(http.MethodPost, url, bytes.NewBufferString(testCase.dtoJson))
 			} else {
 				request, err = http.NewRequest(http.MethodPost, url, &buf)
 			}
 			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
 
 			handler.InitRouter().ServeHTTP(recorder, request)
 
@@ -143,8 +184,8 @@ func Test_updateTask(t *testing.T) {
 			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-52"},
 			id:              primitive.NewObjectID(),
 			expectedSrvcErr: custom_error.ErrInvalidActiveAtFormat,
-			httpStatus:      http.StatusBadRequest,
-			responseBody:    `"activeAt invalid format"`,
+			httpStatus:      http.StatusUnprocessableEntity,
+			responseBody:    `{"code":"invalid_active_at","message":"activeAt invalid format","details":[{"field":"activeAt","message":"activeAt invalid format"}]}`,
 		},
 		{
 			name:            "invalid id param",
@@ -153,7 +194,7 @@ func Test_updateTask(t *testing.T) {
 			idErr:           "1234",
 			expectedSrvcErr: custom_error.ErrInvalidIDParameter,
 			httpStatus:      http.StatusBadRequest,
-			responseBody:    `"invalid id param"`,
+			responseBody:    `{"code":"invalid_id","message":"invalid id param"}`,
 		},
 		{
 			name:            "invalid input body",
@@ -161,7 +202,15 @@ func Test_updateTask(t *testing.T) {
 			dtoJson:         `{wrong}`,
 			expectedSrvcErr: custom_error.ErrInvalidInputBody,
 			httpStatus:      http.StatusBadRequest,
-			responseBody:    `"invalid input body"`,
+			responseBody:    `{"code":"invalid_input_body","message":"invalid input body"}`,
+		},
+		{
+			name:            "task not found",
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04"},
+			id:              primitive.NewObjectID(),
+			expectedSrvcErr: custom_error.ErrTaskNotFound,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"task_not_found","message":"task not found"}`,
 		},
 		{
 			name: "more than 200 char",
@@ -170,8 +219,8 @@ func Test_updateTask(t *testing.T) {
 				"dddddddddddddddddddddddddddddddddddddddddddddddadsfdd", ActiveAt: "2023-08-05"},
 			id:              primitive.NewObjectID(),
 			expectedSrvcErr: custom_error.ErrMessageTooLong,
-			httpStatus:      http.StatusBadRequest,
-			responseBody:    `"more than 200 char"`,
+			httpStatus:      http.StatusUnprocessableEntity,
+			responseBody:    `{"code":"title_too_long","message":"more than 200 char","details":[{"field":"title","message":"more than 200 char"}]}`,
 		},
 	}
 
@@ -181,6 +230,7 @@ func Test_updateTask(t *testing.T) {
 			defer controller.Finish()
 
 			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
 
 			handler := New(mockService)
 
@@ -192,13 +242,13 @@ func Test_updateTask(t *testing.T) {
 
 			switch testCase.name {
 			case "ok":
-				mockService.EXPECT().UpdateTask(gomock.Any(), &testCase.dto, testCase.id).Return(nil).Times(1)
+				mockService.EXPECT().UpdateTask(gomock.Any(), testUserID, &testCase.dto, testCase.id, int64(0)).Return(nil).Times(1)
 				break
-			case "activeAt invalid format", "more than 200 char":
-				mockService.EXPECT().UpdateTask(gomock.Any(), &testCase.dto, testCase.id).Return(testCase.expectedSrvcErr).Times(1)
+			case "activeAt invalid format", "more than 200 char", "task not found":
+				mockService.EXPECT().UpdateTask(gomock.Any(), testUserID, &testCase.dto, testCase.id, int64(0)).Return(testCase.expectedSrvcErr).Times(1)
 				break
 			case "empty id param", "invalid id param", "invalid input body":
-				mockService.EXPECT().UpdateTask(gomock.Any(), &testCase.dto, testCase.idErr).Return(testCase.expectedSrvcErr).Times(0)
+				mockService.EXPECT().UpdateTask(gomock.Any(), testUserID, &testCase.dto, testCase.idErr, int64(0)).Return(testCase.expectedSrvcErr).Times(0)
 				break
 			}
 
@@ -216,6 +266,7 @@ func Test_updateTask(t *testing.T) {
 				request, err = http.NewRequest(http.MethodPut, url, &buf)
 			}
 			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
 
 			handler.InitRouter().ServeHTTP(recorder, request)
 
@@ -225,7 +276,115 @@ func Test_updateTask(t *testing.T) {
 	}
 }
 
-func Test_updateTaskStatus(t *testing.T) {
+func Test_patchTask(t *testing.T) {
+	title, activeAt, urgent := "Купить", "2023-08-05", "urgent"
+
+	table := []struct {
+		name            string
+		id              primitive.ObjectID
+		dtoJson         string
+		dto             dto.TasksPatchDTO
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:       "ok title",
+			id:         primitive.NewObjectID(),
+			dtoJson:    `{"title":"Купить"}`,
+			dto:        dto.TasksPatchDTO{Title: &title},
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:       "ok activeAt",
+			id:         primitive.NewObjectID(),
+			dtoJson:    `{"activeAt":"2023-08-05"}`,
+			dto:        dto.TasksPatchDTO{ActiveAt: &activeAt},
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:       "ok clear due",
+			id:         primitive.NewObjectID(),
+			dtoJson:    `{"due":null,"description":null,"priority":"urgent"}`,
+			dto:        dto.TasksPatchDTO{Description: new(string), Priority: &urgent, ClearDue: true},
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:         "null priority",
+			id:           primitive.NewObjectID(),
+			dtoJson:      `{"priority":null}`,
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"invalid_input_body","message":"invalid input body","details":[{"field":"priority","message":"must be string"}]}`,
+		},
+		{
+			name:            "task not found",
+			id:              primitive.NewObjectID(),
+			dtoJson:         `{"activeAt":"2023-08-05"}`,
+			dto:             dto.TasksPatchDTO{ActiveAt: &activeAt},
+			expectedSrvcErr: custom_error.ErrTaskNotFound,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"task_not_found","message":"task not found"}`,
+		},
+		{
+			name:            "empty title",
+			id:              primitive.NewObjectID(),
+			dtoJson:         `{"title":""}`,
+			dto:             dto.TasksPatchDTO{Title: new(string)},
+			expectedSrvcErr: custom_error.ErrEmptyTitle,
+			httpStatus:      http.StatusUnprocessableEntity,
+			responseBody:    `{"code":"empty_title","message":"title can not be empty","details":[{"field":"title","message":"title can not be empty"}]}`,
+		},
+		{
+			name:         "null title",
+			id:           primitive.NewObjectID(),
+			dtoJson:      `{"title":null}`,
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"invalid_input_body","message":"invalid input body","details":[{"field":"title","message":"must be string"}]}`,
+		},
+		{
+			name:         "invalid input body",
+			id:           primitive.NewObjectID(),
+			dtoJson:      `{"activeAt":20230805}`,
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"invalid_input_body","message":"invalid input body","details":[{"field":"activeAt","message":"must be string"}]}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok title", "ok activeAt", "ok clear due", "task not found", "empty title":
+				mockService.EXPECT().PatchTask(gomock.Any(), testUserID, &testCase.dto, testCase.id, int64(0)).Return(testCase.expectedSrvcErr).Times(1)
+				break
+			case "null title", "null priority", "invalid input body":
+				mockService.EXPECT().PatchTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			url := "/api/todo-list/tasks/" + testCase.id.Hex()
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewBufferString(testCase.dtoJson))
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_markTaskDone(t *testing.T) {
 	table := []struct {
 		name            string
 		id              primitive.ObjectID
@@ -248,7 +407,7 @@ func Test_updateTaskStatus(t *testing.T) {
 			status:          "done",
 			expectedSrvcErr: custom_error.ErrEmptyID,
 			httpStatus:      http.StatusBadRequest,
-			responseBody:    `"empty id param"`,
+			responseBody:    `{"code":"empty_id","message":"empty id param"}`,
 		},
 		{
 			name:            "invalid id param",
@@ -257,15 +416,15 @@ func Test_updateTaskStatus(t *testing.T) {
 			status:          "done",
 			expectedSrvcErr: custom_error.ErrInvalidIDParameter,
 			httpStatus:      http.StatusBadRequest,
-			responseBody:    `"invalid id param"`,
+			responseBody:    `{"code":"invalid_id","message":"invalid id param"}`,
 		},
 		{
-			name:            mongo.ErrNoDocuments.Error(),
+			name:            "task not found",
 			id:              primitive.NewObjectID(),
 			status:          "done",
 			expectedSrvcErr: custom_error.ErrTaskNotFound,
-			httpStatus:      http.StatusBadRequest,
-			responseBody:    fmt.Sprintf(`"%s"`, mongo.ErrNoDocuments.Error()),
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"task_not_found","message":"task not found"}`,
 		},
 	}
 
@@ -275,6 +434,7 @@ func Test_updateTaskStatus(t *testing.T) {
 			defer controller.Finish()
 
 			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
 
 			handler := New(mockService)
 
@@ -282,13 +442,13 @@ func Test_updateTaskStatus(t *testing.T) {
 
 			switch testCase.name {
 			case "ok":
-				mockService.EXPECT().UpdateTaskStatus(gomock.Any(), testCase.id, testCase.status).Return(nil).Times(1)
+				mockService.EXPECT().UpdateTaskStatus(gomock.Any(), testUserID, testCase.id, testCase.status, int64(0)).Return(nil).Times(1)
 				break
-			case mongo.ErrNoDocuments.Error():
-				mockService.EXPECT().UpdateTaskStatus(gomock.Any(), testCase.id, testCase.status).Return(mongo.ErrNoDocuments).Times(1)
+			case "task not found":
+				mockService.EXPECT().UpdateTaskStatus(gomock.Any(), testUserID, testCase.id, testCase.status, int64(0)).Return(custom_error.ErrTaskNotFound).Times(1)
 				break
 			case "empty id param", "invalid id param":
-				mockService.EXPECT().UpdateTaskStatus(gomock.Any(), testCase.id, testCase.status).Return(nil).Times(0)
+				mockService.EXPECT().UpdateTaskStatus(gomock.Any(), testUserID, testCase.id, testCase.status, int64(0)).Return(nil).Times(0)
 				break
 			}
 
@@ -300,6 +460,92 @@ func Test_updateTaskStatus(t *testing.T) {
 			}
 			request, err := http.NewRequest(http.MethodPut, url, nil)
 			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_updateTaskStatus(t *testing.T) {
+	table := []struct {
+		name            string
+		id              primitive.ObjectID
+		dtoJson         string
+		status          string
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:       "ok",
+			id:         primitive.NewObjectID(),
+			dtoJson:    `{"status":"in_progress"}`,
+			status:     "in_progress",
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:            "invalid transition",
+			id:              primitive.NewObjectID(),
+			dtoJson:         `{"status":"in_progress"}`,
+			status:          "in_progress",
+			expectedSrvcErr: fmt.Errorf("%w: from archived to in_progress", custom_error.ErrInvalidTransition),
+			httpStatus:      http.StatusConflict,
+			responseBody:    `{"code":"invalid_status_transition","message":"status transition is not allowed: from archived to in_progress","details":[{"field":"status","message":"status transition is not allowed: from archived to in_progress"}]}`,
+		},
+		{
+			name:            "task not found",
+			id:              primitive.NewObjectID(),
+			dtoJson:         `{"status":"done"}`,
+			status:          "done",
+			expectedSrvcErr: custom_error.ErrTaskNotFound,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"task_not_found","message":"task not found"}`,
+		},
+		{
+			name:         "unknown status",
+			id:           primitive.NewObjectID(),
+			dtoJson:      `{"status":"paused"}`,
+			httpStatus:   http.StatusUnprocessableEntity,
+			responseBody: `{"code":"validation_failed","message":"request validation failed","details":[{"field":"status","message":"must be one of todo in_progress done archived"}]}`,
+		},
+		{
+			name:         "empty status",
+			id:           primitive.NewObjectID(),
+			dtoJson:      `{}`,
+			httpStatus:   http.StatusUnprocessableEntity,
+			responseBody: `{"code":"validation_failed","message":"request validation failed","details":[{"field":"status","message":"is required"}]}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok", "invalid transition", "task not found":
+				mockService.EXPECT().UpdateTaskStatus(gomock.Any(), testUserID, testCase.id, testCase.status, int64(0)).Return(testCase.expectedSrvcErr).Times(1)
+				break
+			case "unknown status", "empty status":
+				mockService.EXPECT().UpdateTaskStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			url := "/api/todo-list/tasks/" + testCase.id.Hex() + "/status"
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewBufferString(testCase.dtoJson))
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
 
 			handler.InitRouter().ServeHTTP(recorder, request)
 
@@ -335,14 +581,14 @@ func Test_deleteTask(t *testing.T) {
 			id:           primitive.NilObjectID,
 			idErr:        "1234",
 			httpStatus:   http.StatusBadRequest,
-			responseBody: `"invalid id param"`,
+			responseBody: `{"code":"invalid_id","message":"invalid id param"}`,
 		},
 		{
 			name:            "task not found",
 			id:              primitive.NewObjectID(),
 			expectedSrvcErr: custom_error.ErrTaskNotFound,
-			httpStatus:      http.StatusBadRequest,
-			responseBody:    `"task not found"`,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"task_not_found","message":"task not found"}`,
 		},
 	}
 
@@ -352,6 +598,7 @@ func Test_deleteTask(t *testing.T) {
 			defer controller.Finish()
 
 			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
 
 			handler := New(mockService)
 
@@ -359,13 +606,13 @@ func Test_deleteTask(t *testing.T) {
 
 			switch testCase.name {
 			case "ok":
-				mockService.EXPECT().DeleteTask(gomock.Any(), testCase.id).Return(nil).Times(1)
+				mockService.EXPECT().DeleteTask(gomock.Any(), testUserID, testCase.id, int64(0)).Return(nil).Times(1)
 				break
 			case "task not found":
-				mockService.EXPECT().DeleteTask(gomock.Any(), testCase.id).Return(custom_error.ErrTaskNotFound).Times(1)
+				mockService.EXPECT().DeleteTask(gomock.Any(), testUserID, testCase.id, int64(0)).Return(custom_error.ErrTaskNotFound).Times(1)
 				break
 			case "empty id param", "invalid id param":
-				mockService.EXPECT().DeleteTask(gomock.Any(), testCase.id).Return(nil).Times(0)
+				mockService.EXPECT().DeleteTask(gomock.Any(), testUserID, testCase.id, int64(0)).Return(nil).Times(0)
 				break
 			}
 
@@ -378,6 +625,7 @@ func Test_deleteTask(t *testing.T) {
 
 			request, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
 
 			handler.InitRouter().ServeHTTP(recorder, request)
 
@@ -387,17 +635,259 @@ func Test_deleteTask(t *testing.T) {
 	}
 }
 
+func Test_restoreTask(t *testing.T) {
+	table := []struct {
+		name            string
+		id              primitive.ObjectID
+		idErr           string
+		expectedSrvcErr error
+		responseBody    string
+		httpStatus      int
+	}{
+		{
+			name:       "ok",
+			id:         primitive.NewObjectID(),
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:         "invalid id param",
+			id:           primitive.NilObjectID,
+			idErr:        "1234",
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"invalid_id","message":"invalid id param"}`,
+		},
+		{
+			name:            "task not found",
+			id:              primitive.NewObjectID(),
+			expectedSrvcErr: custom_error.ErrTaskNotFound,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"task_not_found","message":"task not found"}`,
+		},
+		{
+			name:            "title taken",
+			id:              primitive.NewObjectID(),
+			expectedSrvcErr: custom_error.ErrDuplicateTask,
+			httpStatus:      http.StatusConflict,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok", "task not found", "title taken":
+				mockService.EXPECT().RestoreTask(gomock.Any(), testUserID, testCase.id).Return(testCase.expectedSrvcErr).Times(1)
+				break
+			case "invalid id param":
+				mockService.EXPECT().RestoreTask(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			var url string
+			if testCase.id == primitive.NilObjectID {
+				url = fmt.Sprintf("/api/todo-list/tasks/" + testCase.idErr + "/restore")
+			} else {
+				url = fmt.Sprintf("/api/todo-list/tasks/" + testCase.id.Hex() + "/restore")
+			}
+
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			if testCase.responseBody != "" {
+				require.Equal(t, testCase.responseBody, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func Test_searchTasks(t *testing.T) {
+	id := primitive.NewObjectID()
+	table := []struct {
+		name            string
+		rawQuery        string
+		query           dto.TasksSearchDTO
+		expectedService dto.TasksPageDTO
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:            "ok",
+			rawQuery:        "?q=куп&status=done&limit=1",
+			query:           dto.TasksSearchDTO{Q: "куп", Status: "done", Limit: 1},
+			expectedService: dto.TasksPageDTO{Tasks: []entity.Tasks{{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "done", Owner: testUserID, Version: 1}}, NextCursor: "MQ", Total: 2},
+			httpStatus:      http.StatusOK,
+			responseBody:    fmt.Sprintf(`{"tasks":[{"id":"%s","title":"Купить","activeAt":"2023-08-05","status":"done","priority":"medium","weekend":false,"owner":"%s","version":1}],"nextCursor":"MQ","total":2}`, id.Hex(), testUserID.Hex()),
+		},
+		{
+			name:         "empty q",
+			rawQuery:     "?status=done",
+			httpStatus:   http.StatusUnprocessableEntity,
+			responseBody: `{"code":"validation_failed","message":"request validation failed","details":[{"field":"q","message":"is required"}]}`,
+		},
+		{
+			name:            "invalid search query",
+			rawQuery:        "?q=---",
+			query:           dto.TasksSearchDTO{Q: "---"},
+			expectedSrvcErr: custom_error.ErrInvalidSearchQuery,
+			httpStatus:      http.StatusBadRequest,
+			responseBody:    `{"code":"invalid_search_query","message":"q must contain from 1 to 10 words","details":[{"field":"q","message":"q must contain from 1 to 10 words"}]}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok":
+				mockService.EXPECT().SearchTasks(gomock.Any(), testUserID, &testCase.query).Return(&testCase.expectedService, nil).Times(1)
+				break
+			case "invalid search query":
+				mockService.EXPECT().SearchTasks(gomock.Any(), testUserID, &testCase.query).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			case "empty q":
+				mockService.EXPECT().SearchTasks(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			url := fmt.Sprintf("/api/todo-list/tasks/search" + testCase.rawQuery)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_getTrash(t *testing.T) {
+	id := primitive.NewObjectID()
+	deletedAt := time.Date(2023, time.August, 9, 15, 0, 0, 0, time.UTC)
+	table := []struct {
+		name            string
+		rawQuery        string
+		query           dto.TasksQueryDTO
+		expectedService dto.TasksPageDTO
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:            "ok",
+			rawQuery:        "?status=done",
+			query:           dto.TasksQueryDTO{Status: "done"},
+			expectedService: dto.TasksPageDTO{Tasks: []entity.Tasks{{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "done", Owner: testUserID, Version: 2, DeletedAt: &deletedAt}}, Total: 1},
+			httpStatus:      http.StatusOK,
+			responseBody:    fmt.Sprintf(`{"tasks":[{"id":"%s","title":"Купить","activeAt":"2023-08-05","status":"done","priority":"medium","weekend":false,"owner":"%s","version":2,"deletedAt":"2023-08-09T15:00:00Z"}],"total":1}`, id.Hex(), testUserID.Hex()),
+		},
+		{
+			name:            "invalid status",
+			rawQuery:        "?status=deleted",
+			query:           dto.TasksQueryDTO{Status: "deleted"},
+			expectedSrvcErr: custom_error.ErrInvalidStatus,
+			httpStatus:      http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok":
+				mockService.EXPECT().GetTrash(gomock.Any(), testUserID, &testCase.query).Return(&testCase.expectedService, nil).Times(1)
+				break
+			case "invalid status":
+				mockService.EXPECT().GetTrash(gomock.Any(), testUserID, &testCase.query).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			}
+
+			url := fmt.Sprintf("/api/todo-list/tasks/trash" + testCase.rawQuery)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			if testCase.responseBody != "" {
+				require.Equal(t, testCase.responseBody, recorder.Body.String())
+			}
+		})
+	}
+}
+
 func Test_getAllTasks(t *testing.T) {
+	id := primitive.NewObjectID()
 	table := []struct {
 		name            string
-		status          string
-		expectedService []entity.Tasks
+		rawQuery        string
+		query           dto.TasksQueryDTO
+		expectedService dto.TasksPageDTO
+		expectedSrvcErr error
 		httpStatus      int
+		responseBody    string
 	}{
 		{
 			name:            "ok",
-			expectedService: []entity.Tasks{{ID: primitive.NewObjectID(), Title: "Купить", ActiveAt: "2023-08-05", Status: "active"}},
+			expectedService: dto.TasksPageDTO{Tasks: []entity.Tasks{{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "todo", Owner: testUserID, Version: 1}}, Total: 1},
+			httpStatus:      http.StatusOK,
+			responseBody:    fmt.Sprintf(`{"tasks":[{"id":"%s","title":"Купить","activeAt":"2023-08-05","status":"todo","priority":"medium","weekend":false,"owner":"%s","version":1}],"total":1}`, id.Hex(), testUserID.Hex()),
+		},
+		{
+			name:            "ok with params",
+			rawQuery:        "?status=done&limit=1&sort=-title&cursor=abc",
+			query:           dto.TasksQueryDTO{Status: "done", Limit: 1, Sort: "-title", Cursor: "abc"},
+			expectedService: dto.TasksPageDTO{Tasks: []entity.Tasks{{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "done", Owner: testUserID, Version: 1}}, NextCursor: "def", Total: 2},
 			httpStatus:      http.StatusOK,
+			responseBody:    fmt.Sprintf(`{"tasks":[{"id":"%s","title":"Купить","activeAt":"2023-08-05","status":"done","priority":"medium","weekend":false,"owner":"%s","version":1}],"nextCursor":"def","total":2}`, id.Hex(), testUserID.Hex()),
+		},
+		{
+			name:         "invalid query params",
+			rawQuery:     "?limit=abc",
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"invalid_query_params","message":"invalid query params"}`,
+		},
+		{
+			name:            "invalid sort",
+			rawQuery:        "?sort=status",
+			query:           dto.TasksQueryDTO{Sort: "status"},
+			expectedSrvcErr: custom_error.ErrInvalidSort,
+			httpStatus:      http.StatusBadRequest,
+			responseBody:    `{"code":"invalid_sort","message":"sort must be one of activeAt, title, priority, createdAt","details":[{"field":"sort","message":"sort must be one of activeAt, title, priority, createdAt"}]}`,
 		},
 	}
 
@@ -407,20 +897,206 @@ func Test_getAllTasks(t *testing.T) {
 			defer controller.Finish()
 
 			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
 
 			handler := New(mockService)
 
 			recorder := httptest.NewRecorder()
 
-			mockService.EXPECT().GetAllTasks(gomock.Any(), testCase.status).Return(testCase.expectedService, nil).Times(1)
+			switch testCase.name {
+			case "ok", "ok with params":
+				mockService.EXPECT().GetAllTasks(gomock.Any(), testUserID, &testCase.query).Return(&testCase.expectedService, nil).Times(1)
+				break
+			case "invalid sort":
+				mockService.EXPECT().GetAllTasks(gomock.Any(), testUserID, &testCase.query).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			case "invalid query params":
+				mockService.EXPECT().GetAllTasks(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
 
-			url := fmt.Sprintf("/api/todo-list/tasks/" + testCase.status)
+			url := fmt.Sprintf("/api/todo-list/tasks/" + testCase.rawQuery)
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
 
 			handler.InitRouter().ServeHTTP(recorder, request)
 
 			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
 		})
 	}
 }
+
+func Test_getTaskByID(t *testing.T) {
+	id := primitive.NewObjectID()
+	table := []struct {
+		name            string
+		id              primitive.ObjectID
+		idErr           string
+		expectedSrvc    entity.Tasks
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:         "ok",
+			id:           id,
+			expectedSrvc: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: testUserID, Version: 1},
+			httpStatus:   http.StatusOK,
+			responseBody: fmt.Sprintf(`{"id":"%s","title":"Купить","activeAt":"2023-08-04","status":"todo","priority":"medium","weekend":false,"owner":"%s","version":1}`, id.Hex(), testUserID.Hex()),
+		},
+		{
+			name:         "invalid id param",
+			id:           primitive.NilObjectID,
+			idErr:        "1234",
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"invalid_id","message":"invalid id param"}`,
+		},
+		{
+			name:            "task not found",
+			id:              id,
+			expectedSrvcErr: custom_error.ErrTaskNotFound,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"task_not_found","message":"task not found"}`,
+		},
+		{
+			name:            "internal error",
+			id:              id,
+			expectedSrvcErr: errors.New("connection refused"),
+			httpStatus:      http.StatusInternalServerError,
+			responseBody:    `{"code":"internal_error","message":"Internal Server Error"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "ok":
+				mockService.EXPECT().GetTaskByID(gomock.Any(), testUserID, testCase.id).Return(&testCase.expectedSrvc, nil).Times(1)
+				break
+			case "task not found", "internal error":
+				mockService.EXPECT().GetTaskByID(gomock.Any(), testUserID, testCase.id).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			case "invalid id param":
+				mockService.EXPECT().GetTaskByID(gomock.Any(), testUserID, testCase.id).Times(0)
+				break
+			}
+
+			var url string
+			if testCase.id == primitive.NilObjectID {
+				url = fmt.Sprintf("/api/todo-list/tasks/" + testCase.idErr)
+			} else {
+				url = fmt.Sprintf("/api/todo-list/tasks/" + testCase.id.Hex())
+			}
+
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+			if testCase.name == "ok" {
+				require.Equal(t, `"1"`, recorder.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func Test_ifMatch(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		ifMatch         string
+		version         int64
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:       "without If-Match",
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:       "any version",
+			ifMatch:    "*",
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:       "version",
+			ifMatch:    `"3"`,
+			version:    3,
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:            "stale version",
+			ifMatch:         `"2"`,
+			version:         2,
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+			httpStatus:      http.StatusPreconditionFailed,
+			responseBody:    `{"code":"precondition_failed","message":"task was modified, its version does not match If-Match"}`,
+		},
+		{
+			name:         "invalid If-Match",
+			ifMatch:      `W/"3"`,
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"invalid_if_match","message":"If-Match must be a task version ETag or *"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "without If-Match", "any version", "version", "stale version":
+				mockService.EXPECT().DeleteTask(gomock.Any(), testUserID, id, testCase.version).Return(testCase.expectedSrvcErr).Times(1)
+				break
+			case "invalid If-Match":
+				mockService.EXPECT().DeleteTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			request, err := http.NewRequest(http.MethodDelete, "/api/todo-list/tasks/"+id.Hex(), nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+			if testCase.ifMatch != "" {
+				request.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func mustDate(s string) entity.Date {
+	d, err := entity.ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
@@ -0,0 +1,356 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"log"
+	"net/http"
+)
+
+// createWorkspace 	Create workspace
+// @Summary      Create workspace
+// @Description  Create a workspace shared with teammates, the user becomes its owner. Tasks and lists of the workspace are requested with the X-Workspace-ID header
+// @Security     ApiKeyAuth
+// @Tags         workspace
+// @Accept       json
+// @Produce      json
+// @Param request body dto.WorkspaceDTO true "req body"
+// @Success      201  {object}  entity.Workspace
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /workspaces [post]
+func (h *Handler) createWorkspace(ctx *gin.Context) {
+	var req dto.WorkspaceDTO
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	workspace, err := h.srvs.CreateWorkspace(ctx, getUserID(ctx), &req)
+	if err != nil {
+		log.Printf("can not create workspace: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, workspace)
+}
+
+// getWorkspaces 	Get workspaces
+// @Summary      Get workspaces
+// @Description  Get workspaces the user is a member of, with the user's role
+// @Security     ApiKeyAuth
+// @Tags         workspace
+// @Produce      json
+// @Success      200  {object}  dto.WorkspacesDTO
+// @Failure      401  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /workspaces [get]
+func (h *Handler) getWorkspaces(ctx *gin.Context) {
+	workspaces, err := h.srvs.GetWorkspaces(ctx, getUserID(ctx))
+	if err != nil {
+		log.Printf("can not get workspaces: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	if workspaces == nil {
+		workspaces = make([]entity.Workspace, 0)
+	}
+
+	ctx.JSON(http.StatusOK, dto.WorkspacesDTO{Workspaces: workspaces})
+}
+
+// getWorkspaceByID 	Get workspace by id
+// @Summary      Get workspace by id
+// @Description  Get a workspace the user is a member of
+// @Security     ApiKeyAuth
+// @Tags         workspace
+// @Produce      json
+// @Param 		 id   path      string  true  "Workspace ID"
+// @Success      200  {object}  entity.Workspace
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /workspaces/{id} [get]
+func (h *Handler) getWorkspaceByID(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	workspace, err := h.srvs.GetWorkspaceByID(ctx, getUserID(ctx), id)
+	if err != nil {
+		log.Printf("can not get workspace: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workspace)
+}
+
+// updateWorkspace 	Update workspace
+// @Summary      Update workspace
+// @Description  Rename a workspace, only the owner can do it
+// @Security     ApiKeyAuth
+// @Tags         workspace
+// @Accept       json
+// @Param 		 id   path      string  true  "Workspace ID"
+// @Param request body dto.WorkspaceDTO true "req body"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      403  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /workspaces/{id} [put]
+func (h *Handler) updateWorkspace(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.WorkspaceDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	err = h.srvs.UpdateWorkspace(ctx, getUserID(ctx), id, &req)
+	if err != nil {
+		log.Printf("can not update workspace: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// deleteWorkspace 	Delete workspace
+// @Summary      Delete workspace
+// @Description  Delete a workspace with all its tasks, lists, members and invitations, only the owner can do it
+// @Security     ApiKeyAuth
+// @Tags         workspace
+// @Param 		 id   path      string  true  "Workspace ID"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      403  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /workspaces/{id} [delete]
+func (h *Handler) deleteWorkspace(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	err = h.srvs.DeleteWorkspace(ctx, getUserID(ctx), id)
+	if err != nil {
+		log.Printf("can not delete workspace: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// getMembers 	Get workspace members
+// @Summary      Get workspace members
+// @Description  Get members of a workspace with their roles, ordered by username
+// @Security     ApiKeyAuth
+// @Tags         workspace
+// @Produce      json
+// @Param 		 id   path      string  true  "Workspace ID"
+// @Success      200  {object}  dto.MembersDTO
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /workspaces/{id}/members [get]
+func (h *Handler) getMembers(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	members, err := h.srvs.GetMembers(ctx, getUserID(ctx), id)
+	if err != nil {
+		log.Printf("can not get members: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	if members == nil {
+		members = make([]entity.Member, 0)
+	}
+
+	ctx.JSON(http.StatusOK, dto.MembersDTO{Members: members})
+}
+
+// updateMemberRole 	Update member role
+// @Summary      Update member role
+// @Description  Make a member an editor or a viewer, only the owner can do it. The owner's role can not be changed
+// @Security     ApiKeyAuth
+// @Tags         workspace
+// @Accept       json
+// @Param 		 id      path      string  true  "Workspace ID"
+// @Param 		 userId  path      string  true  "Member user ID"
+// @Param request body dto.MemberRoleDTO true "req body"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      403  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /workspaces/{id}/members/{userId} [put]
+func (h *Handler) updateMemberRole(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	memberID, err := parseIdFromPath(ctx, "userId")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.MemberRoleDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	err = h.srvs.UpdateMemberRole(ctx, getUserID(ctx), id, memberID, &req)
+	if err != nil {
+		log.Printf("can not update member role: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// removeMember 	Remove member
+// @Summary      Remove member
+// @Description  Remove a member from a workspace. The owner can remove anyone else, any other member can leave
+// @Security     ApiKeyAuth
+// @Tags         workspace
+// @Param 		 id      path      string  true  "Workspace ID"
+// @Param 		 userId  path      string  true  "Member user ID"
+// @Success      204
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      403  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /workspaces/{id}/members/{userId} [delete]
+func (h *Handler) removeMember(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	memberID, err := parseIdFromPath(ctx, "userId")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	err = h.srvs.RemoveMember(ctx, getUserID(ctx), id, memberID)
+	if err != nil {
+		log.Printf("can not remove member: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// createInvitation 	Create invitation
+// @Summary      Create invitation
+// @Description  Create a single-use invitation token that gives the accepting user the role. Only the owner can invite, the token is shown only once
+// @Security     ApiKeyAuth
+// @Tags         workspace
+// @Accept       json
+// @Produce      json
+// @Param 		 id   path      string  true  "Workspace ID"
+// @Param request body dto.InvitationDTO true "req body"
+// @Success      201  {object}  entity.Invitation
+// @Failure      400  {object}  dto.Error
+// @Failure      401  {object}  dto.Error
+// @Failure      403  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      422  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /workspaces/{id}/invitations [post]
+func (h *Handler) createInvitation(ctx *gin.Context) {
+	id, err := parseIdFromPath(ctx, "id")
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var req dto.InvitationDTO
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("bind json err: %s \n", err.Error())
+		abortWithBindError(ctx, custom_error.ErrInvalidInputBody, err)
+		return
+	}
+
+	invitation, err := h.srvs.CreateInvitation(ctx, getUserID(ctx), id, &req)
+	if err != nil {
+		log.Printf("can not create invitation: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, invitation)
+}
+
+// acceptInvitation 	Accept invitation
+// @Summary      Accept invitation
+// @Description  Join the workspace of the invitation with its role. An invitation can be accepted only once
+// @Security     ApiKeyAuth
+// @Tags         workspace
+// @Produce      json
+// @Param 		 token   path      string  true  "Invitation token"
+// @Success      200  {object}  entity.Member
+// @Failure      401  {object}  dto.Error
+// @Failure      404  {object}  dto.Error
+// @Failure      409  {object}  dto.Error
+// @Failure      500  {object}  dto.Error
+// @Router       /invitations/{token}/accept [post]
+func (h *Handler) acceptInvitation(ctx *gin.Context) {
+	member, err := h.srvs.AcceptInvitation(ctx, getUserID(ctx), ctx.Param("token"))
+	if err != nil {
+		log.Printf("can not accept invitation: %s \n", err.Error())
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
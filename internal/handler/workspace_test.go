@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_service "github.com/khussa1n/todo-list/internal/service/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_workspaceAccess(t *testing.T) {
+	id := primitive.NewObjectID()
+	workspaceID := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		method          string
+		header          string
+		role            entity.Role
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:         "viewer reads",
+			method:       http.MethodGet,
+			header:       workspaceID.Hex(),
+			role:         entity.RoleViewer,
+			httpStatus:   http.StatusOK,
+			responseBody: fmt.Sprintf(`{"id":"%s","title":"Купить","activeAt":"2023-08-04","status":"todo","priority":"medium","weekend":false,"owner":"%s","workspaceId":"%s","version":1}`, id.Hex(), workspaceID.Hex(), workspaceID.Hex()),
+		},
+		{
+			name:         "viewer deletes",
+			method:       http.MethodDelete,
+			header:       workspaceID.Hex(),
+			role:         entity.RoleViewer,
+			httpStatus:   http.StatusForbidden,
+			responseBody: `{"code":"forbidden","message":"your workspace role does not allow this operation"}`,
+		},
+		{
+			name:       "editor deletes",
+			method:     http.MethodDelete,
+			header:     workspaceID.Hex(),
+			role:       entity.RoleEditor,
+			httpStatus: http.StatusNoContent,
+		},
+		{
+			name:            "not a member",
+			method:          http.MethodGet,
+			header:          workspaceID.Hex(),
+			expectedSrvcErr: custom_error.ErrWorkspaceNotFound,
+			httpStatus:      http.StatusNotFound,
+			responseBody:    `{"code":"workspace_not_found","message":"workspace not found"}`,
+		},
+		{
+			name:         "invalid workspace id",
+			method:       http.MethodGet,
+			header:       "team",
+			httpStatus:   http.StatusBadRequest,
+			responseBody: `{"code":"invalid_workspace_id","message":"X-Workspace-ID must be a workspace ID"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			access := &entity.Access{UserID: testUserID, Owner: workspaceID, Workspace: &workspaceID, Role: testCase.role}
+
+			switch testCase.name {
+			case "viewer reads":
+				task := entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: workspaceID, Workspace: &workspaceID, Version: 1}
+				mockService.EXPECT().GetAccess(gomock.Any(), testUserID, workspaceID).Return(access, nil).Times(1)
+				mockService.EXPECT().GetTaskByID(gomock.Any(), workspaceID, id).Return(&task, nil).Times(1)
+				break
+			case "viewer deletes":
+				mockService.EXPECT().GetAccess(gomock.Any(), testUserID, workspaceID).Return(access, nil).Times(1)
+				mockService.EXPECT().DeleteTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "editor deletes":
+				mockService.EXPECT().GetAccess(gomock.Any(), testUserID, workspaceID).Return(access, nil).Times(1)
+				mockService.EXPECT().DeleteTask(gomock.Any(), workspaceID, id, int64(0)).Return(nil).Times(1)
+				break
+			case "not a member":
+				mockService.EXPECT().GetAccess(gomock.Any(), testUserID, workspaceID).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			case "invalid workspace id":
+				mockService.EXPECT().GetAccess(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			request, err := http.NewRequest(testCase.method, "/api/todo-list/tasks/"+id.Hex(), nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+			request.Header.Set("X-Workspace-ID", testCase.header)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_createInvitation(t *testing.T) {
+	workspaceID := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		body            string
+		expectedSrvcErr error
+		httpStatus      int
+		responseBody    string
+	}{
+		{
+			name:         "owner role",
+			body:         `{"role":"owner"}`,
+			httpStatus:   http.StatusUnprocessableEntity,
+			responseBody: `{"code":"validation_failed","message":"request validation failed","details":[{"field":"role","message":"must be one of editor viewer"}]}`,
+		},
+		{
+			name:            "not the owner",
+			body:            `{"role":"viewer"}`,
+			expectedSrvcErr: custom_error.ErrForbidden,
+			httpStatus:      http.StatusForbidden,
+			responseBody:    `{"code":"forbidden","message":"your workspace role does not allow this operation"}`,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockService := mock_service.NewMockService(controller)
+			mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+			handler := New(mockService)
+
+			recorder := httptest.NewRecorder()
+
+			switch testCase.name {
+			case "owner role":
+				mockService.EXPECT().CreateInvitation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "not the owner":
+				req := dto.InvitationDTO{Role: entity.RoleViewer}
+				mockService.EXPECT().CreateInvitation(gomock.Any(), testUserID, workspaceID, &req).Return(nil, testCase.expectedSrvcErr).Times(1)
+				break
+			}
+
+			request, err := http.NewRequest(http.MethodPost, "/api/todo-list/workspaces/"+workspaceID.Hex()+"/invitations", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+
+			handler.InitRouter().ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.httpStatus, recorder.Code)
+			require.Equal(t, testCase.responseBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_acceptInvitation(t *testing.T) {
+	workspaceID := primitive.NewObjectID()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := mock_service.NewMockService(controller)
+	mockService.EXPECT().ParseToken(gomock.Any(), testToken).Return(testUserID, nil).AnyTimes()
+
+	member := &entity.Member{Workspace: workspaceID, UserID: testUserID, Username: "dana", Role: entity.RoleEditor}
+	mockService.EXPECT().AcceptInvitation(gomock.Any(), testUserID, "abc").Return(member, nil).Times(1)
+
+	handler := New(mockService)
+
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodPost, "/api/todo-list/invitations/abc/accept", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer "+testToken)
+
+	handler.InitRouter().ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got entity.Member
+	err = json.NewDecoder(recorder.Body).Decode(&got)
+	require.NoError(t, err)
+	require.Equal(t, *member, got)
+}
@@ -0,0 +1,113 @@
+package memrepo
+
+import (
+	"bytes"
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+)
+
+func (m *Memory) CreateAttachment(ctx context.Context, a *entity.Attachment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if a.ID.IsZero() {
+		a.ID = primitive.NewObjectID()
+	}
+
+	m.attachments[a.ID] = *a
+
+	return nil
+}
+
+func (m *Memory) GetAttachments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var attachments []entity.Attachment
+	for _, attachment := range m.attachments {
+		if attachment.Owner == owner && attachment.TaskID == taskID {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	sortAttachments(attachments)
+
+	return attachments, nil
+}
+
+func (m *Memory) GetAttachmentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	attachment, ok := m.attachments[id]
+	if !ok || attachment.Owner != owner || attachment.TaskID != taskID {
+		return nil, custom_error.ErrAttachmentNotFound
+	}
+
+	return &attachment, nil
+}
+
+func (m *Memory) DeleteAttachment(ctx context.Context, owner, taskID, id primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attachment, ok := m.attachments[id]
+	if !ok || attachment.Owner != owner || attachment.TaskID != taskID {
+		return custom_error.ErrAttachmentNotFound
+	}
+
+	delete(m.attachments, id)
+
+	return nil
+}
+
+func (m *Memory) DeleteOwnerAttachments(ctx context.Context, owner primitive.ObjectID) ([]entity.Attachment, error) {
+	return m.deleteAttachments(func(a entity.Attachment) bool {
+		return a.Owner == owner
+	}), nil
+}
+
+// deleteAttachments удаляет вложения, для которых match возвращает true, и возвращает их
+func (m *Memory) deleteAttachments(match func(a entity.Attachment) bool) []entity.Attachment {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.removeAttachments(match)
+}
+
+// removePurgedAttachments удаляет вложения задач ids, удаленных насовсем, и возвращает их.
+// Вызывается под m.mu
+func (m *Memory) removePurgedAttachments(ids []primitive.ObjectID) []entity.Attachment {
+	purged := make(map[primitive.ObjectID]bool, len(ids))
+	for _, id := range ids {
+		purged[id] = true
+	}
+
+	return m.removeAttachments(func(a entity.Attachment) bool {
+		return purged[a.TaskID]
+	})
+}
+
+// removeAttachments - deleteAttachments без блокировки. Вызывается под m.mu
+func (m *Memory) removeAttachments(match func(a entity.Attachment) bool) []entity.Attachment {
+	var deleted []entity.Attachment
+	for id, attachment := range m.attachments {
+		if match(attachment) {
+			deleted = append(deleted, attachment)
+			delete(m.attachments, id)
+		}
+	}
+
+	sortAttachments(deleted)
+
+	return deleted
+}
+
+func sortAttachments(attachments []entity.Attachment) {
+	sort.Slice(attachments, func(i, j int) bool {
+		return bytes.Compare(attachments[i].ID[:], attachments[j].ID[:]) < 0
+	})
+}
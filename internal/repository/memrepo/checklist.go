@@ -0,0 +1,24 @@
+package memrepo
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (m *Memory) UpdateChecklist(ctx context.Context, owner, id primitive.ObjectID, checklist []entity.ChecklistItem, status string, version int64) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.versionedTask(owner, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Checklist = checklist
+	task.Status = status
+	task.Version++
+	m.tasks[id] = copyTask(task)
+
+	return &task, nil
+}
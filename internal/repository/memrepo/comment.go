@@ -0,0 +1,114 @@
+package memrepo
+
+import (
+	"bytes"
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+)
+
+func (m *Memory) CreateComment(ctx context.Context, c *entity.Comment) (*entity.Comment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c.ID.IsZero() {
+		c.ID = primitive.NewObjectID()
+	}
+
+	m.comments[c.ID] = copyComment(*c)
+
+	return c, nil
+}
+
+func (m *Memory) GetComments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Comment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var comments []entity.Comment
+	for _, comment := range m.comments {
+		if comment.Owner == owner && comment.TaskID == taskID {
+			comments = append(comments, copyComment(comment))
+		}
+	}
+
+	sort.Slice(comments, func(i, j int) bool {
+		return bytes.Compare(comments[i].ID[:], comments[j].ID[:]) < 0
+	})
+
+	return comments, nil
+}
+
+func (m *Memory) GetCommentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Comment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	comment, ok := m.ownComment(owner, taskID, id)
+	if !ok {
+		return nil, custom_error.ErrCommentNotFound
+	}
+
+	comment = copyComment(comment)
+
+	return &comment, nil
+}
+
+func (m *Memory) UpdateComment(ctx context.Context, c *entity.Comment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	comment, ok := m.ownComment(c.Owner, c.TaskID, c.ID)
+	if !ok {
+		return custom_error.ErrCommentNotFound
+	}
+
+	comment.Body = c.Body
+	comment.UpdatedAt = c.UpdatedAt
+	comment.Edits = append([]entity.CommentEdit(nil), c.Edits...)
+	m.comments[c.ID] = comment
+
+	return nil
+}
+
+func (m *Memory) DeleteComment(ctx context.Context, owner, taskID, id primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.ownComment(owner, taskID, id); !ok {
+		return custom_error.ErrCommentNotFound
+	}
+
+	delete(m.comments, id)
+
+	return nil
+}
+
+// removePurgedComments удаляет комментарии задач ids, удаленных насовсем. Вызывается под m.mu
+func (m *Memory) removePurgedComments(ids []primitive.ObjectID) {
+	purged := make(map[primitive.ObjectID]bool, len(ids))
+	for _, id := range ids {
+		purged[id] = true
+	}
+
+	for id, comment := range m.comments {
+		if purged[comment.TaskID] {
+			delete(m.comments, id)
+		}
+	}
+}
+
+// ownComment возвращает комментарий id к задаче taskID владельца owner. Вызывается под m.mu
+func (m *Memory) ownComment(owner, taskID, id primitive.ObjectID) (entity.Comment, bool) {
+	comment, ok := m.comments[id]
+	if !ok || comment.Owner != owner || comment.TaskID != taskID {
+		return entity.Comment{}, false
+	}
+	return comment, true
+}
+
+// copyComment копирует историю правок, чтобы вызывающий код не менял хранилище
+func copyComment(c entity.Comment) entity.Comment {
+	c.Edits = append([]entity.CommentEdit(nil), c.Edits...)
+	return c
+}
@@ -0,0 +1,61 @@
+package memrepo
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (m *Memory) GetTasksByIDs(ctx context.Context, owner primitive.ObjectID, ids []primitive.ObjectID) ([]entity.Tasks, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var tasks []entity.Tasks
+	for _, id := range ids {
+		if task, ok := m.liveTask(owner, id); ok {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, nil
+}
+
+func (m *Memory) AddDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.versionedTask(owner, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if !task.DependsOn(blocker) {
+		task.Dependencies = append(task.Dependencies, blocker)
+	}
+	task.Version++
+	m.tasks[id] = copyTask(task)
+
+	return &task, nil
+}
+
+func (m *Memory) RemoveDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.versionedTask(owner, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependencies []primitive.ObjectID
+	for _, dependency := range task.Dependencies {
+		if dependency != blocker {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	task.Dependencies = dependencies
+	task.Version++
+	m.tasks[id] = copyTask(task)
+
+	return &task, nil
+}
@@ -0,0 +1,64 @@
+package memrepo
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (m *Memory) CreateHistoryEntry(ctx context.Context, e *entity.HistoryEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, entry := range m.history[e.TaskID] {
+		if entry.Owner == e.Owner && entry.Version == e.Version {
+			return custom_error.ErrVersionMismatch
+		}
+	}
+
+	if e.ID.IsZero() {
+		e.ID = primitive.NewObjectID()
+	}
+
+	m.history[e.TaskID] = append(m.history[e.TaskID], copyHistoryEntry(*e))
+
+	return nil
+}
+
+func (m *Memory) GetHistory(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.HistoryEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var history []entity.HistoryEntry
+	for _, entry := range m.history[taskID] {
+		if entry.Owner == owner {
+			history = append(history, copyHistoryEntry(entry))
+		}
+	}
+
+	return history, nil
+}
+
+func (m *Memory) GetHistoryEntry(ctx context.Context, owner, taskID primitive.ObjectID, version int64) (*entity.HistoryEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, entry := range m.history[taskID] {
+		if entry.Owner == owner && entry.Version == version {
+			entry = copyHistoryEntry(entry)
+			return &entry, nil
+		}
+	}
+
+	return nil, custom_error.ErrHistoryNotFound
+}
+
+// copyHistoryEntry копирует изменения и срезы снимка задачи, чтобы вызывающий код не менял хранилище
+func copyHistoryEntry(e entity.HistoryEntry) entity.HistoryEntry {
+	e.Changes = append([]entity.FieldChange(nil), e.Changes...)
+	e.Snapshot.Tags = append([]string(nil), e.Snapshot.Tags...)
+	e.Snapshot.Checklist = append([]entity.ChecklistItem(nil), e.Snapshot.Checklist...)
+	e.Snapshot.Dependencies = append([]primitive.ObjectID(nil), e.Snapshot.Dependencies...)
+	return e
+}
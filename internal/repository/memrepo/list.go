@@ -0,0 +1,137 @@
+package memrepo
+
+import (
+	"bytes"
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+)
+
+func (m *Memory) CreateList(ctx context.Context, l *entity.List) (*entity.List, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.listTitleTaken(l.Owner, l.Title, primitive.NilObjectID) {
+		return nil, custom_error.ErrDuplicateList
+	}
+
+	if l.ID.IsZero() {
+		l.ID = primitive.NewObjectID()
+	}
+
+	m.lists[l.ID] = *l
+
+	return l, nil
+}
+
+func (m *Memory) GetLists(ctx context.Context, owner primitive.ObjectID) ([]entity.List, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var lists []entity.List
+	for _, list := range m.lists {
+		if list.Owner == owner {
+			lists = append(lists, list)
+		}
+	}
+
+	sort.Slice(lists, func(i, j int) bool {
+		return bytes.Compare(lists[i].ID[:], lists[j].ID[:]) < 0
+	})
+
+	return lists, nil
+}
+
+func (m *Memory) GetListByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.List, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	list, ok := m.lists[id]
+	if !ok || list.Owner != owner {
+		return nil, custom_error.ErrListNotFound
+	}
+
+	return &list, nil
+}
+
+func (m *Memory) UpdateList(ctx context.Context, l *entity.List) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list, ok := m.lists[l.ID]
+	if !ok || list.Owner != l.Owner {
+		return custom_error.ErrListNotFound
+	}
+
+	if m.listTitleTaken(l.Owner, l.Title, l.ID) {
+		return custom_error.ErrDuplicateList
+	}
+
+	list.Title = l.Title
+	m.lists[l.ID] = list
+
+	return nil
+}
+
+func (m *Memory) DeleteList(ctx context.Context, owner, id primitive.ObjectID) ([]entity.Attachment, []entity.TaskRevision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list, ok := m.lists[id]
+	if !ok || list.Owner != owner {
+		return nil, nil, custom_error.ErrListNotFound
+	}
+
+	var trashed []primitive.ObjectID
+	for taskID, task := range m.tasks {
+		if task.ListID == nil || *task.ListID != id {
+			continue
+		}
+		if task.DeletedAt == nil {
+			return nil, nil, custom_error.ErrListNotEmpty
+		}
+		trashed = append(trashed, taskID)
+	}
+
+	for _, taskID := range trashed {
+		delete(m.tasks, taskID)
+	}
+	delete(m.lists, id)
+
+	m.removePurgedComments(trashed)
+
+	// Под блокировкой задача не может попасть в список после проверки, переносить нечего
+	return m.removePurgedAttachments(trashed), nil, nil
+}
+
+func (m *Memory) MoveTask(ctx context.Context, owner, id primitive.ObjectID, listID *primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.versionedTask(owner, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.titleTaken(owner, listID, task.Title, task.Occurrence, id) {
+		return nil, custom_error.ErrDuplicateTask
+	}
+
+	task.ListID = listID
+	task.Version++
+	m.tasks[id] = copyTask(task)
+
+	return &task, nil
+}
+
+// listTitleTaken проверяет, есть ли у owner другой список с названием title. Вызывается под m.mu
+func (m *Memory) listTitleTaken(owner primitive.ObjectID, title string, except primitive.ObjectID) bool {
+	for id, list := range m.lists {
+		if id != except && list.Owner == owner && list.Title == title {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package memrepo
+
+import (
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sync"
+)
+
+// Memory - потокобезопасное хранилище в памяти с той же семантикой ошибок,
+// что и mongorepo. Данные теряются при остановке процесса
+type Memory struct {
+	mu    sync.RWMutex
+	tasks map[primitive.ObjectID]entity.Tasks
+	lists map[primitive.ObjectID]entity.List
+	users map[primitive.ObjectID]entity.User
+
+	comments    map[primitive.ObjectID]entity.Comment
+	attachments map[primitive.ObjectID]entity.Attachment
+	// history - записи истории по ID задачи от старых к новым
+	history map[primitive.ObjectID][]entity.HistoryEntry
+
+	workspaces map[primitive.ObjectID]entity.Workspace
+	// members - участники пространств по ID пространства и ID пользователя
+	members     map[primitive.ObjectID]map[primitive.ObjectID]entity.Member
+	invitations map[string]entity.Invitation
+}
+
+func New() *Memory {
+	return &Memory{
+		tasks: make(map[primitive.ObjectID]entity.Tasks),
+		lists: make(map[primitive.ObjectID]entity.List),
+		users: make(map[primitive.ObjectID]entity.User),
+
+		comments:    make(map[primitive.ObjectID]entity.Comment),
+		attachments: make(map[primitive.ObjectID]entity.Attachment),
+		history:     make(map[primitive.ObjectID][]entity.HistoryEntry),
+
+		workspaces:  make(map[primitive.ObjectID]entity.Workspace),
+		members:     make(map[primitive.ObjectID]map[primitive.ObjectID]entity.Member),
+		invitations: make(map[string]entity.Invitation),
+	}
+}
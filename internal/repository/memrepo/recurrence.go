@@ -0,0 +1,69 @@
+package memrepo
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (m *Memory) SetRecurrence(ctx context.Context, owner, id primitive.ObjectID, rule string, version int64) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.versionedTask(owner, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Recurrence = rule
+	task.Version++
+	m.tasks[id] = copyTask(task)
+
+	return &task, nil
+}
+
+func (m *Memory) CompleteOccurrence(ctx context.Context, t *entity.Tasks, version int64, next *entity.Tasks) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.versionedTask(t.Owner, t.ID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	series := t.Series()
+	occurrence := task.ActiveAt
+
+	if m.titleTaken(t.Owner, task.ListID, task.Title, &occurrence, t.ID) {
+		return nil, custom_error.ErrDuplicateTask
+	}
+
+	task.Status = entity.StatusDone
+	task.Recurrence = ""
+	task.SeriesID = &series
+	task.Occurrence = &occurrence
+	task.Version++
+
+	if next != nil {
+		// Задача уже отмечена повторением, поэтому ее название не мешает следующему
+		previous := m.tasks[t.ID]
+		m.tasks[t.ID] = copyTask(task)
+
+		if m.titleTaken(next.Owner, next.ListID, next.Title, next.Occurrence, primitive.NilObjectID) {
+			m.tasks[t.ID] = previous
+			return nil, custom_error.ErrDuplicateTask
+		}
+
+		if next.ID.IsZero() {
+			next.ID = primitive.NewObjectID()
+		}
+		m.tasks[next.ID] = copyTask(*next)
+
+		return &task, nil
+	}
+
+	m.tasks[t.ID] = copyTask(task)
+
+	return &task, nil
+}
@@ -0,0 +1,69 @@
+package memrepo
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+)
+
+func (m *Memory) GetTags(ctx context.Context, owner primitive.ObjectID) ([]entity.TagStat, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	counts := make(map[string]int64)
+	for _, task := range m.tasks {
+		if task.Owner != owner || task.DeletedAt != nil {
+			continue
+		}
+		for _, tag := range task.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]entity.TagStat, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, entity.TagStat{Tag: tag, Count: count})
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+
+	return tags, nil
+}
+
+func (m *Memory) RenameTag(ctx context.Context, owner primitive.ObjectID, from, to string) ([]entity.TaskRevision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var renamed []entity.TaskRevision
+	for id, task := range m.tasks {
+		if task.Owner != owner || !containsString(task.Tags, from) {
+			continue
+		}
+
+		before := copyTask(task)
+		if from == to {
+			// Метка есть, переименовывать нечего
+			return nil, nil
+		}
+
+		task = copyTask(task)
+		task.Tags = entity.RenameTag(task.Tags, from, to)
+		task.Version++
+		m.tasks[id] = copyTask(task)
+
+		renamed = append(renamed, entity.TaskRevision{Before: before, After: task})
+	}
+
+	if len(renamed) == 0 {
+		return nil, custom_error.ErrTagNotFound
+	}
+
+	return renamed, nil
+}
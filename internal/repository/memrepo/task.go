@@ -0,0 +1,437 @@
+package memrepo
+
+import (
+	"bytes"
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+	"strings"
+	"time"
+)
+
+func (m *Memory) CreateTask(ctx context.Context, t *entity.Tasks) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.titleTaken(t.Owner, t.ListID, t.Title, t.Occurrence, primitive.NilObjectID) {
+		return nil, custom_error.ErrDuplicateTask
+	}
+
+	if t.ID.IsZero() {
+		t.ID = primitive.NewObjectID()
+	}
+
+	if _, ok := m.tasks[t.ID]; ok {
+		return nil, custom_error.ErrDuplicateTask
+	}
+
+	m.tasks[t.ID] = copyTask(*t)
+
+	return t, nil
+}
+
+func (m *Memory) UpdateTask(ctx context.Context, t *entity.Tasks, id primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.versionedTask(t.Owner, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.titleTaken(t.Owner, task.ListID, t.Title, task.Occurrence, id) {
+		return nil, custom_error.ErrDuplicateTask
+	}
+
+	task.Title = t.Title
+	task.Description = t.Description
+	task.ActiveAt = t.ActiveAt
+	task.Status = t.Status
+	task.Priority = t.Priority
+	task.Due = t.Due
+	task.Tags = t.Tags
+	task.AutoComplete = t.AutoComplete
+	task.Weekend = t.Weekend
+	task.Version++
+	m.tasks[id] = copyTask(task)
+
+	return &task, nil
+}
+
+func (m *Memory) PatchTask(ctx context.Context, owner, id primitive.ObjectID, patch *entity.TaskPatch, version int64) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.versionedTask(owner, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.IsEmpty() {
+		return &task, nil
+	}
+
+	if patch.Title != nil && m.titleTaken(owner, task.ListID, *patch.Title, task.Occurrence, id) {
+		return nil, custom_error.ErrDuplicateTask
+	}
+
+	patch.Apply(&task)
+	task.Version++
+	m.tasks[id] = copyTask(task)
+
+	return &task, nil
+}
+
+func (m *Memory) UpdateTaskStatus(ctx context.Context, owner, id primitive.ObjectID, status string, version int64) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.versionedTask(owner, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Status = status
+	task.Version++
+	m.tasks[id] = copyTask(task)
+
+	return &task, nil
+}
+
+func (m *Memory) GetAllTasks(ctx context.Context, f *entity.TaskFilter) ([]entity.Tasks, int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var tasks []entity.Tasks
+	for _, task := range m.tasks {
+		if matchesFilter(task, f) {
+			tasks = append(tasks, copyTask(task))
+		}
+	}
+
+	total := int64(len(tasks))
+
+	if len(f.Search) > 0 {
+		return searchPage(tasks, f), total, nil
+	}
+
+	less := func(a, b entity.Tasks) bool {
+		c := compareBy(f.SortField, a, b)
+		if c == 0 {
+			c = bytes.Compare(a.ID[:], b.ID[:])
+		}
+		if f.SortDesc {
+			return c > 0
+		}
+		return c < 0
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return less(tasks[i], tasks[j])
+	})
+
+	if !f.After.IsZero() {
+		last, ok := m.ownTask(f.Owner, f.After)
+		if !ok {
+			return nil, 0, custom_error.ErrInvalidCursor
+		}
+
+		start := sort.Search(len(tasks), func(i int) bool {
+			return less(last, tasks[i])
+		})
+		tasks = tasks[start:]
+	}
+
+	if f.Limit > 0 && int64(len(tasks)) > f.Limit {
+		tasks = tasks[:f.Limit]
+	}
+
+	return tasks, total, nil
+}
+
+func (m *Memory) GetTaskByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	task, ok := m.liveTask(owner, id)
+	if !ok {
+		return nil, custom_error.ErrTaskNotFound
+	}
+
+	return &task, nil
+}
+
+func (m *Memory) DeleteTask(ctx context.Context, owner, id primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.versionedTask(owner, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedAt := time.Now().UTC()
+	task.DeletedAt = &deletedAt
+	task.Version++
+	m.tasks[id] = copyTask(task)
+
+	return &task, nil
+}
+
+func (m *Memory) RestoreTask(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, ok := m.ownTask(owner, id)
+	if !ok || task.DeletedAt == nil {
+		return nil, custom_error.ErrTaskNotFound
+	}
+
+	if m.titleTaken(owner, task.ListID, task.Title, task.Occurrence, id) {
+		return nil, custom_error.ErrDuplicateTask
+	}
+
+	task.DeletedAt = nil
+	task.Version++
+	m.tasks[id] = copyTask(task)
+
+	return &task, nil
+}
+
+func (m *Memory) PurgeDeletedTasks(ctx context.Context, before time.Time) (int64, []entity.Attachment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var purged []primitive.ObjectID
+	for id, task := range m.tasks {
+		if task.DeletedAt != nil && task.DeletedAt.Before(before) {
+			delete(m.tasks, id)
+			purged = append(purged, id)
+		}
+	}
+
+	m.removePurgedComments(purged)
+
+	return int64(len(purged)), m.removePurgedAttachments(purged), nil
+}
+
+// ownTask возвращает задачу id, если она принадлежит owner. Вызывается под m.mu
+func (m *Memory) ownTask(owner, id primitive.ObjectID) (entity.Tasks, bool) {
+	task, ok := m.tasks[id]
+	if !ok || task.Owner != owner {
+		return entity.Tasks{}, false
+	}
+	return copyTask(task), true
+}
+
+// copyTask копирует срезы и указатели задачи: задачи хранятся по значению, и без этого
+// вызывающий код менял бы чек-лист, метки и зависимости сохраненной задачи в обход m.mu
+func copyTask(t entity.Tasks) entity.Tasks {
+	t.Tags = append([]string(nil), t.Tags...)
+	t.Checklist = append([]entity.ChecklistItem(nil), t.Checklist...)
+	t.Dependencies = append([]primitive.ObjectID(nil), t.Dependencies...)
+	if t.ListID != nil {
+		listID := *t.ListID
+		t.ListID = &listID
+	}
+	if t.Due != nil {
+		due := *t.Due
+		t.Due = &due
+	}
+	if t.Progress != nil {
+		progress := *t.Progress
+		t.Progress = &progress
+	}
+	if t.SeriesID != nil {
+		seriesID := *t.SeriesID
+		t.SeriesID = &seriesID
+	}
+	if t.Occurrence != nil {
+		occurrence := *t.Occurrence
+		t.Occurrence = &occurrence
+	}
+	if t.Workspace != nil {
+		workspace := *t.Workspace
+		t.Workspace = &workspace
+	}
+	if t.DeletedAt != nil {
+		deletedAt := *t.DeletedAt
+		t.DeletedAt = &deletedAt
+	}
+	return t
+}
+
+// liveTask возвращает задачу id владельца owner, если она не в корзине. Вызывается под m.mu
+func (m *Memory) liveTask(owner, id primitive.ObjectID) (entity.Tasks, bool) {
+	task, ok := m.ownTask(owner, id)
+	if !ok || task.DeletedAt != nil {
+		return entity.Tasks{}, false
+	}
+	return task, true
+}
+
+// versionedTask возвращает задачу id владельца owner не из корзины, если ее версия
+// равна version (0 - любая версия). Вызывается под m.mu
+func (m *Memory) versionedTask(owner, id primitive.ObjectID, version int64) (entity.Tasks, error) {
+	task, ok := m.liveTask(owner, id)
+	if !ok {
+		return entity.Tasks{}, custom_error.ErrTaskNotFound
+	}
+	if version > 0 && task.Version != version {
+		return entity.Tasks{}, custom_error.ErrVersionMismatch
+	}
+	return task, nil
+}
+
+// titleTaken проверяет, есть ли у owner в списке list другая задача не из корзины
+// с таким названием и датой завершенного повторения occurrence. Вызывается под m.mu
+func (m *Memory) titleTaken(owner primitive.ObjectID, list *primitive.ObjectID, title string, occurrence *entity.Date, except primitive.ObjectID) bool {
+	for id, task := range m.tasks {
+		if id != except && task.Owner == owner && task.Title == title && task.DeletedAt == nil &&
+			sameList(task.ListID, list) && sameOccurrence(task.Occurrence, occurrence) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameList(a, b *primitive.ObjectID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameOccurrence(a, b *entity.Date) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(b.Time)
+}
+
+func matchesFilter(task entity.Tasks, f *entity.TaskFilter) bool {
+	if task.Owner != f.Owner || (task.DeletedAt != nil) != f.Deleted {
+		return false
+	}
+	if !f.ListID.IsZero() && (task.ListID == nil || *task.ListID != f.ListID) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !containsString(f.Statuses, task.Status) {
+		return false
+	}
+	if len(f.Priorities) > 0 && !containsPriority(f.Priorities, task.Priority) {
+		return false
+	}
+	if len(f.Tags) > 0 && !matchesTags(task.Tags, f.Tags, f.TagsAll) {
+		return false
+	}
+	if !f.ActiveFrom.IsZero() && task.ActiveAt.Before(f.ActiveFrom.Time) {
+		return false
+	}
+	if !f.ActiveTo.IsZero() && task.ActiveAt.After(f.ActiveTo.Time) {
+		return false
+	}
+	if len(f.Search) > 0 && searchScore(task, f.Search) == 0 {
+		return false
+	}
+	return true
+}
+
+// searchPage упорядочивает найденные задачи по релевантности и вырезает страницу
+func searchPage(tasks []entity.Tasks, f *entity.TaskFilter) []entity.Tasks {
+	scores := make(map[primitive.ObjectID]int, len(tasks))
+	for _, task := range tasks {
+		scores[task.ID] = searchScore(task, f.Search)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if scores[a.ID] != scores[b.ID] {
+			return scores[a.ID] > scores[b.ID]
+		}
+		return bytes.Compare(a.ID[:], b.ID[:]) < 0
+	})
+
+	if f.Offset >= int64(len(tasks)) {
+		return nil
+	}
+	tasks = tasks[f.Offset:]
+
+	if f.Limit > 0 && int64(len(tasks)) > f.Limit {
+		tasks = tasks[:f.Limit]
+	}
+
+	return tasks
+}
+
+// searchScore - релевантность задачи: каждое слово поиска, совпавшее со словом
+// названия или описания целиком, дает 2, совпавшее с началом слова - 1.
+// 0 - не найдено хотя бы одно слово
+func searchScore(task entity.Tasks, search []string) int {
+	words := entity.SearchTerms(task.Title + " " + task.Description)
+
+	score := 0
+	for _, term := range search {
+		best := 0
+		for _, word := range words {
+			if word == term {
+				best = 2
+				break
+			}
+			if strings.HasPrefix(word, term) {
+				best = 1
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		score += best
+	}
+
+	return score
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesTags проверяет, есть ли у задачи все метки filter (all) или хотя бы одна из них
+func matchesTags(tags, filter []string, all bool) bool {
+	for _, tag := range filter {
+		found := containsString(tags, tag)
+		if found != all {
+			return found
+		}
+	}
+	return all
+}
+
+func containsPriority(values []entity.Priority, value entity.Priority) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// compareBy сравнивает задачи по полю сортировки API так же, как MongoDB
+func compareBy(field string, a, b entity.Tasks) int {
+	switch field {
+	case "activeAt":
+		return a.ActiveAt.Compare(b.ActiveAt.Time)
+	case "title":
+		return strings.Compare(a.Title, b.Title)
+	case "priority":
+		return int(a.Priority) - int(b.Priority)
+	default:
+		return bytes.Compare(a.ID[:], b.ID[:])
+	}
+}
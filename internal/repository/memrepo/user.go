@@ -0,0 +1,52 @@
+package memrepo
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (m *Memory) CreateUser(ctx context.Context, u *entity.User) (*entity.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, user := range m.users {
+		if user.Username == u.Username {
+			return nil, custom_error.ErrDuplicateUser
+		}
+	}
+
+	if u.ID.IsZero() {
+		u.ID = primitive.NewObjectID()
+	}
+
+	m.users[u.ID] = *u
+
+	return u, nil
+}
+
+func (m *Memory) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, user := range m.users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+
+	return nil, custom_error.ErrUserNotFound
+}
+
+func (m *Memory) GetUserByID(ctx context.Context, id primitive.ObjectID) (*entity.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return nil, custom_error.ErrUserNotFound
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,224 @@
+package memrepo
+
+import (
+	"bytes"
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+	"strings"
+)
+
+func (m *Memory) CreateWorkspace(ctx context.Context, w *entity.Workspace, owner *entity.Member) (*entity.Workspace, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if w.ID.IsZero() {
+		w.ID = primitive.NewObjectID()
+	}
+
+	m.workspaces[w.ID] = *w
+
+	owner.Workspace = w.ID
+	m.members[w.ID] = map[primitive.ObjectID]entity.Member{owner.UserID: *owner}
+
+	return w, nil
+}
+
+func (m *Memory) GetWorkspaces(ctx context.Context, userID primitive.ObjectID) ([]entity.Workspace, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var workspaces []entity.Workspace
+	for id, members := range m.members {
+		member, ok := members[userID]
+		if !ok {
+			continue
+		}
+
+		workspace := m.workspaces[id]
+		workspace.Role = member.Role
+		workspaces = append(workspaces, workspace)
+	}
+
+	sort.Slice(workspaces, func(i, j int) bool {
+		return bytes.Compare(workspaces[i].ID[:], workspaces[j].ID[:]) < 0
+	})
+
+	return workspaces, nil
+}
+
+func (m *Memory) GetWorkspaceByID(ctx context.Context, id primitive.ObjectID) (*entity.Workspace, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	workspace, ok := m.workspaces[id]
+	if !ok {
+		return nil, custom_error.ErrWorkspaceNotFound
+	}
+
+	return &workspace, nil
+}
+
+func (m *Memory) UpdateWorkspace(ctx context.Context, w *entity.Workspace) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	workspace, ok := m.workspaces[w.ID]
+	if !ok {
+		return custom_error.ErrWorkspaceNotFound
+	}
+
+	workspace.Title = w.Title
+	m.workspaces[w.ID] = workspace
+
+	return nil
+}
+
+func (m *Memory) DeleteWorkspace(ctx context.Context, id primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.workspaces[id]; !ok {
+		return custom_error.ErrWorkspaceNotFound
+	}
+
+	for taskID, task := range m.tasks {
+		if task.Owner == id {
+			delete(m.tasks, taskID)
+		}
+	}
+	for taskID, history := range m.history {
+		if len(history) > 0 && history[0].Owner == id {
+			delete(m.history, taskID)
+		}
+	}
+	for commentID, comment := range m.comments {
+		if comment.Owner == id {
+			delete(m.comments, commentID)
+		}
+	}
+	for listID, list := range m.lists {
+		if list.Owner == id {
+			delete(m.lists, listID)
+		}
+	}
+	for hash, invitation := range m.invitations {
+		if invitation.Workspace == id {
+			delete(m.invitations, hash)
+		}
+	}
+	delete(m.members, id)
+	delete(m.workspaces, id)
+
+	return nil
+}
+
+func (m *Memory) GetMember(ctx context.Context, workspace, userID primitive.ObjectID) (*entity.Member, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	member, ok := m.members[workspace][userID]
+	if !ok {
+		return nil, custom_error.ErrMemberNotFound
+	}
+
+	return &member, nil
+}
+
+func (m *Memory) GetMembers(ctx context.Context, workspace primitive.ObjectID) ([]entity.Member, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var members []entity.Member
+	for _, member := range m.members[workspace] {
+		members = append(members, member)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return strings.Compare(members[i].Username, members[j].Username) < 0
+	})
+
+	return members, nil
+}
+
+func (m *Memory) AddMember(ctx context.Context, member *entity.Member) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	members, ok := m.members[member.Workspace]
+	if !ok {
+		return custom_error.ErrWorkspaceNotFound
+	}
+
+	if _, ok := members[member.UserID]; ok {
+		return custom_error.ErrAlreadyMember
+	}
+
+	members[member.UserID] = *member
+
+	return nil
+}
+
+func (m *Memory) UpdateMemberRole(ctx context.Context, workspace, userID primitive.ObjectID, role entity.Role) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	member, ok := m.members[workspace][userID]
+	if !ok {
+		return custom_error.ErrMemberNotFound
+	}
+
+	member.Role = role
+	m.members[workspace][userID] = member
+
+	return nil
+}
+
+func (m *Memory) RemoveMember(ctx context.Context, workspace, userID primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.members[workspace][userID]; !ok {
+		return custom_error.ErrMemberNotFound
+	}
+
+	delete(m.members[workspace], userID)
+
+	return nil
+}
+
+func (m *Memory) CreateInvitation(ctx context.Context, i *entity.Invitation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.invitations[i.TokenHash] = *i
+
+	return nil
+}
+
+func (m *Memory) GetInvitation(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	invitation, ok := m.invitations[tokenHash]
+	if !ok {
+		return nil, custom_error.ErrInvitationNotFound
+	}
+
+	return &invitation, nil
+}
+
+func (m *Memory) DeleteInvitation(ctx context.Context, tokenHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.invitations[tokenHash]; !ok {
+		return custom_error.ErrInvitationNotFound
+	}
+
+	delete(m.invitations, tokenHash)
+
+	return nil
+}
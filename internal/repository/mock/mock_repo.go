@@ -7,6 +7,7 @@ package mock_repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	entity "github.com/khussa1n/todo-list/internal/entity"
@@ -36,6 +37,36 @@ func (m *MockTodoList) EXPECT() *MockTodoListMockRecorder {
 	return m.recorder
 }
 
+// AddDependency mocks base method.
+func (m *MockTodoList) AddDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", ctx, owner, id, blocker, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockTodoListMockRecorder) AddDependency(ctx, owner, id, blocker, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockTodoList)(nil).AddDependency), ctx, owner, id, blocker, version)
+}
+
+// CompleteOccurrence mocks base method.
+func (m *MockTodoList) CompleteOccurrence(ctx context.Context, task *entity.Tasks, version int64, next *entity.Tasks) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOccurrence", ctx, task, version, next)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteOccurrence indicates an expected call of CompleteOccurrence.
+func (mr *MockTodoListMockRecorder) CompleteOccurrence(ctx, task, version, next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOccurrence", reflect.TypeOf((*MockTodoList)(nil).CompleteOccurrence), ctx, task, version, next)
+}
+
 // CreateTask mocks base method.
 func (m *MockTodoList) CreateTask(ctx context.Context, e *entity.Tasks) (*entity.Tasks, error) {
 	m.ctrl.T.Helper()
@@ -52,183 +83,1657 @@ func (mr *MockTodoListMockRecorder) CreateTask(ctx, e interface{}) *gomock.Call
 }
 
 // DeleteTask mocks base method.
-func (m *MockTodoList) DeleteTask(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockTodoList) DeleteTask(ctx context.Context, owner, id primitive.ObjectID, version int64) (*entity.Tasks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, owner, id, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTodoListMockRecorder) DeleteTask(ctx, id interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) DeleteTask(ctx, owner, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTodoList)(nil).DeleteTask), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTodoList)(nil).DeleteTask), ctx, owner, id, version)
 }
 
 // GetAllTasks mocks base method.
-func (m *MockTodoList) GetAllTasks(ctx context.Context, status string) ([]entity.Tasks, error) {
+func (m *MockTodoList) GetAllTasks(ctx context.Context, filter *entity.TaskFilter) ([]entity.Tasks, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTasks", ctx, status)
+	ret := m.ctrl.Call(m, "GetAllTasks", ctx, filter)
 	ret0, _ := ret[0].([]entity.Tasks)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllTasks indicates an expected call of GetAllTasks.
+func (mr *MockTodoListMockRecorder) GetAllTasks(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTodoList)(nil).GetAllTasks), ctx, filter)
+}
+
+// GetTags mocks base method.
+func (m *MockTodoList) GetTags(ctx context.Context, owner primitive.ObjectID) ([]entity.TagStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx, owner)
+	ret0, _ := ret[0].([]entity.TagStat)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllTasks indicates an expected call of GetAllTasks.
-func (mr *MockTodoListMockRecorder) GetAllTasks(ctx, status interface{}) *gomock.Call {
+// GetTags indicates an expected call of GetTags.
+func (mr *MockTodoListMockRecorder) GetTags(ctx, owner interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTodoList)(nil).GetAllTasks), ctx, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockTodoList)(nil).GetTags), ctx, owner)
 }
 
 // GetTaskByID mocks base method.
-func (m *MockTodoList) GetTaskByID(ctx context.Context, id primitive.ObjectID) (*entity.Tasks, error) {
+func (m *MockTodoList) GetTaskByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetTaskByID", ctx, owner, id)
 	ret0, _ := ret[0].(*entity.Tasks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTaskByID indicates an expected call of GetTaskByID.
-func (mr *MockTodoListMockRecorder) GetTaskByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) GetTaskByID(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTodoList)(nil).GetTaskByID), ctx, owner, id)
+}
+
+// GetTasksByIDs mocks base method.
+func (m *MockTodoList) GetTasksByIDs(ctx context.Context, owner primitive.ObjectID, ids []primitive.ObjectID) ([]entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByIDs", ctx, owner, ids)
+	ret0, _ := ret[0].([]entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByIDs indicates an expected call of GetTasksByIDs.
+func (mr *MockTodoListMockRecorder) GetTasksByIDs(ctx, owner, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByIDs", reflect.TypeOf((*MockTodoList)(nil).GetTasksByIDs), ctx, owner, ids)
+}
+
+// MoveTask mocks base method.
+func (m *MockTodoList) MoveTask(ctx context.Context, owner, id primitive.ObjectID, listID *primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", ctx, owner, id, listID, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockTodoListMockRecorder) MoveTask(ctx, owner, id, listID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockTodoList)(nil).MoveTask), ctx, owner, id, listID, version)
+}
+
+// PatchTask mocks base method.
+func (m *MockTodoList) PatchTask(ctx context.Context, owner, id primitive.ObjectID, patch *entity.TaskPatch, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTask", ctx, owner, id, patch, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchTask indicates an expected call of PatchTask.
+func (mr *MockTodoListMockRecorder) PatchTask(ctx, owner, id, patch, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTask", reflect.TypeOf((*MockTodoList)(nil).PatchTask), ctx, owner, id, patch, version)
+}
+
+// PurgeDeletedTasks mocks base method.
+func (m *MockTodoList) PurgeDeletedTasks(ctx context.Context, before time.Time) (int64, []entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedTasks", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]entity.Attachment)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PurgeDeletedTasks indicates an expected call of PurgeDeletedTasks.
+func (mr *MockTodoListMockRecorder) PurgeDeletedTasks(ctx, before interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTodoList)(nil).GetTaskByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedTasks", reflect.TypeOf((*MockTodoList)(nil).PurgeDeletedTasks), ctx, before)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockTodoList) RemoveDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", ctx, owner, id, blocker, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockTodoListMockRecorder) RemoveDependency(ctx, owner, id, blocker, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockTodoList)(nil).RemoveDependency), ctx, owner, id, blocker, version)
+}
+
+// RenameTag mocks base method.
+func (m *MockTodoList) RenameTag(ctx context.Context, owner primitive.ObjectID, from, to string) ([]entity.TaskRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, owner, from, to)
+	ret0, _ := ret[0].([]entity.TaskRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockTodoListMockRecorder) RenameTag(ctx, owner, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockTodoList)(nil).RenameTag), ctx, owner, from, to)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTodoList) RestoreTask(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, owner, id)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTodoListMockRecorder) RestoreTask(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTodoList)(nil).RestoreTask), ctx, owner, id)
+}
+
+// SetRecurrence mocks base method.
+func (m *MockTodoList) SetRecurrence(ctx context.Context, owner, id primitive.ObjectID, rule string, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecurrence", ctx, owner, id, rule, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRecurrence indicates an expected call of SetRecurrence.
+func (mr *MockTodoListMockRecorder) SetRecurrence(ctx, owner, id, rule, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecurrence", reflect.TypeOf((*MockTodoList)(nil).SetRecurrence), ctx, owner, id, rule, version)
+}
+
+// UpdateChecklist mocks base method.
+func (m *MockTodoList) UpdateChecklist(ctx context.Context, owner, id primitive.ObjectID, checklist []entity.ChecklistItem, status string, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklist", ctx, owner, id, checklist, status, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChecklist indicates an expected call of UpdateChecklist.
+func (mr *MockTodoListMockRecorder) UpdateChecklist(ctx, owner, id, checklist, status, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklist", reflect.TypeOf((*MockTodoList)(nil).UpdateChecklist), ctx, owner, id, checklist, status, version)
 }
 
 // UpdateTask mocks base method.
-func (m *MockTodoList) UpdateTask(ctx context.Context, e *entity.Tasks, id primitive.ObjectID) error {
+func (m *MockTodoList) UpdateTask(ctx context.Context, e *entity.Tasks, id primitive.ObjectID, version int64) (*entity.Tasks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", ctx, e, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, e, id, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTodoListMockRecorder) UpdateTask(ctx, e, id interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) UpdateTask(ctx, e, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTodoList)(nil).UpdateTask), ctx, e, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTodoList)(nil).UpdateTask), ctx, e, id, version)
 }
 
 // UpdateTaskStatus mocks base method.
-func (m *MockTodoList) UpdateTaskStatus(ctx context.Context, id primitive.ObjectID, status string) error {
+func (m *MockTodoList) UpdateTaskStatus(ctx context.Context, owner, id primitive.ObjectID, status string, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, owner, id, status, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskStatus indicates an expected call of UpdateTaskStatus.
+func (mr *MockTodoListMockRecorder) UpdateTaskStatus(ctx, owner, id, status, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockTodoList)(nil).UpdateTaskStatus), ctx, owner, id, status, version)
+}
+
+// MockList is a mock of List interface.
+type MockList struct {
+	ctrl     *gomock.Controller
+	recorder *MockListMockRecorder
+}
+
+// MockListMockRecorder is the mock recorder for MockList.
+type MockListMockRecorder struct {
+	mock *MockList
+}
+
+// NewMockList creates a new mock instance.
+func NewMockList(ctrl *gomock.Controller) *MockList {
+	mock := &MockList{ctrl: ctrl}
+	mock.recorder = &MockListMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockList) EXPECT() *MockListMockRecorder {
+	return m.recorder
+}
+
+// CreateList mocks base method.
+func (m *MockList) CreateList(ctx context.Context, l *entity.List) (*entity.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateList", ctx, l)
+	ret0, _ := ret[0].(*entity.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateList indicates an expected call of CreateList.
+func (mr *MockListMockRecorder) CreateList(ctx, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateList", reflect.TypeOf((*MockList)(nil).CreateList), ctx, l)
+}
+
+// DeleteList mocks base method.
+func (m *MockList) DeleteList(ctx context.Context, owner, id primitive.ObjectID) ([]entity.Attachment, []entity.TaskRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteList", ctx, owner, id)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].([]entity.TaskRevision)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockListMockRecorder) DeleteList(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockList)(nil).DeleteList), ctx, owner, id)
+}
+
+// GetListByID mocks base method.
+func (m *MockList) GetListByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListByID", ctx, owner, id)
+	ret0, _ := ret[0].(*entity.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListByID indicates an expected call of GetListByID.
+func (mr *MockListMockRecorder) GetListByID(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListByID", reflect.TypeOf((*MockList)(nil).GetListByID), ctx, owner, id)
+}
+
+// GetLists mocks base method.
+func (m *MockList) GetLists(ctx context.Context, owner primitive.ObjectID) ([]entity.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLists", ctx, owner)
+	ret0, _ := ret[0].([]entity.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLists indicates an expected call of GetLists.
+func (mr *MockListMockRecorder) GetLists(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockList)(nil).GetLists), ctx, owner)
+}
+
+// UpdateList mocks base method.
+func (m *MockList) UpdateList(ctx context.Context, l *entity.List) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, id, status)
+	ret := m.ctrl.Call(m, "UpdateList", ctx, l)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateTaskStatus indicates an expected call of UpdateTaskStatus.
-func (mr *MockTodoListMockRecorder) UpdateTaskStatus(ctx, id, status interface{}) *gomock.Call {
+// UpdateList indicates an expected call of UpdateList.
+func (mr *MockListMockRecorder) UpdateList(ctx, l interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockTodoList)(nil).UpdateTaskStatus), ctx, id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateList", reflect.TypeOf((*MockList)(nil).UpdateList), ctx, l)
 }
 
-// MockRepository is a mock of Repository interface.
-type MockRepository struct {
+// MockComment is a mock of Comment interface.
+type MockComment struct {
 	ctrl     *gomock.Controller
-	recorder *MockRepositoryMockRecorder
+	recorder *MockCommentMockRecorder
 }
 
-// MockRepositoryMockRecorder is the mock recorder for MockRepository.
-type MockRepositoryMockRecorder struct {
-	mock *MockRepository
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
 }
 
-// NewMockRepository creates a new mock instance.
-func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
-	mock := &MockRepository{ctrl: ctrl}
-	mock.recorder = &MockRepositoryMockRecorder{mock}
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
 	return m.recorder
 }
 
-// CreateTask mocks base method.
-func (m *MockRepository) CreateTask(ctx context.Context, e *entity.Tasks) (*entity.Tasks, error) {
+// CreateComment mocks base method.
+func (m *MockComment) CreateComment(ctx context.Context, c *entity.Comment) (*entity.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", ctx, e)
-	ret0, _ := ret[0].(*entity.Tasks)
+	ret := m.ctrl.Call(m, "CreateComment", ctx, c)
+	ret0, _ := ret[0].(*entity.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTask indicates an expected call of CreateTask.
-func (mr *MockRepositoryMockRecorder) CreateTask(ctx, e interface{}) *gomock.Call {
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentMockRecorder) CreateComment(ctx, c interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockRepository)(nil).CreateTask), ctx, e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockComment)(nil).CreateComment), ctx, c)
 }
 
-// DeleteTask mocks base method.
-func (m *MockRepository) DeleteTask(ctx context.Context, id primitive.ObjectID) error {
+// DeleteComment mocks base method.
+func (m *MockComment) DeleteComment(ctx context.Context, owner, taskID, id primitive.ObjectID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, owner, taskID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockRepositoryMockRecorder) DeleteTask(ctx, id interface{}) *gomock.Call {
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentMockRecorder) DeleteComment(ctx, owner, taskID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockRepository)(nil).DeleteTask), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockComment)(nil).DeleteComment), ctx, owner, taskID, id)
 }
 
-// GetAllTasks mocks base method.
-func (m *MockRepository) GetAllTasks(ctx context.Context, status string) ([]entity.Tasks, error) {
+// GetCommentByID mocks base method.
+func (m *MockComment) GetCommentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTasks", ctx, status)
-	ret0, _ := ret[0].([]entity.Tasks)
+	ret := m.ctrl.Call(m, "GetCommentByID", ctx, owner, taskID, id)
+	ret0, _ := ret[0].(*entity.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllTasks indicates an expected call of GetAllTasks.
-func (mr *MockRepositoryMockRecorder) GetAllTasks(ctx, status interface{}) *gomock.Call {
+// GetCommentByID indicates an expected call of GetCommentByID.
+func (mr *MockCommentMockRecorder) GetCommentByID(ctx, owner, taskID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockRepository)(nil).GetAllTasks), ctx, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentByID", reflect.TypeOf((*MockComment)(nil).GetCommentByID), ctx, owner, taskID, id)
 }
 
-// GetTaskByID mocks base method.
-func (m *MockRepository) GetTaskByID(ctx context.Context, id primitive.ObjectID) (*entity.Tasks, error) {
+// GetComments mocks base method.
+func (m *MockComment) GetComments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskByID", ctx, id)
-	ret0, _ := ret[0].(*entity.Tasks)
+	ret := m.ctrl.Call(m, "GetComments", ctx, owner, taskID)
+	ret0, _ := ret[0].([]entity.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTaskByID indicates an expected call of GetTaskByID.
-func (mr *MockRepositoryMockRecorder) GetTaskByID(ctx, id interface{}) *gomock.Call {
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentMockRecorder) GetComments(ctx, owner, taskID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockRepository)(nil).GetTaskByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockComment)(nil).GetComments), ctx, owner, taskID)
 }
 
-// UpdateTask mocks base method.
-func (m *MockRepository) UpdateTask(ctx context.Context, e *entity.Tasks, id primitive.ObjectID) error {
+// UpdateComment mocks base method.
+func (m *MockComment) UpdateComment(ctx context.Context, c *entity.Comment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", ctx, e, id)
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, c)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockRepositoryMockRecorder) UpdateTask(ctx, e, id interface{}) *gomock.Call {
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockCommentMockRecorder) UpdateComment(ctx, c interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockRepository)(nil).UpdateTask), ctx, e, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockComment)(nil).UpdateComment), ctx, c)
 }
 
-// UpdateTaskStatus mocks base method.
-func (m *MockRepository) UpdateTaskStatus(ctx context.Context, id primitive.ObjectID, status string) error {
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// CreateAttachment mocks base method.
+func (m *MockAttachment) CreateAttachment(ctx context.Context, a *entity.Attachment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, id, status)
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, a)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateTaskStatus indicates an expected call of UpdateTaskStatus.
-func (mr *MockRepositoryMockRecorder) UpdateTaskStatus(ctx, id, status interface{}) *gomock.Call {
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockAttachmentMockRecorder) CreateAttachment(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockAttachment)(nil).CreateAttachment), ctx, a)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachment) DeleteAttachment(ctx context.Context, owner, taskID, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, owner, taskID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentMockRecorder) DeleteAttachment(ctx, owner, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachment)(nil).DeleteAttachment), ctx, owner, taskID, id)
+}
+
+// DeleteOwnerAttachments mocks base method.
+func (m *MockAttachment) DeleteOwnerAttachments(ctx context.Context, owner primitive.ObjectID) ([]entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOwnerAttachments", ctx, owner)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOwnerAttachments indicates an expected call of DeleteOwnerAttachments.
+func (mr *MockAttachmentMockRecorder) DeleteOwnerAttachments(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOwnerAttachments", reflect.TypeOf((*MockAttachment)(nil).DeleteOwnerAttachments), ctx, owner)
+}
+
+// GetAttachmentByID mocks base method.
+func (m *MockAttachment) GetAttachmentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentByID", ctx, owner, taskID, id)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentByID indicates an expected call of GetAttachmentByID.
+func (mr *MockAttachmentMockRecorder) GetAttachmentByID(ctx, owner, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentByID", reflect.TypeOf((*MockAttachment)(nil).GetAttachmentByID), ctx, owner, taskID, id)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAttachment) GetAttachments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, owner, taskID)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAttachmentMockRecorder) GetAttachments(ctx, owner, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAttachment)(nil).GetAttachments), ctx, owner, taskID)
+}
+
+// MockHistory is a mock of History interface.
+type MockHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryMockRecorder
+}
+
+// MockHistoryMockRecorder is the mock recorder for MockHistory.
+type MockHistoryMockRecorder struct {
+	mock *MockHistory
+}
+
+// NewMockHistory creates a new mock instance.
+func NewMockHistory(ctrl *gomock.Controller) *MockHistory {
+	mock := &MockHistory{ctrl: ctrl}
+	mock.recorder = &MockHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistory) EXPECT() *MockHistoryMockRecorder {
+	return m.recorder
+}
+
+// CreateHistoryEntry mocks base method.
+func (m *MockHistory) CreateHistoryEntry(ctx context.Context, e *entity.HistoryEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHistoryEntry", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateHistoryEntry indicates an expected call of CreateHistoryEntry.
+func (mr *MockHistoryMockRecorder) CreateHistoryEntry(ctx, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHistoryEntry", reflect.TypeOf((*MockHistory)(nil).CreateHistoryEntry), ctx, e)
+}
+
+// GetHistory mocks base method.
+func (m *MockHistory) GetHistory(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, owner, taskID)
+	ret0, _ := ret[0].([]entity.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockHistoryMockRecorder) GetHistory(ctx, owner, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockHistory)(nil).GetHistory), ctx, owner, taskID)
+}
+
+// GetHistoryEntry mocks base method.
+func (m *MockHistory) GetHistoryEntry(ctx context.Context, owner, taskID primitive.ObjectID, version int64) (*entity.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoryEntry", ctx, owner, taskID, version)
+	ret0, _ := ret[0].(*entity.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoryEntry indicates an expected call of GetHistoryEntry.
+func (mr *MockHistoryMockRecorder) GetHistoryEntry(ctx, owner, taskID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryEntry", reflect.TypeOf((*MockHistory)(nil).GetHistoryEntry), ctx, owner, taskID, version)
+}
+
+// MockWorkspace is a mock of Workspace interface.
+type MockWorkspace struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceMockRecorder
+}
+
+// MockWorkspaceMockRecorder is the mock recorder for MockWorkspace.
+type MockWorkspaceMockRecorder struct {
+	mock *MockWorkspace
+}
+
+// NewMockWorkspace creates a new mock instance.
+func NewMockWorkspace(ctrl *gomock.Controller) *MockWorkspace {
+	mock := &MockWorkspace{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspace) EXPECT() *MockWorkspaceMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m_2 *MockWorkspace) AddMember(ctx context.Context, m *entity.Member) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "AddMember", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockWorkspaceMockRecorder) AddMember(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockWorkspace)(nil).AddMember), ctx, m)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockWorkspace) CreateInvitation(ctx context.Context, i *entity.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockWorkspaceMockRecorder) CreateInvitation(ctx, i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockWorkspace)(nil).CreateInvitation), ctx, i)
+}
+
+// CreateWorkspace mocks base method.
+func (m *MockWorkspace) CreateWorkspace(ctx context.Context, w *entity.Workspace, owner *entity.Member) (*entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", ctx, w, owner)
+	ret0, _ := ret[0].(*entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockWorkspaceMockRecorder) CreateWorkspace(ctx, w, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockWorkspace)(nil).CreateWorkspace), ctx, w, owner)
+}
+
+// DeleteInvitation mocks base method.
+func (m *MockWorkspace) DeleteInvitation(ctx context.Context, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvitation", ctx, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvitation indicates an expected call of DeleteInvitation.
+func (mr *MockWorkspaceMockRecorder) DeleteInvitation(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvitation", reflect.TypeOf((*MockWorkspace)(nil).DeleteInvitation), ctx, tokenHash)
+}
+
+// DeleteWorkspace mocks base method.
+func (m *MockWorkspace) DeleteWorkspace(ctx context.Context, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspace", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspace indicates an expected call of DeleteWorkspace.
+func (mr *MockWorkspaceMockRecorder) DeleteWorkspace(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspace", reflect.TypeOf((*MockWorkspace)(nil).DeleteWorkspace), ctx, id)
+}
+
+// GetInvitation mocks base method.
+func (m *MockWorkspace) GetInvitation(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitation", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitation indicates an expected call of GetInvitation.
+func (mr *MockWorkspaceMockRecorder) GetInvitation(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitation", reflect.TypeOf((*MockWorkspace)(nil).GetInvitation), ctx, tokenHash)
+}
+
+// GetMember mocks base method.
+func (m *MockWorkspace) GetMember(ctx context.Context, workspace, userID primitive.ObjectID) (*entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", ctx, workspace, userID)
+	ret0, _ := ret[0].(*entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockWorkspaceMockRecorder) GetMember(ctx, workspace, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockWorkspace)(nil).GetMember), ctx, workspace, userID)
+}
+
+// GetMembers mocks base method.
+func (m *MockWorkspace) GetMembers(ctx context.Context, workspace primitive.ObjectID) ([]entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, workspace)
+	ret0, _ := ret[0].([]entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockWorkspaceMockRecorder) GetMembers(ctx, workspace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockWorkspace)(nil).GetMembers), ctx, workspace)
+}
+
+// GetWorkspaceByID mocks base method.
+func (m *MockWorkspace) GetWorkspaceByID(ctx context.Context, id primitive.ObjectID) (*entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceByID indicates an expected call of GetWorkspaceByID.
+func (mr *MockWorkspaceMockRecorder) GetWorkspaceByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceByID", reflect.TypeOf((*MockWorkspace)(nil).GetWorkspaceByID), ctx, id)
+}
+
+// GetWorkspaces mocks base method.
+func (m *MockWorkspace) GetWorkspaces(ctx context.Context, userID primitive.ObjectID) ([]entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaces", ctx, userID)
+	ret0, _ := ret[0].([]entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaces indicates an expected call of GetWorkspaces.
+func (mr *MockWorkspaceMockRecorder) GetWorkspaces(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaces", reflect.TypeOf((*MockWorkspace)(nil).GetWorkspaces), ctx, userID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockWorkspace) RemoveMember(ctx context.Context, workspace, userID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, workspace, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockWorkspaceMockRecorder) RemoveMember(ctx, workspace, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockWorkspace)(nil).RemoveMember), ctx, workspace, userID)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockWorkspace) UpdateMemberRole(ctx context.Context, workspace, userID primitive.ObjectID, role entity.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", ctx, workspace, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockWorkspaceMockRecorder) UpdateMemberRole(ctx, workspace, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockWorkspace)(nil).UpdateMemberRole), ctx, workspace, userID, role)
+}
+
+// UpdateWorkspace mocks base method.
+func (m *MockWorkspace) UpdateWorkspace(ctx context.Context, w *entity.Workspace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspace", ctx, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspace indicates an expected call of UpdateWorkspace.
+func (mr *MockWorkspaceMockRecorder) UpdateWorkspace(ctx, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockWorkspace)(nil).UpdateWorkspace), ctx, w)
+}
+
+// MockUser is a mock of User interface.
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser.
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance.
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockUser) CreateUser(ctx context.Context, u *entity.User) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, u)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUserMockRecorder) CreateUser(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUser)(nil).CreateUser), ctx, u)
+}
+
+// GetUserByID mocks base method.
+func (m *MockUser) GetUserByID(ctx context.Context, id primitive.ObjectID) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUser)(nil).GetUserByID), ctx, id)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockUser) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockUserMockRecorder) GetUserByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockUser)(nil).GetUserByUsername), ctx, username)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddDependency mocks base method.
+func (m *MockRepository) AddDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", ctx, owner, id, blocker, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockRepositoryMockRecorder) AddDependency(ctx, owner, id, blocker, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockRepository)(nil).AddDependency), ctx, owner, id, blocker, version)
+}
+
+// AddMember mocks base method.
+func (m_2 *MockRepository) AddMember(ctx context.Context, m *entity.Member) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "AddMember", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockRepositoryMockRecorder) AddMember(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockRepository)(nil).AddMember), ctx, m)
+}
+
+// CompleteOccurrence mocks base method.
+func (m *MockRepository) CompleteOccurrence(ctx context.Context, task *entity.Tasks, version int64, next *entity.Tasks) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOccurrence", ctx, task, version, next)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteOccurrence indicates an expected call of CompleteOccurrence.
+func (mr *MockRepositoryMockRecorder) CompleteOccurrence(ctx, task, version, next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOccurrence", reflect.TypeOf((*MockRepository)(nil).CompleteOccurrence), ctx, task, version, next)
+}
+
+// CreateAttachment mocks base method.
+func (m *MockRepository) CreateAttachment(ctx context.Context, a *entity.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockRepositoryMockRecorder) CreateAttachment(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockRepository)(nil).CreateAttachment), ctx, a)
+}
+
+// CreateComment mocks base method.
+func (m *MockRepository) CreateComment(ctx context.Context, c *entity.Comment) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, c)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockRepositoryMockRecorder) CreateComment(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockRepository)(nil).CreateComment), ctx, c)
+}
+
+// CreateHistoryEntry mocks base method.
+func (m *MockRepository) CreateHistoryEntry(ctx context.Context, e *entity.HistoryEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHistoryEntry", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateHistoryEntry indicates an expected call of CreateHistoryEntry.
+func (mr *MockRepositoryMockRecorder) CreateHistoryEntry(ctx, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHistoryEntry", reflect.TypeOf((*MockRepository)(nil).CreateHistoryEntry), ctx, e)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockRepository) CreateInvitation(ctx context.Context, i *entity.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockRepositoryMockRecorder) CreateInvitation(ctx, i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockRepository)(nil).CreateInvitation), ctx, i)
+}
+
+// CreateList mocks base method.
+func (m *MockRepository) CreateList(ctx context.Context, l *entity.List) (*entity.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateList", ctx, l)
+	ret0, _ := ret[0].(*entity.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateList indicates an expected call of CreateList.
+func (mr *MockRepositoryMockRecorder) CreateList(ctx, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateList", reflect.TypeOf((*MockRepository)(nil).CreateList), ctx, l)
+}
+
+// CreateTask mocks base method.
+func (m *MockRepository) CreateTask(ctx context.Context, e *entity.Tasks) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTask", ctx, e)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockRepositoryMockRecorder) CreateTask(ctx, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockRepository)(nil).CreateTask), ctx, e)
+}
+
+// CreateUser mocks base method.
+func (m *MockRepository) CreateUser(ctx context.Context, u *entity.User) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, u)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockRepositoryMockRecorder) CreateUser(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepository)(nil).CreateUser), ctx, u)
+}
+
+// CreateWorkspace mocks base method.
+func (m *MockRepository) CreateWorkspace(ctx context.Context, w *entity.Workspace, owner *entity.Member) (*entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", ctx, w, owner)
+	ret0, _ := ret[0].(*entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockRepositoryMockRecorder) CreateWorkspace(ctx, w, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockRepository)(nil).CreateWorkspace), ctx, w, owner)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockRepository) DeleteAttachment(ctx context.Context, owner, taskID, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, owner, taskID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockRepositoryMockRecorder) DeleteAttachment(ctx, owner, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockRepository)(nil).DeleteAttachment), ctx, owner, taskID, id)
+}
+
+// DeleteComment mocks base method.
+func (m *MockRepository) DeleteComment(ctx context.Context, owner, taskID, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, owner, taskID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockRepositoryMockRecorder) DeleteComment(ctx, owner, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockRepository)(nil).DeleteComment), ctx, owner, taskID, id)
+}
+
+// DeleteInvitation mocks base method.
+func (m *MockRepository) DeleteInvitation(ctx context.Context, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvitation", ctx, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvitation indicates an expected call of DeleteInvitation.
+func (mr *MockRepositoryMockRecorder) DeleteInvitation(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvitation", reflect.TypeOf((*MockRepository)(nil).DeleteInvitation), ctx, tokenHash)
+}
+
+// DeleteList mocks base method.
+func (m *MockRepository) DeleteList(ctx context.Context, owner, id primitive.ObjectID) ([]entity.Attachment, []entity.TaskRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteList", ctx, owner, id)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].([]entity.TaskRevision)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockRepositoryMockRecorder) DeleteList(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockRepository)(nil).DeleteList), ctx, owner, id)
+}
+
+// DeleteOwnerAttachments mocks base method.
+func (m *MockRepository) DeleteOwnerAttachments(ctx context.Context, owner primitive.ObjectID) ([]entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOwnerAttachments", ctx, owner)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOwnerAttachments indicates an expected call of DeleteOwnerAttachments.
+func (mr *MockRepositoryMockRecorder) DeleteOwnerAttachments(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOwnerAttachments", reflect.TypeOf((*MockRepository)(nil).DeleteOwnerAttachments), ctx, owner)
+}
+
+// DeleteTask mocks base method.
+func (m *MockRepository) DeleteTask(ctx context.Context, owner, id primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, owner, id, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockRepositoryMockRecorder) DeleteTask(ctx, owner, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockRepository)(nil).DeleteTask), ctx, owner, id, version)
+}
+
+// DeleteWorkspace mocks base method.
+func (m *MockRepository) DeleteWorkspace(ctx context.Context, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspace", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspace indicates an expected call of DeleteWorkspace.
+func (mr *MockRepositoryMockRecorder) DeleteWorkspace(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspace", reflect.TypeOf((*MockRepository)(nil).DeleteWorkspace), ctx, id)
+}
+
+// GetAllTasks mocks base method.
+func (m *MockRepository) GetAllTasks(ctx context.Context, filter *entity.TaskFilter) ([]entity.Tasks, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTasks", ctx, filter)
+	ret0, _ := ret[0].([]entity.Tasks)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllTasks indicates an expected call of GetAllTasks.
+func (mr *MockRepositoryMockRecorder) GetAllTasks(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockRepository)(nil).GetAllTasks), ctx, filter)
+}
+
+// GetAttachmentByID mocks base method.
+func (m *MockRepository) GetAttachmentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentByID", ctx, owner, taskID, id)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentByID indicates an expected call of GetAttachmentByID.
+func (mr *MockRepositoryMockRecorder) GetAttachmentByID(ctx, owner, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentByID", reflect.TypeOf((*MockRepository)(nil).GetAttachmentByID), ctx, owner, taskID, id)
+}
+
+// GetAttachments mocks base method.
+func (m *MockRepository) GetAttachments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, owner, taskID)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockRepositoryMockRecorder) GetAttachments(ctx, owner, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockRepository)(nil).GetAttachments), ctx, owner, taskID)
+}
+
+// GetCommentByID mocks base method.
+func (m *MockRepository) GetCommentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentByID", ctx, owner, taskID, id)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentByID indicates an expected call of GetCommentByID.
+func (mr *MockRepositoryMockRecorder) GetCommentByID(ctx, owner, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentByID", reflect.TypeOf((*MockRepository)(nil).GetCommentByID), ctx, owner, taskID, id)
+}
+
+// GetComments mocks base method.
+func (m *MockRepository) GetComments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, owner, taskID)
+	ret0, _ := ret[0].([]entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockRepositoryMockRecorder) GetComments(ctx, owner, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockRepository)(nil).GetComments), ctx, owner, taskID)
+}
+
+// GetHistory mocks base method.
+func (m *MockRepository) GetHistory(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, owner, taskID)
+	ret0, _ := ret[0].([]entity.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockRepositoryMockRecorder) GetHistory(ctx, owner, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockRepository)(nil).GetHistory), ctx, owner, taskID)
+}
+
+// GetHistoryEntry mocks base method.
+func (m *MockRepository) GetHistoryEntry(ctx context.Context, owner, taskID primitive.ObjectID, version int64) (*entity.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoryEntry", ctx, owner, taskID, version)
+	ret0, _ := ret[0].(*entity.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoryEntry indicates an expected call of GetHistoryEntry.
+func (mr *MockRepositoryMockRecorder) GetHistoryEntry(ctx, owner, taskID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryEntry", reflect.TypeOf((*MockRepository)(nil).GetHistoryEntry), ctx, owner, taskID, version)
+}
+
+// GetInvitation mocks base method.
+func (m *MockRepository) GetInvitation(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitation", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitation indicates an expected call of GetInvitation.
+func (mr *MockRepositoryMockRecorder) GetInvitation(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitation", reflect.TypeOf((*MockRepository)(nil).GetInvitation), ctx, tokenHash)
+}
+
+// GetListByID mocks base method.
+func (m *MockRepository) GetListByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListByID", ctx, owner, id)
+	ret0, _ := ret[0].(*entity.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListByID indicates an expected call of GetListByID.
+func (mr *MockRepositoryMockRecorder) GetListByID(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListByID", reflect.TypeOf((*MockRepository)(nil).GetListByID), ctx, owner, id)
+}
+
+// GetLists mocks base method.
+func (m *MockRepository) GetLists(ctx context.Context, owner primitive.ObjectID) ([]entity.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLists", ctx, owner)
+	ret0, _ := ret[0].([]entity.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLists indicates an expected call of GetLists.
+func (mr *MockRepositoryMockRecorder) GetLists(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockRepository)(nil).GetLists), ctx, owner)
+}
+
+// GetMember mocks base method.
+func (m *MockRepository) GetMember(ctx context.Context, workspace, userID primitive.ObjectID) (*entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", ctx, workspace, userID)
+	ret0, _ := ret[0].(*entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockRepositoryMockRecorder) GetMember(ctx, workspace, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockRepository)(nil).GetMember), ctx, workspace, userID)
+}
+
+// GetMembers mocks base method.
+func (m *MockRepository) GetMembers(ctx context.Context, workspace primitive.ObjectID) ([]entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, workspace)
+	ret0, _ := ret[0].([]entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockRepositoryMockRecorder) GetMembers(ctx, workspace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockRepository)(nil).GetMembers), ctx, workspace)
+}
+
+// GetTags mocks base method.
+func (m *MockRepository) GetTags(ctx context.Context, owner primitive.ObjectID) ([]entity.TagStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx, owner)
+	ret0, _ := ret[0].([]entity.TagStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockRepositoryMockRecorder) GetTags(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockRepository)(nil).GetTags), ctx, owner)
+}
+
+// GetTaskByID mocks base method.
+func (m *MockRepository) GetTaskByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByID", ctx, owner, id)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByID indicates an expected call of GetTaskByID.
+func (mr *MockRepositoryMockRecorder) GetTaskByID(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockRepository)(nil).GetTaskByID), ctx, owner, id)
+}
+
+// GetTasksByIDs mocks base method.
+func (m *MockRepository) GetTasksByIDs(ctx context.Context, owner primitive.ObjectID, ids []primitive.ObjectID) ([]entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByIDs", ctx, owner, ids)
+	ret0, _ := ret[0].([]entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByIDs indicates an expected call of GetTasksByIDs.
+func (mr *MockRepositoryMockRecorder) GetTasksByIDs(ctx, owner, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByIDs", reflect.TypeOf((*MockRepository)(nil).GetTasksByIDs), ctx, owner, ids)
+}
+
+// GetUserByID mocks base method.
+func (m *MockRepository) GetUserByID(ctx context.Context, id primitive.ObjectID) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockRepository)(nil).GetUserByID), ctx, id)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockRepository) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockRepositoryMockRecorder) GetUserByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockRepository)(nil).GetUserByUsername), ctx, username)
+}
+
+// GetWorkspaceByID mocks base method.
+func (m *MockRepository) GetWorkspaceByID(ctx context.Context, id primitive.ObjectID) (*entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceByID indicates an expected call of GetWorkspaceByID.
+func (mr *MockRepositoryMockRecorder) GetWorkspaceByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceByID", reflect.TypeOf((*MockRepository)(nil).GetWorkspaceByID), ctx, id)
+}
+
+// GetWorkspaces mocks base method.
+func (m *MockRepository) GetWorkspaces(ctx context.Context, userID primitive.ObjectID) ([]entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaces", ctx, userID)
+	ret0, _ := ret[0].([]entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaces indicates an expected call of GetWorkspaces.
+func (mr *MockRepositoryMockRecorder) GetWorkspaces(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaces", reflect.TypeOf((*MockRepository)(nil).GetWorkspaces), ctx, userID)
+}
+
+// MoveTask mocks base method.
+func (m *MockRepository) MoveTask(ctx context.Context, owner, id primitive.ObjectID, listID *primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", ctx, owner, id, listID, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockRepositoryMockRecorder) MoveTask(ctx, owner, id, listID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockRepository)(nil).MoveTask), ctx, owner, id, listID, version)
+}
+
+// PatchTask mocks base method.
+func (m *MockRepository) PatchTask(ctx context.Context, owner, id primitive.ObjectID, patch *entity.TaskPatch, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTask", ctx, owner, id, patch, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchTask indicates an expected call of PatchTask.
+func (mr *MockRepositoryMockRecorder) PatchTask(ctx, owner, id, patch, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTask", reflect.TypeOf((*MockRepository)(nil).PatchTask), ctx, owner, id, patch, version)
+}
+
+// PurgeDeletedTasks mocks base method.
+func (m *MockRepository) PurgeDeletedTasks(ctx context.Context, before time.Time) (int64, []entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedTasks", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]entity.Attachment)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PurgeDeletedTasks indicates an expected call of PurgeDeletedTasks.
+func (mr *MockRepositoryMockRecorder) PurgeDeletedTasks(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedTasks", reflect.TypeOf((*MockRepository)(nil).PurgeDeletedTasks), ctx, before)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockRepository) RemoveDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", ctx, owner, id, blocker, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockRepositoryMockRecorder) RemoveDependency(ctx, owner, id, blocker, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockRepository)(nil).RemoveDependency), ctx, owner, id, blocker, version)
+}
+
+// RemoveMember mocks base method.
+func (m *MockRepository) RemoveMember(ctx context.Context, workspace, userID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, workspace, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockRepositoryMockRecorder) RemoveMember(ctx, workspace, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockRepository)(nil).RemoveMember), ctx, workspace, userID)
+}
+
+// RenameTag mocks base method.
+func (m *MockRepository) RenameTag(ctx context.Context, owner primitive.ObjectID, from, to string) ([]entity.TaskRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, owner, from, to)
+	ret0, _ := ret[0].([]entity.TaskRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockRepositoryMockRecorder) RenameTag(ctx, owner, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockRepository)(nil).RenameTag), ctx, owner, from, to)
+}
+
+// RestoreTask mocks base method.
+func (m *MockRepository) RestoreTask(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, owner, id)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockRepositoryMockRecorder) RestoreTask(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockRepository)(nil).RestoreTask), ctx, owner, id)
+}
+
+// SetRecurrence mocks base method.
+func (m *MockRepository) SetRecurrence(ctx context.Context, owner, id primitive.ObjectID, rule string, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecurrence", ctx, owner, id, rule, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRecurrence indicates an expected call of SetRecurrence.
+func (mr *MockRepositoryMockRecorder) SetRecurrence(ctx, owner, id, rule, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecurrence", reflect.TypeOf((*MockRepository)(nil).SetRecurrence), ctx, owner, id, rule, version)
+}
+
+// UpdateChecklist mocks base method.
+func (m *MockRepository) UpdateChecklist(ctx context.Context, owner, id primitive.ObjectID, checklist []entity.ChecklistItem, status string, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklist", ctx, owner, id, checklist, status, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChecklist indicates an expected call of UpdateChecklist.
+func (mr *MockRepositoryMockRecorder) UpdateChecklist(ctx, owner, id, checklist, status, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklist", reflect.TypeOf((*MockRepository)(nil).UpdateChecklist), ctx, owner, id, checklist, status, version)
+}
+
+// UpdateComment mocks base method.
+func (m *MockRepository) UpdateComment(ctx context.Context, c *entity.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockRepositoryMockRecorder) UpdateComment(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockRepository)(nil).UpdateComment), ctx, c)
+}
+
+// UpdateList mocks base method.
+func (m *MockRepository) UpdateList(ctx context.Context, l *entity.List) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateList", ctx, l)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateList indicates an expected call of UpdateList.
+func (mr *MockRepositoryMockRecorder) UpdateList(ctx, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateList", reflect.TypeOf((*MockRepository)(nil).UpdateList), ctx, l)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockRepository) UpdateMemberRole(ctx context.Context, workspace, userID primitive.ObjectID, role entity.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", ctx, workspace, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockRepositoryMockRecorder) UpdateMemberRole(ctx, workspace, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockRepository)(nil).UpdateMemberRole), ctx, workspace, userID, role)
+}
+
+// UpdateTask mocks base method.
+func (m *MockRepository) UpdateTask(ctx context.Context, e *entity.Tasks, id primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, e, id, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockRepositoryMockRecorder) UpdateTask(ctx, e, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockRepository)(nil).UpdateTask), ctx, e, id, version)
+}
+
+// UpdateTaskStatus mocks base method.
+func (m *MockRepository) UpdateTaskStatus(ctx context.Context, owner, id primitive.ObjectID, status string, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, owner, id, status, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskStatus indicates an expected call of UpdateTaskStatus.
+func (mr *MockRepositoryMockRecorder) UpdateTaskStatus(ctx, owner, id, status, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockRepository)(nil).UpdateTaskStatus), ctx, owner, id, status, version)
+}
+
+// UpdateWorkspace mocks base method.
+func (m *MockRepository) UpdateWorkspace(ctx context.Context, w *entity.Workspace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspace", ctx, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspace indicates an expected call of UpdateWorkspace.
+func (mr *MockRepositoryMockRecorder) UpdateWorkspace(ctx, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockRepository)(nil).UpdateTaskStatus), ctx, id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockRepository)(nil).UpdateWorkspace), ctx, w)
 }
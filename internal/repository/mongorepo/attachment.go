@@ -0,0 +1,118 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+)
+
+func (m *MongoDB) CreateAttachment(ctx context.Context, a *entity.Attachment) error {
+	result, err := m.attachmentCollection.InsertOne(ctx, a)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %v", err)
+	}
+
+	a.ID = result.InsertedID.(primitive.ObjectID)
+
+	log.Printf("create attachment")
+
+	return nil
+}
+
+func (m *MongoDB) GetAttachments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Attachment, error) {
+	attachments, err := m.findAttachments(ctx, bson.M{"owner": owner, "taskId": taskID})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("get attachments")
+
+	return attachments, nil
+}
+
+func (m *MongoDB) GetAttachmentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Attachment, error) {
+	var attachment entity.Attachment
+
+	err := m.attachmentCollection.FindOne(ctx, bson.M{"_id": id, "owner": owner, "taskId": taskID}).Decode(&attachment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custom_error.ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get attachment by ID: %v", err)
+	}
+
+	log.Printf("get attachment")
+
+	return &attachment, nil
+}
+
+func (m *MongoDB) DeleteAttachment(ctx context.Context, owner, taskID, id primitive.ObjectID) error {
+	result, err := m.attachmentCollection.DeleteOne(ctx, bson.M{"_id": id, "owner": owner, "taskId": taskID})
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment. error: %v", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return custom_error.ErrAttachmentNotFound
+	}
+
+	log.Printf("delete attachment")
+
+	return nil
+}
+
+func (m *MongoDB) DeleteOwnerAttachments(ctx context.Context, owner primitive.ObjectID) ([]entity.Attachment, error) {
+	return m.deleteAttachments(ctx, bson.M{"owner": owner})
+}
+
+// deleteAttachments удаляет найденные по filter вложения и возвращает их.
+// Удаляются только найденные документы, вложение, загруженное между запросами, остается
+func (m *MongoDB) deleteAttachments(ctx context.Context, filter bson.M) ([]entity.Attachment, error) {
+	attachments, err := m.findAttachments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(attachments))
+	for i, attachment := range attachments {
+		ids[i] = attachment.ID
+	}
+
+	_, err = m.attachmentCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete attachments. error: %v", err)
+	}
+
+	log.Printf("delete attachments")
+
+	return attachments, nil
+}
+
+func (m *MongoDB) findAttachments(ctx context.Context, filter bson.M) ([]entity.Attachment, error) {
+	var attachments []entity.Attachment
+
+	cursor, err := m.attachmentCollection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve attachments. error: %v", err)
+	}
+	defer func() {
+		err = cursor.Close(ctx)
+	}()
+
+	err = cursor.All(ctx, &attachments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode attachments. error: %v", err)
+	}
+
+	return attachments, nil
+}
@@ -0,0 +1,27 @@
+package mongorepo
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+func (m *MongoDB) UpdateChecklist(ctx context.Context, owner, id primitive.ObjectID, checklist []entity.ChecklistItem, status string, version int64) (*entity.Tasks, error) {
+	update := bson.M{"$set": bson.M{"status": status}, "$inc": bson.M{"version": 1}}
+	if len(checklist) > 0 {
+		update["$set"] = bson.M{"status": status, "checklist": checklist}
+	} else {
+		update["$unset"] = bson.M{"checklist": ""}
+	}
+
+	task, err := m.updateTask(ctx, owner, id, version, update, "update checklist")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("update checklist")
+
+	return task, nil
+}
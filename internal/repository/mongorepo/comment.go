@@ -0,0 +1,103 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+)
+
+func (m *MongoDB) CreateComment(ctx context.Context, c *entity.Comment) (*entity.Comment, error) {
+	result, err := m.commentCollection.InsertOne(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %v", err)
+	}
+
+	c.ID = result.InsertedID.(primitive.ObjectID)
+
+	log.Printf("create comment")
+
+	return c, nil
+}
+
+func (m *MongoDB) GetComments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Comment, error) {
+	var comments []entity.Comment
+
+	cursor, err := m.commentCollection.Find(ctx,
+		bson.M{"owner": owner, "taskId": taskID},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve comments. error: %v", err)
+	}
+	defer func() {
+		err = cursor.Close(ctx)
+	}()
+
+	err = cursor.All(ctx, &comments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode comments. error: %v", err)
+	}
+
+	log.Printf("get comments")
+
+	return comments, nil
+}
+
+func (m *MongoDB) GetCommentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Comment, error) {
+	var comment entity.Comment
+
+	err := m.commentCollection.FindOne(ctx, commentFilter(owner, taskID, id)).Decode(&comment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custom_error.ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("failed to get comment by ID: %v", err)
+	}
+
+	log.Printf("get comment")
+
+	return &comment, nil
+}
+
+func (m *MongoDB) UpdateComment(ctx context.Context, c *entity.Comment) error {
+	result, err := m.commentCollection.UpdateOne(ctx,
+		commentFilter(c.Owner, c.TaskID, c.ID),
+		bson.M{"$set": bson.M{"body": c.Body, "updatedAt": c.UpdatedAt, "edits": c.Edits}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update comment. error: %v", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return custom_error.ErrCommentNotFound
+	}
+
+	log.Printf("update comment")
+
+	return nil
+}
+
+func (m *MongoDB) DeleteComment(ctx context.Context, owner, taskID, id primitive.ObjectID) error {
+	result, err := m.commentCollection.DeleteOne(ctx, commentFilter(owner, taskID, id))
+	if err != nil {
+		return fmt.Errorf("failed to delete comment. error: %v", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return custom_error.ErrCommentNotFound
+	}
+
+	log.Printf("delete comment")
+
+	return nil
+}
+
+func commentFilter(owner, taskID, id primitive.ObjectID) bson.M {
+	return bson.M{"_id": id, "owner": owner, "taskId": taskID}
+}
@@ -0,0 +1,153 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"sort"
+	"strings"
+)
+
+// duplicateGroup - документы с одинаковыми значениями полей уникального индекса
+type duplicateGroup struct {
+	Key bson.M               `bson:"_id"`
+	IDs []primitive.ObjectID `bson:"ids"`
+}
+
+// findDuplicates возвращает группы документов collection, которые не дают создать
+// уникальный индекс по keys. Отсутствующее поле индексируется как null, поэтому
+// и группируется как null
+func findDuplicates(ctx context.Context, collection *mongo.Collection, keys []string) ([]duplicateGroup, error) {
+	key := bson.M{}
+	for _, k := range keys {
+		key[k] = bson.M{"$ifNull": bson.A{"$" + k, nil}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": key, "ids": bson.M{"$push": "$_id"}, "count": bson.M{"$sum": 1}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicates in %s: %v", collection.Name(), err)
+	}
+
+	var groups []duplicateGroup
+	if err = cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("failed to decode duplicates in %s: %v", collection.Name(), err)
+	}
+
+	return groups, nil
+}
+
+// renameDuplicateTitles переименовывает документы с одинаковым названием в пределах
+// keys, сохраненные до появления уникального индекса: самый старый документ сохраняет
+// название, остальные получают суффикс " (2)", " (3)" и так далее.
+// set возвращает поля, которые записываются вместе с новым названием
+func renameDuplicateTitles(ctx context.Context, collection *mongo.Collection, keys []string, set func(title string) bson.M) (int64, error) {
+	groups, err := findDuplicates(ctx, collection, append(keys, "title"))
+	if err != nil {
+		return 0, err
+	}
+
+	var renamed int64
+	for _, group := range groups {
+		// ObjectID начинается со времени создания, поэтому первым идет самый старый документ
+		sort.Slice(group.IDs, func(i, j int) bool {
+			return group.IDs[i].Hex() < group.IDs[j].Hex()
+		})
+
+		filter := bson.M{}
+		for _, k := range keys {
+			filter[k] = bson.M{"$eq": group.Key[k]}
+		}
+
+		title, _ := group.Key["title"].(string)
+		suffix := 2
+		for _, id := range group.IDs[1:] {
+			var candidate string
+			for {
+				candidate = fmt.Sprintf("%s (%d)", title, suffix)
+				suffix++
+
+				filter["title"] = candidate
+				count, err := collection.CountDocuments(ctx, filter)
+				if err != nil {
+					return renamed, fmt.Errorf("failed to check title in %s: %v", collection.Name(), err)
+				}
+				if count == 0 {
+					break
+				}
+			}
+
+			_, err = collection.UpdateByID(ctx, id, bson.M{"$set": set(candidate)})
+			if err != nil {
+				return renamed, fmt.Errorf("failed to rename %s in %s: %v", id.Hex(), collection.Name(), err)
+			}
+			log.Printf("dedupe %s: %s renamed %q -> %q", collection.Name(), id.Hex(), title, candidate)
+			renamed++
+		}
+	}
+
+	return renamed, nil
+}
+
+// DedupeTaskTitles переименовывает задачи с одинаковыми названиями в одном списке,
+// иначе индекс уникальности названий не создается. Повторный запуск ничего не меняет
+func (m *MongoDB) DedupeTaskTitles(ctx context.Context) (int64, error) {
+	renamed, err := renameDuplicateTitles(ctx, m.taskCollection, []string{"owner", "listId", "deletedAt", "occurrence"},
+		func(title string) bson.M {
+			return bson.M{"title": title, "titleTerms": searchPrefixes(title)}
+		})
+	if err != nil {
+		return renamed, err
+	}
+
+	log.Printf("dedupe task titles: %d tasks", renamed)
+
+	return renamed, nil
+}
+
+// DedupeListTitles переименовывает списки пользователя с одинаковыми названиями.
+// Повторный запуск ничего не меняет
+func (m *MongoDB) DedupeListTitles(ctx context.Context) (int64, error) {
+	renamed, err := renameDuplicateTitles(ctx, m.listCollection, []string{"owner"},
+		func(title string) bson.M {
+			return bson.M{"title": title}
+		})
+	if err != nil {
+		return renamed, err
+	}
+
+	log.Printf("dedupe list titles: %d lists", renamed)
+
+	return renamed, nil
+}
+
+// checkDuplicateUsernames не дает создать индекс уникальности имен, пока в базе есть
+// одинаковые имена: переименовать пользователя за него нельзя, поэтому ошибка
+// называет имена, которые нужно исправить вручную
+func (m *MongoDB) checkDuplicateUsernames(ctx context.Context) error {
+	groups, err := findDuplicates(ctx, m.userCollection, []string{"username"})
+	if err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+
+	usernames := make([]string, 0, len(groups))
+	for _, group := range groups {
+		username, _ := group.Key["username"].(string)
+		usernames = append(usernames, fmt.Sprintf("%q", username))
+	}
+	sort.Strings(usernames)
+
+	return fmt.Errorf("collection %s has several users with username %s: rename or remove the extra users, then restart",
+		m.userCollection.Name(), strings.Join(usernames, ", "))
+}
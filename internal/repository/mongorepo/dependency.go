@@ -0,0 +1,59 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+func (m *MongoDB) GetTasksByIDs(ctx context.Context, owner primitive.ObjectID, ids []primitive.ObjectID) ([]entity.Tasks, error) {
+	var tasks []entity.Tasks
+
+	filter := bson.M{"_id": bson.M{"$in": ids}, "owner": owner, "deletedAt": liveTask}
+
+	cursor, err := m.taskCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tasks by ids. error: %v", err)
+	}
+	defer func() {
+		err = cursor.Close(ctx)
+	}()
+
+	err = cursor.All(ctx, &tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tasks. error: %v", err)
+	}
+
+	log.Printf("get tasks by ids")
+
+	return tasks, nil
+}
+
+func (m *MongoDB) AddDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	update := bson.M{"$addToSet": bson.M{"dependencies": blocker}, "$inc": bson.M{"version": 1}}
+
+	task, err := m.updateTask(ctx, owner, id, version, update, "add dependency")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("add dependency")
+
+	return task, nil
+}
+
+func (m *MongoDB) RemoveDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	update := bson.M{"$pull": bson.M{"dependencies": blocker}, "$inc": bson.M{"version": 1}}
+
+	task, err := m.updateTask(ctx, owner, id, version, update, "remove dependency")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("remove dependency")
+
+	return task, nil
+}
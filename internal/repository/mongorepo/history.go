@@ -0,0 +1,69 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+)
+
+func (m *MongoDB) CreateHistoryEntry(ctx context.Context, e *entity.HistoryEntry) error {
+	result, err := m.historyCollection.InsertOne(ctx, e)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return custom_error.ErrVersionMismatch
+		}
+		return fmt.Errorf("failed to create history entry: %v", err)
+	}
+
+	e.ID = result.InsertedID.(primitive.ObjectID)
+
+	log.Printf("create history entry")
+
+	return nil
+}
+
+func (m *MongoDB) GetHistory(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.HistoryEntry, error) {
+	var history []entity.HistoryEntry
+
+	cursor, err := m.historyCollection.Find(ctx,
+		bson.M{"owner": owner, "taskId": taskID},
+		options.Find().SetSort(bson.D{{Key: "version", Value: 1}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve history. error: %v", err)
+	}
+	defer func() {
+		err = cursor.Close(ctx)
+	}()
+
+	err = cursor.All(ctx, &history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode history. error: %v", err)
+	}
+
+	log.Printf("get history")
+
+	return history, nil
+}
+
+func (m *MongoDB) GetHistoryEntry(ctx context.Context, owner, taskID primitive.ObjectID, version int64) (*entity.HistoryEntry, error) {
+	var entry entity.HistoryEntry
+
+	err := m.historyCollection.FindOne(ctx, bson.M{"owner": owner, "taskId": taskID, "version": version}).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custom_error.ErrHistoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get history entry: %v", err)
+	}
+
+	log.Printf("get history entry")
+
+	return &entry, nil
+}
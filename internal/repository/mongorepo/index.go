@@ -0,0 +1,184 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+)
+
+// EnsureIndexes создает индексы коллекций. Уникальность названия задачи
+// и имени пользователя проверяет сама MongoDB, поэтому параллельные запросы
+// не могут создать дубликаты. Одинаковые названия задач и списков, сохраненные
+// до появления индексов, переименовываются, одинаковые имена пользователей нужно исправить вручную
+func (m *MongoDB) EnsureIndexes(ctx context.Context) error {
+	// Индекс уникальности без deletedAt мешал создать задачу с названием задачи из корзины,
+	// без occurrence - следующее повторение серии, без listId - задачу с тем же названием
+	// в другом списке. Текстовый индекс только по названию заменен индексом по названию и описанию
+	for _, name := range []string{
+		"owner_title_unique", "owner_title_deleted_unique", "owner_title_deleted_occurrence_unique", "owner_search_text",
+	} {
+		err := m.dropIndexIfExists(ctx, m.taskCollection, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := m.DedupeTaskTitles(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.taskCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// У обычных задач deletedAt и occurrence нет и индексируются как null, поэтому названия
+			// уникальны среди них, а задачи в корзине и завершенные повторения серий с ними не конфликтуют.
+			// Задачи во входящих без listId тоже индексируются как null. Индекс нужен и для выборки по списку
+			Keys: bson.D{
+				{Key: "owner", Value: 1},
+				{Key: "listId", Value: 1},
+				{Key: "title", Value: 1},
+				{Key: "deletedAt", Value: 1},
+				{Key: "occurrence", Value: 1},
+			},
+			Options: options.Index().SetName("owner_list_title_deleted_occurrence_unique").SetUnique(true),
+		},
+		{
+			// Поиск всегда идет по задачам одного владельца, поэтому owner - префикс индекса.
+			// Язык none: слова не приводятся к основе, стоп-слова не выбрасываются
+			Keys: bson.D{
+				{Key: "owner", Value: 1},
+				{Key: "title", Value: "text"},
+				{Key: "description", Value: "text"},
+				{Key: "titleTerms", Value: "text"},
+				{Key: "descriptionTerms", Value: "text"},
+			},
+			Options: options.Index().SetName("owner_search_text_description").
+				SetDefaultLanguage("none").
+				SetWeights(bson.M{"title": 10, "description": 5, "titleTerms": 2, "descriptionTerms": 1}),
+		},
+		{
+			Keys:    bson.D{{Key: "deletedAt", Value: 1}},
+			Options: options.Index().SetName("deletedAt").SetSparse(true),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}},
+			Options: options.Index().SetName("status"),
+		},
+		{
+			// Мультиключевой индекс: каждая метка задачи - отдельный ключ
+			Keys:    bson.D{{Key: "owner", Value: 1}, {Key: "tags", Value: 1}},
+			Options: options.Index().SetName("owner_tags"),
+		},
+		{
+			Keys:    bson.D{{Key: "priority", Value: 1}},
+			Options: options.Index().SetName("priority"),
+		},
+		{
+			Keys:    bson.D{{Key: "activeAt", Value: 1}},
+			Options: options.Index().SetName("activeAt"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create task indexes: %v", err)
+	}
+
+	_, err = m.DedupeListTitles(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.listCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "owner", Value: 1}, {Key: "title", Value: 1}},
+		Options: options.Index().SetName("owner_title_unique").SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create list indexes: %v", err)
+	}
+
+	_, err = m.commentCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "owner", Value: 1}, {Key: "taskId", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("owner_task"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create comment indexes: %v", err)
+	}
+
+	_, err = m.attachmentCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "owner", Value: 1}, {Key: "taskId", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("owner_task"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create attachment indexes: %v", err)
+	}
+
+	// Версия задачи попадает в историю один раз, даже если изменения записываются параллельно
+	_, err = m.historyCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "owner", Value: 1}, {Key: "taskId", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetName("owner_task_version_unique").SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create history indexes: %v", err)
+	}
+
+	_, err = m.memberCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "workspace", Value: 1}, {Key: "user", Value: 1}},
+			Options: options.Index().SetName("workspace_user_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user", Value: 1}},
+			Options: options.Index().SetName("user"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create member indexes: %v", err)
+	}
+
+	// Просроченные приглашения MongoDB удаляет сама
+	_, err = m.invitationCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetName("expiresAt_ttl").SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create invitation indexes: %v", err)
+	}
+
+	err = m.checkDuplicateUsernames(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.userCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "username", Value: 1}},
+		Options: options.Index().SetName("username_unique").SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user indexes: %v", err)
+	}
+
+	log.Printf("ensure indexes")
+
+	return nil
+}
+
+func (m *MongoDB) dropIndexIfExists(ctx context.Context, collection *mongo.Collection, name string) error {
+	names, err := collection.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list indexes: %v", err)
+	}
+
+	for _, spec := range names {
+		if spec.Name == name {
+			_, err = collection.Indexes().DropOne(ctx, name)
+			if err != nil {
+				return fmt.Errorf("failed to drop index %s: %v", name, err)
+			}
+			log.Printf("drop index %s", name)
+		}
+	}
+
+	return nil
+}
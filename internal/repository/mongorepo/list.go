@@ -0,0 +1,237 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"time"
+)
+
+// liveList - условие на список, который не удаляется прямо сейчас
+var liveList = bson.M{"$exists": false}
+
+func (m *MongoDB) CreateList(ctx context.Context, l *entity.List) (*entity.List, error) {
+	result, err := m.listCollection.InsertOne(ctx, l)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, custom_error.ErrDuplicateList
+		}
+		return nil, fmt.Errorf("failed to create list: %v", err)
+	}
+
+	l.ID = result.InsertedID.(primitive.ObjectID)
+
+	log.Printf("create list")
+
+	return l, nil
+}
+
+func (m *MongoDB) GetLists(ctx context.Context, owner primitive.ObjectID) ([]entity.List, error) {
+	var lists []entity.List
+
+	cursor, err := m.listCollection.Find(ctx, bson.M{"owner": owner, "deletingAt": liveList}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve lists. error: %v", err)
+	}
+	defer func() {
+		err = cursor.Close(ctx)
+	}()
+
+	err = cursor.All(ctx, &lists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode lists. error: %v", err)
+	}
+
+	log.Printf("get lists")
+
+	return lists, nil
+}
+
+func (m *MongoDB) GetListByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.List, error) {
+	var list entity.List
+
+	err := m.listCollection.FindOne(ctx, bson.M{"_id": id, "owner": owner, "deletingAt": liveList}).Decode(&list)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custom_error.ErrListNotFound
+		}
+		return nil, fmt.Errorf("failed to get list by ID: %v", err)
+	}
+
+	log.Printf("get list")
+
+	return &list, nil
+}
+
+func (m *MongoDB) UpdateList(ctx context.Context, l *entity.List) error {
+	result, err := m.listCollection.UpdateOne(ctx,
+		bson.M{"_id": l.ID, "owner": l.Owner, "deletingAt": liveList},
+		bson.M{"$set": bson.M{"title": l.Title}},
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return custom_error.ErrDuplicateList
+		}
+		return fmt.Errorf("failed to update list. error: %v", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return custom_error.ErrListNotFound
+	}
+
+	log.Printf("update list")
+
+	return nil
+}
+
+// DeleteList сначала помечает список полем deletingAt: помеченный список не читается
+// и не удаляется вторым запросом. Вместе с задачами корзины удаляются их комментарии и вложения.
+// Задачи, попавшие в список после проверки, переносятся во «Входящие» до удаления списка.
+// Если перенести их не удалось, список остается, а вложения уже очищенной корзины
+// и перенесенные задачи возвращаются вместе с ошибкой
+func (m *MongoDB) DeleteList(ctx context.Context, owner, id primitive.ObjectID) ([]entity.Attachment, []entity.TaskRevision, error) {
+	result, err := m.listCollection.UpdateOne(ctx,
+		bson.M{"_id": id, "owner": owner, "deletingAt": liveList},
+		bson.M{"$set": bson.M{"deletingAt": time.Now().UTC()}},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to mark list for deletion. error: %v", err)
+	}
+	if result.MatchedCount == 0 {
+		return nil, nil, custom_error.ErrListNotFound
+	}
+
+	purged, err := m.purgeMarkedList(ctx, owner, id)
+	if err != nil {
+		m.unmarkList(ctx, id)
+		return nil, nil, err
+	}
+
+	err = m.deletePurgedComments(ctx, purged)
+	if err != nil {
+		m.unmarkList(ctx, id)
+		return nil, nil, err
+	}
+
+	attachments, err := m.deletePurgedAttachments(ctx, purged)
+	if err != nil {
+		m.unmarkList(ctx, id)
+		return nil, nil, err
+	}
+
+	// Задача могла попасть в список между проверкой и удалением списка
+	moved, err := m.moveStrayTasks(ctx, owner, id)
+	if err != nil {
+		m.unmarkList(ctx, id)
+		return attachments, moved, err
+	}
+
+	_, err = m.listCollection.DeleteOne(ctx, bson.M{"_id": id, "owner": owner})
+	if err != nil {
+		m.unmarkList(ctx, id)
+		return attachments, moved, fmt.Errorf("failed to delete list. error: %v", err)
+	}
+
+	log.Printf("delete list")
+
+	return attachments, moved, nil
+}
+
+// purgeMarkedList удаляет задачи помеченного списка из корзины, если в нем нет задач
+// вне корзины, и возвращает ID удаленных задач
+func (m *MongoDB) purgeMarkedList(ctx context.Context, owner, id primitive.ObjectID) ([]primitive.ObjectID, error) {
+	count, err := m.taskCollection.CountDocuments(ctx, bson.M{"owner": owner, "listId": id, "deletedAt": liveTask})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count list tasks. error: %v", err)
+	}
+	if count > 0 {
+		return nil, custom_error.ErrListNotEmpty
+	}
+
+	filter := bson.M{"owner": owner, "listId": id, "deletedAt": trashedTask}
+	trashed, err := m.findTaskIDs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	filter["_id"] = bson.M{"$in": trashed}
+	_, err = m.taskCollection.DeleteMany(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete list tasks from trash. error: %v", err)
+	}
+
+	// Задача, восстановленная между запросами, остается, и ее комментарии тоже
+	kept, err := m.findTaskIDs(ctx, bson.M{"_id": bson.M{"$in": trashed}})
+	if err != nil {
+		return nil, err
+	}
+
+	return excludeIDs(trashed, kept), nil
+}
+
+// moveStrayTasks переносит задачи списка id во «Входящие» по одной поверх прочитанной версии,
+// как MoveTask, и возвращает их до и после переноса. Задача, измененная между чтением
+// и записью, читается заново. Название, занятое во «Входящих», возвращает custom_error.ErrDuplicateTask
+// и уже перенесенные задачи
+func (m *MongoDB) moveStrayTasks(ctx context.Context, owner, id primitive.ObjectID) ([]entity.TaskRevision, error) {
+	var moved []entity.TaskRevision
+	for {
+		var before entity.Tasks
+		err := m.taskCollection.FindOne(ctx, bson.M{"owner": owner, "listId": id}).Decode(&before)
+		if err == mongo.ErrNoDocuments {
+			return moved, nil
+		}
+		if err != nil {
+			return moved, fmt.Errorf("failed to find list tasks. error: %v", err)
+		}
+
+		var after entity.Tasks
+		err = m.taskCollection.FindOneAndUpdate(ctx,
+			bson.M{"_id": before.ID, "owner": owner, "listId": id, "version": before.Version},
+			bson.M{"$unset": bson.M{"listId": ""}, "$inc": bson.M{"version": 1}}, returnUpdated,
+		).Decode(&after)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return moved, custom_error.ErrDuplicateTask
+			}
+			return moved, fmt.Errorf("failed to move list tasks. error: %v", err)
+		}
+
+		moved = append(moved, entity.TaskRevision{Before: before, After: after})
+	}
+}
+
+// unmarkList снимает пометку удаления, если список удалить не удалось
+func (m *MongoDB) unmarkList(ctx context.Context, id primitive.ObjectID) {
+	_, err := m.listCollection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$unset": bson.M{"deletingAt": ""}})
+	if err != nil {
+		log.Printf("unmark list %s err: %s", id.Hex(), err.Error())
+	}
+}
+
+func (m *MongoDB) MoveTask(ctx context.Context, owner, id primitive.ObjectID, listID *primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	update := bson.M{"$inc": bson.M{"version": 1}}
+	if listID != nil {
+		update["$set"] = bson.M{"listId": *listID}
+	} else {
+		update["$unset"] = bson.M{"listId": ""}
+	}
+
+	task, err := m.updateTask(ctx, owner, id, version, update, "move task")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("move task")
+
+	return task, nil
+}
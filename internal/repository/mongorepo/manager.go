@@ -7,10 +7,30 @@ import (
 
 type MongoDB struct {
 	taskCollection *mongo.Collection
+	listCollection *mongo.Collection
+	userCollection *mongo.Collection
+
+	commentCollection    *mongo.Collection
+	attachmentCollection *mongo.Collection
+	historyCollection    *mongo.Collection
+
+	workspaceCollection  *mongo.Collection
+	memberCollection     *mongo.Collection
+	invitationCollection *mongo.Collection
 }
 
 func New(db *mongo.Database, collections config.Collections) *MongoDB {
 	return &MongoDB{
 		taskCollection: db.Collection(collections.Task),
+		listCollection: db.Collection(collections.List),
+		userCollection: db.Collection(collections.User),
+
+		commentCollection:    db.Collection(collections.Comment),
+		attachmentCollection: db.Collection(collections.Attachment),
+		historyCollection:    db.Collection(collections.History),
+
+		workspaceCollection:  db.Collection(collections.Workspace),
+		memberCollection:     db.Collection(collections.Member),
+		invitationCollection: db.Collection(collections.Invitation),
 	}
 }
@@ -0,0 +1,186 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// Migrate приводит задачи, сохраненные прежними версиями сервиса, к текущей схеме:
+// без этого выборки по дате, статусу и приоритету, поиск и проверка версии их не видят.
+// Вызывается при запуске до EnsureIndexes, каждый шаг при повторном запуске ничего не меняет.
+// Префикс выходного дня здесь не убирается: задача могла получить такое название
+// уже после перехода на флаг weekend, поэтому это делает только cmd/migrate
+func (m *MongoDB) Migrate(ctx context.Context) error {
+	for _, migrate := range []func(context.Context) (int64, error){
+		m.MigrateActiveAtToDate,
+		m.MigrateActiveStatus,
+		m.MigrateTaskVersion,
+		m.MigrateSearchTerms,
+		m.MigrateTaskPriority,
+	} {
+		_, err := migrate(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrateActiveAtToDate переводит activeAt, сохраненный строкой "2006-01-02",
+// в BSON date. Повторный запуск ничего не меняет
+func (m *MongoDB) MigrateActiveAtToDate(ctx context.Context) (int64, error) {
+	filter := bson.M{"activeAt": bson.M{"$type": "string"}}
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"activeAt": bson.M{"$dateFromString": bson.M{
+				"dateString": "$activeAt",
+				"format":     "%Y-%m-%d",
+				"timezone":   "UTC",
+			}},
+		}},
+	}
+
+	result, err := m.taskCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate activeAt: %v", err)
+	}
+
+	log.Printf("migrate activeAt: %d tasks", result.ModifiedCount)
+
+	return result.ModifiedCount, nil
+}
+
+// MigrateActiveStatus переводит задачи со старым статусом "active" в статус todo.
+// Повторный запуск ничего не меняет
+func (m *MongoDB) MigrateActiveStatus(ctx context.Context) (int64, error) {
+	filter := bson.M{"status": "active"}
+	update := bson.M{"$set": bson.M{"status": entity.StatusTodo}}
+
+	result, err := m.taskCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate status: %v", err)
+	}
+
+	log.Printf("migrate status: %d tasks", result.ModifiedCount)
+
+	return result.ModifiedCount, nil
+}
+
+// MigrateWeekendPrefix убирает префикс выходного дня из сохраненных названий
+// и выставляет задачам флаг weekend. Если у владельца уже есть задача
+// с названием без префикса, задача пропускается
+func (m *MongoDB) MigrateWeekendPrefix(ctx context.Context, prefix string) (int64, error) {
+	filter := bson.M{"title": bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}}
+
+	cursor, err := m.taskCollection.Find(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find prefixed tasks: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	var migrated int64
+	for cursor.Next(ctx) {
+		var task entity.Tasks
+		if err = cursor.Decode(&task); err != nil {
+			return migrated, fmt.Errorf("failed to decode task: %v", err)
+		}
+
+		title := strings.TrimPrefix(task.Title, prefix)
+		update := bson.M{"$set": bson.M{"title": title, "titleTerms": searchPrefixes(title), "weekend": true}}
+
+		_, err = m.taskCollection.UpdateByID(ctx, task.ID, update)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				log.Printf("migrate weekend prefix: task %s skipped, title taken", task.ID.Hex())
+				continue
+			}
+			return migrated, fmt.Errorf("failed to migrate task %s: %v", task.ID.Hex(), err)
+		}
+		migrated++
+	}
+
+	log.Printf("migrate weekend prefix: %d tasks", migrated)
+
+	return migrated, cursor.Err()
+}
+
+// MigrateTaskVersion выставляет версию 1 задачам, созданным до появления версий.
+// Повторный запуск ничего не меняет
+func (m *MongoDB) MigrateTaskVersion(ctx context.Context) (int64, error) {
+	filter := bson.M{"version": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"version": 1}}
+
+	result, err := m.taskCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate version: %v", err)
+	}
+
+	log.Printf("migrate version: %d tasks", result.ModifiedCount)
+
+	return result.ModifiedCount, nil
+}
+
+// MigrateSearchTerms заполняет поля поиска задачам, созданным до появления поиска,
+// и пересчитывает их у задач, сохраненных до ограничения длины начал слов.
+// Повторный запуск ничего не меняет
+func (m *MongoDB) MigrateSearchTerms(ctx context.Context) (int64, error) {
+	tooLong := bson.M{"$regex": fmt.Sprintf("^.{%d}", maxSearchPrefix+1)}
+	filter := bson.M{"$or": bson.A{
+		bson.M{"titleTerms": bson.M{"$exists": false}},
+		bson.M{"descriptionTerms": bson.M{"$exists": false}},
+		bson.M{"titleTerms": tooLong},
+		bson.M{"descriptionTerms": tooLong},
+	}}
+
+	cursor, err := m.taskCollection.Find(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find tasks without search terms: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	var migrated int64
+	for cursor.Next(ctx) {
+		var task entity.Tasks
+		if err = cursor.Decode(&task); err != nil {
+			return migrated, fmt.Errorf("failed to decode task: %v", err)
+		}
+
+		update := bson.M{"$set": bson.M{
+			"titleTerms":       searchPrefixes(task.Title),
+			"descriptionTerms": searchPrefixes(task.Description),
+		}}
+
+		_, err = m.taskCollection.UpdateByID(ctx, task.ID, update)
+		if err != nil {
+			return migrated, fmt.Errorf("failed to migrate task %s: %v", task.ID.Hex(), err)
+		}
+		migrated++
+	}
+
+	log.Printf("migrate search terms: %d tasks", migrated)
+
+	return migrated, cursor.Err()
+}
+
+// MigrateTaskPriority выставляет приоритет medium задачам, созданным до появления
+// приоритетов, чтобы они находились фильтром и сортировались. Повторный запуск ничего не меняет
+func (m *MongoDB) MigrateTaskPriority(ctx context.Context) (int64, error) {
+	filter := bson.M{"priority": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"priority": entity.PriorityMedium}}
+
+	result, err := m.taskCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate priority: %v", err)
+	}
+
+	log.Printf("migrate priority: %d tasks", result.ModifiedCount)
+
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,69 @@
+package mongorepo
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+func (m *MongoDB) SetRecurrence(ctx context.Context, owner, id primitive.ObjectID, rule string, version int64) (*entity.Tasks, error) {
+	update := bson.M{"$set": bson.M{"recurrence": rule}, "$inc": bson.M{"version": 1}}
+	if rule == "" {
+		update = bson.M{"$unset": bson.M{"recurrence": ""}, "$inc": bson.M{"version": 1}}
+	}
+
+	task, err := m.updateTask(ctx, owner, id, version, update, "set recurrence")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("set recurrence")
+
+	return task, nil
+}
+
+// CompleteOccurrence выполняется без транзакции: если следующее повторение не создалось,
+// завершение откатывается, чтобы серия не оборвалась
+func (m *MongoDB) CompleteOccurrence(ctx context.Context, task *entity.Tasks, version int64, next *entity.Tasks) (*entity.Tasks, error) {
+	update := bson.M{
+		"$set":   bson.M{"status": entity.StatusDone, "seriesId": task.Series(), "occurrence": task.ActiveAt},
+		"$unset": bson.M{"recurrence": ""},
+		"$inc":   bson.M{"version": 1},
+	}
+
+	completed, err := m.updateTask(ctx, task.Owner, task.ID, version, update, "complete occurrence")
+	if err != nil {
+		return nil, err
+	}
+
+	if next == nil {
+		log.Printf("complete occurrence, series ended")
+		return completed, nil
+	}
+
+	_, err = m.CreateTask(ctx, next)
+	if err != nil {
+		unset := bson.M{"occurrence": ""}
+		if task.SeriesID == nil {
+			unset["seriesId"] = ""
+		}
+		revert := bson.M{
+			"$set":   bson.M{"status": task.Status, "recurrence": task.Recurrence},
+			"$unset": unset,
+			"$inc":   bson.M{"version": 1},
+		}
+
+		_, revertErr := m.taskCollection.UpdateOne(ctx, bson.M{"_id": task.ID, "owner": task.Owner}, revert)
+		if revertErr != nil {
+			log.Printf("failed to revert occurrence %s: %v", task.ID.Hex(), revertErr)
+		}
+
+		return nil, err
+	}
+
+	log.Printf("complete occurrence")
+
+	return completed, nil
+}
@@ -0,0 +1,92 @@
+package mongorepo
+
+import (
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"strings"
+)
+
+// taskDocument - задача в коллекции вместе с полями для поиска
+type taskDocument struct {
+	entity.Tasks `bson:",inline"`
+	// TitleTerms и DescriptionTerms - слова названия и описания и их начала: текстовый
+	// индекс MongoDB совпадает только с целыми словами, а поиск ищет и по началу слова
+	TitleTerms       []string `bson:"titleTerms"`
+	DescriptionTerms []string `bson:"descriptionTerms"`
+}
+
+func newTaskDocument(t *entity.Tasks) taskDocument {
+	return taskDocument{
+		Tasks:            *t,
+		TitleTerms:       searchPrefixes(t.Title),
+		DescriptionTerms: searchPrefixes(t.Description),
+	}
+}
+
+const (
+	// maxSearchPrefix - длина начала слова в рунах: более длинные слова и слова поиска
+	// обрезаются до нее, иначе одно длинное слово дало бы тысячи длинных начал
+	maxSearchPrefix = 20
+	// maxSearchPrefixes - сколько начал слов сохраняется для одного поля. Слова целиком
+	// сохраняются всегда, поэтому по ним находится и задача с самым длинным описанием
+	maxSearchPrefixes = 1000
+)
+
+// searchPrefixes возвращает все слова текста, обрезанные до maxSearchPrefix,
+// и их начала, пока их не больше maxSearchPrefixes
+func searchPrefixes(text string) []string {
+	words := entity.SearchTerms(text)
+
+	prefixes := make([]string, 0, len(words))
+	seen := make(map[string]struct{}, len(words))
+	add := func(prefix string) {
+		if _, ok := seen[prefix]; ok {
+			return
+		}
+		seen[prefix] = struct{}{}
+		prefixes = append(prefixes, prefix)
+	}
+
+	for i, word := range words {
+		words[i] = searchTerm(word)
+		add(words[i])
+	}
+	for _, word := range words {
+		runes := []rune(word)
+		for i := 1; i < len(runes) && len(prefixes) < maxSearchPrefixes; i++ {
+			add(string(runes[:i]))
+		}
+	}
+	return prefixes
+}
+
+// searchTerm обрезает слово до maxSearchPrefix рун
+func searchTerm(word string) string {
+	runes := []rune(word)
+	if len(runes) > maxSearchPrefix {
+		return string(runes[:maxSearchPrefix])
+	}
+	return word
+}
+
+// searchFilter отбирает задачи, в которых каждое слово поиска - начало какого-то слова
+// названия или описания. $text нужен для ранжирования: целые слова весят больше
+// начал слов, название - больше описания
+func searchFilter(search []string) bson.M {
+	words := make([]string, 0, len(search))
+	terms := make(bson.A, 0, len(search))
+	for _, term := range search {
+		// Сохранены только начала длинных слов, поэтому и слово поиска обрезается
+		term = searchTerm(term)
+		words = append(words, term)
+		terms = append(terms, bson.M{"$or": bson.A{
+			bson.M{"titleTerms": term},
+			bson.M{"descriptionTerms": term},
+		}})
+	}
+
+	return bson.M{
+		"$text": bson.M{"$search": strings.Join(words, " ")},
+		"$and":  terms,
+	}
+}
@@ -0,0 +1,113 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+func (m *MongoDB) GetTags(ctx context.Context, owner primitive.ObjectID) ([]entity.TagStat, error) {
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{"owner": owner, "deletedAt": liveTask, "tags": bson.M{"$exists": true}}},
+		bson.M{"$unwind": "$tags"},
+		bson.M{"$group": bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}},
+		bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+	}
+
+	cursor, err := m.taskCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags. error: %v", err)
+	}
+
+	var tags []entity.TagStat
+	if err = cursor.All(ctx, &tags); err != nil {
+		return nil, fmt.Errorf("failed to decode tags. error: %v", err)
+	}
+
+	log.Printf("get tags")
+
+	return tags, nil
+}
+
+// RenameTag меняет метку у всех задач владельца, включая корзину, одним UpdateMany:
+// каждая задача меняется целиком или не меняется вовсе. Задачи до и после изменения
+// читаются запросом до и после записи. from == to только проверяет, что метка есть
+func (m *MongoDB) RenameTag(ctx context.Context, owner primitive.ObjectID, from, to string) ([]entity.TaskRevision, error) {
+	before, err := m.findTasks(ctx, bson.M{"owner": owner, "tags": from})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(before) == 0 {
+		return nil, custom_error.ErrTagNotFound
+	}
+
+	if from == to {
+		return nil, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(before))
+	for i, task := range before {
+		ids[i] = task.ID
+	}
+
+	// Если to уже есть у задачи, from убирается, иначе заменяется на месте
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"tags": bson.M{"$cond": bson.A{
+				bson.M{"$in": bson.A{to, "$tags"}},
+				bson.M{"$filter": bson.M{"input": "$tags", "cond": bson.M{"$ne": bson.A{"$$this", from}}}},
+				bson.M{"$map": bson.M{"input": "$tags", "in": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$$this", from}}, to, "$$this"}}}},
+			}},
+			"version": bson.M{"$add": bson.A{"$version", 1}},
+		}},
+	}
+
+	_, err = m.taskCollection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}, "owner": owner, "tags": from}, update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename tag. error: %v", err)
+	}
+
+	after, err := m.findTasks(ctx, bson.M{"_id": bson.M{"$in": ids}, "owner": owner})
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make(map[primitive.ObjectID]entity.Tasks, len(before))
+	for _, task := range before {
+		previous[task.ID] = task
+	}
+
+	renamed := make([]entity.TaskRevision, 0, len(after))
+	for _, task := range after {
+		// Задача, удаленная из корзины или потерявшая метку до записи, не изменилась
+		if task.Version == previous[task.ID].Version {
+			continue
+		}
+		renamed = append(renamed, entity.TaskRevision{Before: previous[task.ID], After: task})
+	}
+
+	log.Printf("rename tag: %d tasks", len(renamed))
+
+	return renamed, nil
+}
+
+// findTasks возвращает задачи по filter, включая корзину
+func (m *MongoDB) findTasks(ctx context.Context, filter bson.M) ([]entity.Tasks, error) {
+	cursor, err := m.taskCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tasks. error: %v", err)
+	}
+
+	var tasks []entity.Tasks
+	err = cursor.All(ctx, &tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tasks. error: %v", err)
+	}
+
+	return tasks, nil
+}
@@ -10,24 +10,23 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"log"
+	"time"
 )
 
-func (m *MongoDB) CreateTask(ctx context.Context, t *entity.Tasks) (*entity.Tasks, error) {
-	existingTaskFilter := bson.M{
-		"title": t.Title,
-	}
-
-	existingTaskCount, err := m.taskCollection.CountDocuments(ctx, existingTaskFilter)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check task uniqueness: %v", err)
-	}
-
-	if existingTaskCount > 0 {
-		return nil, custom_error.ErrDuplicateTask
-	}
+// sortFields сопоставляет поля сортировки API с полями документа
+var sortFields = map[string]string{
+	"activeAt":  "activeAt",
+	"title":     "title",
+	"priority":  "priority",
+	"createdAt": "_id",
+}
 
-	result, err := m.taskCollection.InsertOne(ctx, t)
+func (m *MongoDB) CreateTask(ctx context.Context, t *entity.Tasks) (*entity.Tasks, error) {
+	result, err := m.taskCollection.InsertOne(ctx, newTaskDocument(t))
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, custom_error.ErrDuplicateTask
+		}
 		return nil, fmt.Errorf("failed to create task: %v", err)
 	}
 
@@ -38,76 +37,162 @@ func (m *MongoDB) CreateTask(ctx context.Context, t *entity.Tasks) (*entity.Task
 	return t, nil
 }
 
-func (m *MongoDB) UpdateTask(ctx context.Context, t *entity.Tasks, id primitive.ObjectID) error {
-	filter := bson.M{"_id": id}
+func (m *MongoDB) UpdateTask(ctx context.Context, t *entity.Tasks, id primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	set := bson.M{
+		"title":            t.Title,
+		"titleTerms":       searchPrefixes(t.Title),
+		"description":      t.Description,
+		"descriptionTerms": searchPrefixes(t.Description),
+		"activeAt":         t.ActiveAt,
+		"status":           t.Status,
+		"priority":         t.Priority,
+		"autoComplete":     t.AutoComplete,
+		"weekend":          t.Weekend,
+	}
 
-	var task entity.Tasks
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	unset := bson.M{}
+	if t.Due != nil {
+		set["due"] = t.Due
+	} else {
+		unset["due"] = ""
+	}
+	if len(t.Tags) > 0 {
+		set["tags"] = t.Tags
+	} else {
+		unset["tags"] = ""
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
 
-	err := m.taskCollection.FindOne(ctx, filter).Decode(&task)
+	task, err := m.updateTask(ctx, t.Owner, id, version, update, "update task")
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return custom_error.ErrTaskNotFound
-		}
-		return fmt.Errorf("failed to get task by ID: %v", err)
+		return nil, err
 	}
 
-	if t.Title != task.Title {
-		existingTaskFilter := bson.M{
-			"title": t.Title,
-		}
+	log.Printf("update task")
 
-		existingTaskCount, err := m.taskCollection.CountDocuments(ctx, existingTaskFilter)
-		if err != nil {
-			return fmt.Errorf("failed to check task uniqueness: %v", err)
-		}
+	return task, nil
+}
 
-		if existingTaskCount > 0 {
-			return custom_error.ErrDuplicateTask
+func (m *MongoDB) PatchTask(ctx context.Context, owner, id primitive.ObjectID, patch *entity.TaskPatch, version int64) (*entity.Tasks, error) {
+	set := bson.M{}
+	if patch.Title != nil {
+		set["title"] = *patch.Title
+		set["titleTerms"] = searchPrefixes(*patch.Title)
+	}
+	if patch.ActiveAt != nil {
+		set["activeAt"] = *patch.ActiveAt
+	}
+	if patch.Weekend != nil {
+		set["weekend"] = *patch.Weekend
+	}
+	if patch.Description != nil {
+		set["description"] = *patch.Description
+		set["descriptionTerms"] = searchPrefixes(*patch.Description)
+	}
+	if patch.Priority != nil {
+		set["priority"] = *patch.Priority
+	}
+	if patch.AutoComplete != nil {
+		set["autoComplete"] = *patch.AutoComplete
+	}
+	unset := bson.M{}
+	if patch.Due != nil && !patch.ClearDue {
+		set["due"] = *patch.Due
+	}
+	if patch.ClearDue {
+		unset["due"] = ""
+	}
+	if patch.Tags != nil {
+		if len(*patch.Tags) > 0 {
+			set["tags"] = *patch.Tags
+		} else {
+			unset["tags"] = ""
 		}
 	}
 
-	update := bson.M{
-		"$set": bson.M{"title": t.Title, "activeAt": t.ActiveAt, "status": t.Status},
+	filter := versionFilter(owner, id, version)
+
+	update := bson.M{"$inc": bson.M{"version": 1}}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	if patch.IsEmpty() {
+		// Пустое изменение только проверяет, что задача существует, и возвращает ее
+		var task entity.Tasks
+		err := m.taskCollection.FindOne(ctx, filter).Decode(&task)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, m.missError(ctx, owner, id)
+			}
+			return nil, fmt.Errorf("failed to get task by ID: %v", err)
+		}
+		return &task, nil
 	}
 
-	_, err = m.taskCollection.UpdateOne(ctx, filter, update)
+	task, err := m.updateTask(ctx, owner, id, version, update, "patch task")
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return custom_error.ErrTaskNotFound
-		}
-		return fmt.Errorf("failed to update task. error: %v", err)
+		return nil, err
 	}
 
-	log.Printf("update task")
+	log.Printf("patch task")
 
-	return nil
+	return task, nil
 }
 
-func (m *MongoDB) UpdateTaskStatus(ctx context.Context, id primitive.ObjectID, status string) error {
-	filter := bson.M{"_id": id}
-	update := bson.M{"$set": bson.M{"status": status}}
+func (m *MongoDB) UpdateTaskStatus(ctx context.Context, owner, id primitive.ObjectID, status string, version int64) (*entity.Tasks, error) {
+	update := bson.M{"$set": bson.M{"status": status}, "$inc": bson.M{"version": 1}}
 
-	_, err := m.taskCollection.UpdateOne(ctx, filter, update)
+	return m.updateTask(ctx, owner, id, version, update, "update task status")
+}
+
+func (m *MongoDB) GetAllTasks(ctx context.Context, f *entity.TaskFilter) ([]entity.Tasks, int64, error) {
+	var tasks []entity.Tasks
+
+	filter := tasksFilter(f)
+
+	total, err := m.taskCollection.CountDocuments(ctx, filter)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return err
-		}
-		return fmt.Errorf("failed to update task status: %v", err)
+		return nil, 0, fmt.Errorf("failed to count tasks. error: %v", err)
 	}
 
-	return nil
-}
+	field := sortFields[f.SortField]
+	order := 1
+	if f.SortDesc {
+		order = -1
+	}
 
-func (m *MongoDB) GetAllTasks(ctx context.Context, status string) ([]entity.Tasks, error) {
-	var tasks []entity.Tasks
+	if !f.After.IsZero() {
+		pageFilter, err := m.afterCursorFilter(ctx, f.Owner, field, f.SortDesc, f.After)
+		if err != nil {
+			return nil, 0, err
+		}
+		filter = bson.M{"$and": bson.A{filter, pageFilter}}
+	}
+
+	sort := bson.D{{Key: field, Value: order}}
+	if field != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: order})
+	}
 
-	filter := bson.M{"status": status}
+	findOptions := options.Find().SetSort(sort).SetLimit(f.Limit)
 
-	findOptions := options.Find()
+	if len(f.Search) > 0 {
+		score := bson.M{"$meta": "textScore"}
+		findOptions.SetProjection(bson.M{"score": score}).
+			SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+			SetSkip(f.Offset)
+	}
 
 	cursor, err := m.taskCollection.Find(ctx, filter, findOptions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve tasks. error: %v", err)
+		return nil, 0, fmt.Errorf("failed to retrieve tasks. error: %v", err)
 	}
 	defer func() {
 		err = cursor.Close(ctx)
@@ -116,23 +201,166 @@ func (m *MongoDB) GetAllTasks(ctx context.Context, status string) ([]entity.Task
 	for cursor.Next(ctx) {
 		var task entity.Tasks
 		if err = cursor.Decode(&task); err != nil {
-			return nil, fmt.Errorf("failed to decode task. error: %v", err)
+			return nil, 0, fmt.Errorf("failed to decode task. error: %v", err)
 		}
 
 		tasks = append(tasks, task)
 	}
 
 	if err = cursor.Err(); err != nil {
-		return nil, fmt.Errorf("cursor error. error: %v", err)
+		return nil, 0, fmt.Errorf("cursor error. error: %v", err)
 	}
 
 	log.Printf("get all tasks")
 
-	return tasks, err
+	return tasks, total, err
+}
+
+// deletePurgedComments удаляет комментарии задач ids, удаленных насовсем
+func (m *MongoDB) deletePurgedComments(ctx context.Context, ids []primitive.ObjectID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := m.commentCollection.DeleteMany(ctx, bson.M{"taskId": bson.M{"$in": ids}})
+	if err != nil {
+		return fmt.Errorf("failed to delete comments of purged tasks. error: %v", err)
+	}
+
+	return nil
+}
+
+// deletePurgedAttachments удаляет вложения задач ids, удаленных насовсем, и возвращает их
+func (m *MongoDB) deletePurgedAttachments(ctx context.Context, ids []primitive.ObjectID) ([]entity.Attachment, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return m.deleteAttachments(ctx, bson.M{"taskId": bson.M{"$in": ids}})
+}
+
+// liveTask и trashedTask - условия на задачи не из корзины и из корзины. Сравнение с null
+// совпадает и с отсутствующим полем, поэтому задачи, сохраненные до появления корзины
+// или с deletedAt: null, считаются обычными, как и в индексе уникальности названий
+var (
+	liveTask    = bson.M{"$eq": nil}
+	trashedTask = bson.M{"$ne": nil}
+)
+
+// versionFilter выбирает задачу владельца не из корзины с версией version, 0 - с любой версией
+func versionFilter(owner, id primitive.ObjectID, version int64) bson.M {
+	filter := bson.M{"_id": id, "owner": owner, "deletedAt": liveTask}
+	if version > 0 {
+		filter["version"] = version
+	}
+	return filter
+}
+
+// returnUpdated - FindOneAndUpdate возвращает документ после изменения
+var returnUpdated = options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+// updateTask применяет update к задаче владельца не из корзины с версией version
+// и возвращает задачу после изменения. action попадает в текст ошибки
+func (m *MongoDB) updateTask(ctx context.Context, owner, id primitive.ObjectID, version int64, update bson.M, action string) (*entity.Tasks, error) {
+	var task entity.Tasks
+
+	err := m.taskCollection.FindOneAndUpdate(ctx, versionFilter(owner, id, version), update, returnUpdated).Decode(&task)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, m.missError(ctx, owner, id)
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, custom_error.ErrDuplicateTask
+		}
+		return nil, fmt.Errorf("failed to %s. error: %v", action, err)
+	}
+
+	return &task, nil
+}
+
+// missError объясняет, почему изменение не затронуло задачу:
+// задачи нет у владельца или ее версия уже изменилась
+func (m *MongoDB) missError(ctx context.Context, owner, id primitive.ObjectID) error {
+	count, err := m.taskCollection.CountDocuments(ctx, bson.M{"_id": id, "owner": owner, "deletedAt": liveTask})
+	if err != nil {
+		return fmt.Errorf("failed to get task by ID: %v", err)
+	}
+	if count == 0 {
+		return custom_error.ErrTaskNotFound
+	}
+	return custom_error.ErrVersionMismatch
+}
+
+// tasksFilter строит фильтр Mongo по корзине, статусам, приоритетам, меткам, поиску и диапазону activeAt
+func tasksFilter(f *entity.TaskFilter) bson.M {
+	filter := bson.M{"owner": f.Owner, "deletedAt": liveTask}
+	if f.Deleted {
+		filter["deletedAt"] = trashedTask
+	}
+	if !f.ListID.IsZero() {
+		filter["listId"] = f.ListID
+	}
+	if len(f.Statuses) > 0 {
+		filter["status"] = bson.M{"$in": f.Statuses}
+	}
+	if len(f.Priorities) > 0 {
+		filter["priority"] = bson.M{"$in": f.Priorities}
+	}
+	if len(f.Tags) > 0 {
+		op := "$in"
+		if f.TagsAll {
+			op = "$all"
+		}
+		filter["tags"] = bson.M{op: f.Tags}
+	}
+	if len(f.Search) > 0 {
+		for key, value := range searchFilter(f.Search) {
+			filter[key] = value
+		}
+	}
+
+	activeAt := bson.M{}
+	if !f.ActiveFrom.IsZero() {
+		activeAt["$gte"] = f.ActiveFrom
+	}
+	if !f.ActiveTo.IsZero() {
+		activeAt["$lte"] = f.ActiveTo
+	}
+	if len(activeAt) > 0 {
+		filter["activeAt"] = activeAt
+	}
+
+	return filter
+}
+
+// afterCursorFilter строит keyset-условие для задач, идущих после задачи с ID after
+func (m *MongoDB) afterCursorFilter(ctx context.Context, owner primitive.ObjectID, field string, desc bool, after primitive.ObjectID) (bson.M, error) {
+	op := "$gt"
+	if desc {
+		op = "$lt"
+	}
+
+	if field == "_id" {
+		return bson.M{"_id": bson.M{op: after}}, nil
+	}
+
+	var last bson.M
+	err := m.taskCollection.FindOne(ctx, bson.M{"_id": after, "owner": owner}).Decode(&last)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custom_error.ErrInvalidCursor
+		}
+		return nil, fmt.Errorf("failed to get cursor task: %v", err)
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{op: last[field]}},
+		bson.M{field: last[field], "_id": bson.M{op: after}},
+	}}, nil
 }
 
-func (m *MongoDB) GetTaskByID(ctx context.Context, id primitive.ObjectID) (*entity.Tasks, error) {
-	filter := bson.M{"_id": id}
+func (m *MongoDB) GetTaskByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error) {
+	filter := bson.M{"_id": id, "owner": owner, "deletedAt": liveTask}
 
 	var task entity.Tasks
 
@@ -149,18 +377,117 @@ func (m *MongoDB) GetTaskByID(ctx context.Context, id primitive.ObjectID) (*enti
 	return &task, nil
 }
 
-func (m *MongoDB) DeleteTask(ctx context.Context, id primitive.ObjectID) error {
-	filter := bson.M{"_id": id}
+func (m *MongoDB) DeleteTask(ctx context.Context, owner, id primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	update := bson.M{"$set": bson.M{"deletedAt": time.Now().UTC()}, "$inc": bson.M{"version": 1}}
 
-	_, err := m.taskCollection.DeleteOne(ctx, filter)
+	task, err := m.updateTask(ctx, owner, id, version, update, "delete task")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("delete task")
+
+	return task, nil
+}
+
+func (m *MongoDB) RestoreTask(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error) {
+	filter := bson.M{"_id": id, "owner": owner, "deletedAt": trashedTask}
+	update := bson.M{"$unset": bson.M{"deletedAt": ""}, "$inc": bson.M{"version": 1}}
+
+	var task entity.Tasks
+	err := m.taskCollection.FindOneAndUpdate(ctx, filter, update, returnUpdated).Decode(&task)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return custom_error.ErrTaskNotFound
+			return nil, custom_error.ErrTaskNotFound
 		}
-		return fmt.Errorf("failed to delete task. error: %v", err)
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, custom_error.ErrDuplicateTask
+		}
+		return nil, fmt.Errorf("failed to restore task. error: %v", err)
 	}
 
-	log.Printf("delete task")
+	log.Printf("restore task")
 
-	return nil
+	return &task, nil
+}
+
+// PurgeDeletedTasks удаляет задачи корзины вместе с их комментариями и вложениями
+func (m *MongoDB) PurgeDeletedTasks(ctx context.Context, before time.Time) (int64, []entity.Attachment, error) {
+	filter := bson.M{"deletedAt": bson.M{"$lt": before}}
+
+	ids, err := m.findTaskIDs(ctx, filter)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(ids) == 0 {
+		return 0, nil, nil
+	}
+
+	filter["_id"] = bson.M{"$in": ids}
+	result, err := m.taskCollection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to purge tasks. error: %v", err)
+	}
+
+	// Задача, восстановленная между запросами, остается вместе со своими комментариями и вложениями
+	kept, err := m.findTaskIDs(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return result.DeletedCount, nil, err
+	}
+	purged := excludeIDs(ids, kept)
+
+	err = m.deletePurgedComments(ctx, purged)
+	if err != nil {
+		return result.DeletedCount, nil, err
+	}
+
+	attachments, err := m.deletePurgedAttachments(ctx, purged)
+	if err != nil {
+		return result.DeletedCount, nil, err
+	}
+
+	log.Printf("purge tasks: %d", result.DeletedCount)
+
+	return result.DeletedCount, attachments, nil
+}
+
+// findTaskIDs возвращает ID задач, найденных по filter
+func (m *MongoDB) findTaskIDs(ctx context.Context, filter bson.M) ([]primitive.ObjectID, error) {
+	var found []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+
+	cursor, err := m.taskCollection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tasks. error: %v", err)
+	}
+
+	err = cursor.All(ctx, &found)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tasks. error: %v", err)
+	}
+
+	ids := make([]primitive.ObjectID, len(found))
+	for i, task := range found {
+		ids[i] = task.ID
+	}
+
+	return ids, nil
+}
+
+// excludeIDs возвращает ids без kept
+func excludeIDs(ids, kept []primitive.ObjectID) []primitive.ObjectID {
+	skip := make(map[primitive.ObjectID]bool, len(kept))
+	for _, id := range kept {
+		skip[id] = true
+	}
+
+	var result []primitive.ObjectID
+	for _, id := range ids {
+		if !skip[id] {
+			result = append(result, id)
+		}
+	}
+
+	return result
 }
@@ -0,0 +1,62 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"log"
+)
+
+func (m *MongoDB) CreateUser(ctx context.Context, u *entity.User) (*entity.User, error) {
+	result, err := m.userCollection.InsertOne(ctx, u)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, custom_error.ErrDuplicateUser
+		}
+		return nil, fmt.Errorf("failed to create user: %v", err)
+	}
+
+	u.ID = result.InsertedID.(primitive.ObjectID)
+
+	log.Printf("create user")
+
+	return u, nil
+}
+
+func (m *MongoDB) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	filter := bson.M{"username": username}
+
+	var user entity.User
+
+	err := m.userCollection.FindOne(ctx, filter).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custom_error.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by username: %v", err)
+	}
+
+	log.Printf("get user")
+
+	return &user, nil
+}
+
+func (m *MongoDB) GetUserByID(ctx context.Context, id primitive.ObjectID) (*entity.User, error) {
+	var user entity.User
+
+	err := m.userCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custom_error.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by ID: %v", err)
+	}
+
+	log.Printf("get user")
+
+	return &user, nil
+}
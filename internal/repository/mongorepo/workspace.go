@@ -0,0 +1,291 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+)
+
+func (m *MongoDB) CreateWorkspace(ctx context.Context, w *entity.Workspace, owner *entity.Member) (*entity.Workspace, error) {
+	if w.ID.IsZero() {
+		w.ID = primitive.NewObjectID()
+	}
+
+	_, err := m.workspaceCollection.InsertOne(ctx, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %v", err)
+	}
+
+	owner.Workspace = w.ID
+	_, err = m.memberCollection.InsertOne(ctx, owner)
+	if err != nil {
+		// Пространство без владельца недоступно никому, поэтому удаляется
+		_, _ = m.workspaceCollection.DeleteOne(ctx, bson.M{"_id": w.ID})
+		return nil, fmt.Errorf("failed to add workspace owner: %v", err)
+	}
+
+	log.Printf("create workspace")
+
+	return w, nil
+}
+
+func (m *MongoDB) GetWorkspaces(ctx context.Context, userID primitive.ObjectID) ([]entity.Workspace, error) {
+	var members []entity.Member
+
+	cursor, err := m.memberCollection.Find(ctx, bson.M{"user": userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve memberships. error: %v", err)
+	}
+	defer func() {
+		err = cursor.Close(ctx)
+	}()
+
+	err = cursor.All(ctx, &members)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode memberships. error: %v", err)
+	}
+
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	roles := make(map[primitive.ObjectID]entity.Role, len(members))
+	ids := make([]primitive.ObjectID, 0, len(members))
+	for _, member := range members {
+		roles[member.Workspace] = member.Role
+		ids = append(ids, member.Workspace)
+	}
+
+	var workspaces []entity.Workspace
+
+	wsCursor, err := m.workspaceCollection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve workspaces. error: %v", err)
+	}
+	defer func() {
+		err = wsCursor.Close(ctx)
+	}()
+
+	err = wsCursor.All(ctx, &workspaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode workspaces. error: %v", err)
+	}
+
+	for i := range workspaces {
+		workspaces[i].Role = roles[workspaces[i].ID]
+	}
+
+	log.Printf("get workspaces")
+
+	return workspaces, nil
+}
+
+func (m *MongoDB) GetWorkspaceByID(ctx context.Context, id primitive.ObjectID) (*entity.Workspace, error) {
+	var workspace entity.Workspace
+
+	err := m.workspaceCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&workspace)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custom_error.ErrWorkspaceNotFound
+		}
+		return nil, fmt.Errorf("failed to get workspace by ID: %v", err)
+	}
+
+	log.Printf("get workspace")
+
+	return &workspace, nil
+}
+
+func (m *MongoDB) UpdateWorkspace(ctx context.Context, w *entity.Workspace) error {
+	result, err := m.workspaceCollection.UpdateOne(ctx, bson.M{"_id": w.ID}, bson.M{"$set": bson.M{"title": w.Title}})
+	if err != nil {
+		return fmt.Errorf("failed to update workspace. error: %v", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return custom_error.ErrWorkspaceNotFound
+	}
+
+	log.Printf("update workspace")
+
+	return nil
+}
+
+// DeleteWorkspace сначала удаляет пространство, чтобы участники сразу потеряли
+// к нему доступ, а потом его данные
+func (m *MongoDB) DeleteWorkspace(ctx context.Context, id primitive.ObjectID) error {
+	result, err := m.workspaceCollection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace. error: %v", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return custom_error.ErrWorkspaceNotFound
+	}
+
+	_, err = m.memberCollection.DeleteMany(ctx, bson.M{"workspace": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace members. error: %v", err)
+	}
+
+	_, err = m.invitationCollection.DeleteMany(ctx, bson.M{"workspace": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace invitations. error: %v", err)
+	}
+
+	_, err = m.taskCollection.DeleteMany(ctx, bson.M{"owner": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace tasks. error: %v", err)
+	}
+
+	_, err = m.commentCollection.DeleteMany(ctx, bson.M{"owner": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace comments. error: %v", err)
+	}
+
+	_, err = m.historyCollection.DeleteMany(ctx, bson.M{"owner": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace task history. error: %v", err)
+	}
+
+	_, err = m.listCollection.DeleteMany(ctx, bson.M{"owner": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace lists. error: %v", err)
+	}
+
+	log.Printf("delete workspace")
+
+	return nil
+}
+
+func (m *MongoDB) GetMember(ctx context.Context, workspace, userID primitive.ObjectID) (*entity.Member, error) {
+	var member entity.Member
+
+	err := m.memberCollection.FindOne(ctx, bson.M{"workspace": workspace, "user": userID}).Decode(&member)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custom_error.ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to get member: %v", err)
+	}
+
+	log.Printf("get member")
+
+	return &member, nil
+}
+
+func (m *MongoDB) GetMembers(ctx context.Context, workspace primitive.ObjectID) ([]entity.Member, error) {
+	var members []entity.Member
+
+	cursor, err := m.memberCollection.Find(ctx, bson.M{"workspace": workspace}, options.Find().SetSort(bson.D{{Key: "username", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve members. error: %v", err)
+	}
+	defer func() {
+		err = cursor.Close(ctx)
+	}()
+
+	err = cursor.All(ctx, &members)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode members. error: %v", err)
+	}
+
+	log.Printf("get members")
+
+	return members, nil
+}
+
+func (m *MongoDB) AddMember(ctx context.Context, member *entity.Member) error {
+	_, err := m.memberCollection.InsertOne(ctx, member)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return custom_error.ErrAlreadyMember
+		}
+		return fmt.Errorf("failed to add member: %v", err)
+	}
+
+	log.Printf("add member")
+
+	return nil
+}
+
+func (m *MongoDB) UpdateMemberRole(ctx context.Context, workspace, userID primitive.ObjectID, role entity.Role) error {
+	result, err := m.memberCollection.UpdateOne(ctx,
+		bson.M{"workspace": workspace, "user": userID},
+		bson.M{"$set": bson.M{"role": role}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update member role. error: %v", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return custom_error.ErrMemberNotFound
+	}
+
+	log.Printf("update member role")
+
+	return nil
+}
+
+func (m *MongoDB) RemoveMember(ctx context.Context, workspace, userID primitive.ObjectID) error {
+	result, err := m.memberCollection.DeleteOne(ctx, bson.M{"workspace": workspace, "user": userID})
+	if err != nil {
+		return fmt.Errorf("failed to remove member. error: %v", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return custom_error.ErrMemberNotFound
+	}
+
+	log.Printf("remove member")
+
+	return nil
+}
+
+func (m *MongoDB) CreateInvitation(ctx context.Context, i *entity.Invitation) error {
+	_, err := m.invitationCollection.InsertOne(ctx, i)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %v", err)
+	}
+
+	log.Printf("create invitation")
+
+	return nil
+}
+
+func (m *MongoDB) GetInvitation(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	var invitation entity.Invitation
+
+	err := m.invitationCollection.FindOne(ctx, bson.M{"_id": tokenHash}).Decode(&invitation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custom_error.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to get invitation: %v", err)
+	}
+
+	log.Printf("get invitation")
+
+	return &invitation, nil
+}
+
+func (m *MongoDB) DeleteInvitation(ctx context.Context, tokenHash string) error {
+	result, err := m.invitationCollection.DeleteOne(ctx, bson.M{"_id": tokenHash})
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation. error: %v", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return custom_error.ErrInvitationNotFound
+	}
+
+	log.Printf("delete invitation")
+
+	return nil
+}
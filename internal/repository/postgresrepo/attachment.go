@@ -0,0 +1,170 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+const attachmentColumns = `id, owner, task_id, uploaded_by, file_name, content_type, size, checksum, key, created_at`
+
+func (p *Postgres) CreateAttachment(ctx context.Context, a *entity.Attachment) error {
+	if a.ID.IsZero() {
+		a.ID = primitive.NewObjectID()
+	}
+
+	_, err := p.db.ExecContext(ctx,
+		`INSERT INTO task_attachments (`+attachmentColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		a.ID.Hex(), a.Owner.Hex(), a.TaskID.Hex(), a.UploadedBy.Hex(), a.FileName, a.ContentType, a.Size, a.Checksum, a.Key, a.CreatedAt,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return custom_error.ErrTaskNotFound
+		}
+		return fmt.Errorf("failed to create attachment: %v", err)
+	}
+
+	log.Printf("create attachment")
+
+	return nil
+}
+
+func (p *Postgres) GetAttachments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Attachment, error) {
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT `+attachmentColumns+` FROM task_attachments WHERE owner = $1 AND task_id = $2 ORDER BY id`,
+		owner.Hex(), taskID.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve attachments. error: %v", err)
+	}
+
+	attachments, err := scanAttachments(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("get attachments")
+
+	return attachments, nil
+}
+
+func (p *Postgres) GetAttachmentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Attachment, error) {
+	row := p.db.QueryRowContext(ctx,
+		`SELECT `+attachmentColumns+` FROM task_attachments WHERE id = $1 AND owner = $2 AND task_id = $3`,
+		id.Hex(), owner.Hex(), taskID.Hex(),
+	)
+
+	attachment, err := scanAttachment(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, custom_error.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+
+	log.Printf("get attachment")
+
+	return &attachment, nil
+}
+
+func (p *Postgres) DeleteAttachment(ctx context.Context, owner, taskID, id primitive.ObjectID) error {
+	result, err := p.db.ExecContext(ctx,
+		`DELETE FROM task_attachments WHERE id = $1 AND owner = $2 AND task_id = $3`, id.Hex(), owner.Hex(), taskID.Hex(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment. error: %v", err)
+	}
+
+	err = checkRows(result, custom_error.ErrAttachmentNotFound)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("delete attachment")
+
+	return nil
+}
+
+func (p *Postgres) DeleteOwnerAttachments(ctx context.Context, owner primitive.ObjectID) ([]entity.Attachment, error) {
+	rows, err := p.db.QueryContext(ctx,
+		`DELETE FROM task_attachments WHERE owner = $1 RETURNING `+attachmentColumns, owner.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete owner attachments. error: %v", err)
+	}
+
+	attachments, err := scanAttachments(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("delete owner attachments")
+
+	return attachments, nil
+}
+
+// scanAttachments читает и закрывает rows
+func scanAttachments(rows *sql.Rows) ([]entity.Attachment, error) {
+	defer rows.Close()
+
+	var attachments []entity.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error. error: %v", err)
+	}
+
+	return attachments, nil
+}
+
+func scanAttachment(row scanner) (entity.Attachment, error) {
+	var (
+		attachment                    entity.Attachment
+		id, owner, taskID, uploadedBy string
+	)
+
+	err := row.Scan(&id, &owner, &taskID, &uploadedBy, &attachment.FileName, &attachment.ContentType,
+		&attachment.Size, &attachment.Checksum, &attachment.Key, &attachment.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return attachment, err
+		}
+		return attachment, fmt.Errorf("failed to scan attachment. error: %v", err)
+	}
+
+	attachment.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return attachment, fmt.Errorf("failed to parse attachment id: %v", err)
+	}
+
+	attachment.Owner, err = primitive.ObjectIDFromHex(owner)
+	if err != nil {
+		return attachment, fmt.Errorf("failed to parse attachment owner: %v", err)
+	}
+
+	attachment.TaskID, err = primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return attachment, fmt.Errorf("failed to parse attachment task: %v", err)
+	}
+
+	attachment.UploadedBy, err = primitive.ObjectIDFromHex(uploadedBy)
+	if err != nil {
+		return attachment, fmt.Errorf("failed to parse attachment uploader: %v", err)
+	}
+
+	attachment.CreatedAt = attachment.CreatedAt.UTC()
+
+	return attachment, nil
+}
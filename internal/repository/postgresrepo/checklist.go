@@ -0,0 +1,31 @@
+package postgresrepo
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+func (p *Postgres) UpdateChecklist(ctx context.Context, owner, id primitive.ObjectID, checklist []entity.ChecklistItem, status string, version int64) (*entity.Tasks, error) {
+	value, err := checklistColumn(checklist)
+	if err != nil {
+		return nil, err
+	}
+
+	row := p.db.QueryRowContext(ctx,
+		`UPDATE tasks SET checklist = $1, status = $2, version = version + 1
+		WHERE id = $3 AND owner = $4 AND deleted_at IS NULL AND ($5 = 0 OR version = $5)
+		RETURNING `+taskColumns,
+		value, status, id.Hex(), owner.Hex(), version,
+	)
+
+	task, err := p.updatedTask(ctx, row, owner, id, "update checklist")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("update checklist")
+
+	return task, nil
+}
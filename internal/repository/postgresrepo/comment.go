@@ -0,0 +1,198 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+const commentColumns = `id, owner, task_id, author, author_name, body, created_at, updated_at, edits`
+
+func (p *Postgres) CreateComment(ctx context.Context, c *entity.Comment) (*entity.Comment, error) {
+	if c.ID.IsZero() {
+		c.ID = primitive.NewObjectID()
+	}
+
+	edits, err := editsColumn(c.Edits)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.db.ExecContext(ctx,
+		`INSERT INTO task_comments (`+commentColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		c.ID.Hex(), c.Owner.Hex(), c.TaskID.Hex(), c.Author.Hex(), c.AuthorName, c.Body, c.CreatedAt, c.UpdatedAt, edits,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, custom_error.ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to create comment: %v", err)
+	}
+
+	log.Printf("create comment")
+
+	return c, nil
+}
+
+func (p *Postgres) GetComments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Comment, error) {
+	var comments []entity.Comment
+
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT `+commentColumns+` FROM task_comments WHERE owner = $1 AND task_id = $2 ORDER BY id`,
+		owner.Hex(), taskID.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve comments. error: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error. error: %v", err)
+	}
+
+	log.Printf("get comments")
+
+	return comments, nil
+}
+
+func (p *Postgres) GetCommentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Comment, error) {
+	row := p.db.QueryRowContext(ctx,
+		`SELECT `+commentColumns+` FROM task_comments WHERE id = $1 AND owner = $2 AND task_id = $3`,
+		id.Hex(), owner.Hex(), taskID.Hex(),
+	)
+
+	comment, err := scanComment(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, custom_error.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	log.Printf("get comment")
+
+	return &comment, nil
+}
+
+func (p *Postgres) UpdateComment(ctx context.Context, c *entity.Comment) error {
+	edits, err := editsColumn(c.Edits)
+	if err != nil {
+		return err
+	}
+
+	result, err := p.db.ExecContext(ctx,
+		`UPDATE task_comments SET body = $1, updated_at = $2, edits = $3 WHERE id = $4 AND owner = $5 AND task_id = $6`,
+		c.Body, c.UpdatedAt, edits, c.ID.Hex(), c.Owner.Hex(), c.TaskID.Hex(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update comment. error: %v", err)
+	}
+
+	err = checkRows(result, custom_error.ErrCommentNotFound)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("update comment")
+
+	return nil
+}
+
+func (p *Postgres) DeleteComment(ctx context.Context, owner, taskID, id primitive.ObjectID) error {
+	result, err := p.db.ExecContext(ctx,
+		`DELETE FROM task_comments WHERE id = $1 AND owner = $2 AND task_id = $3`, id.Hex(), owner.Hex(), taskID.Hex(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment. error: %v", err)
+	}
+
+	err = checkRows(result, custom_error.ErrCommentNotFound)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("delete comment")
+
+	return nil
+}
+
+// editsColumn возвращает значение колонки edits, пустой массив без правок
+func editsColumn(edits []entity.CommentEdit) (string, error) {
+	if edits == nil {
+		edits = []entity.CommentEdit{}
+	}
+
+	value, err := json.Marshal(edits)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode comment edits: %v", err)
+	}
+	return string(value), nil
+}
+
+func scanComment(row scanner) (entity.Comment, error) {
+	var (
+		comment                   entity.Comment
+		id, owner, taskID, author string
+		updatedAt                 sql.NullTime
+		edits                     []byte
+	)
+
+	err := row.Scan(&id, &owner, &taskID, &author, &comment.AuthorName, &comment.Body, &comment.CreatedAt, &updatedAt, &edits)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return comment, err
+		}
+		return comment, fmt.Errorf("failed to scan comment. error: %v", err)
+	}
+
+	comment.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return comment, fmt.Errorf("failed to parse comment id: %v", err)
+	}
+
+	comment.Owner, err = primitive.ObjectIDFromHex(owner)
+	if err != nil {
+		return comment, fmt.Errorf("failed to parse comment owner: %v", err)
+	}
+
+	comment.TaskID, err = primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return comment, fmt.Errorf("failed to parse comment task: %v", err)
+	}
+
+	comment.Author, err = primitive.ObjectIDFromHex(author)
+	if err != nil {
+		return comment, fmt.Errorf("failed to parse comment author: %v", err)
+	}
+
+	comment.CreatedAt = comment.CreatedAt.UTC()
+	if updatedAt.Valid {
+		updated := updatedAt.Time.UTC()
+		comment.UpdatedAt = &updated
+	}
+
+	err = json.Unmarshal(edits, &comment.Edits)
+	if err != nil {
+		return comment, fmt.Errorf("failed to parse comment edits: %v", err)
+	}
+	if len(comment.Edits) == 0 {
+		comment.Edits = nil
+	}
+
+	return comment, nil
+}
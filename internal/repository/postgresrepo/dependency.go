@@ -0,0 +1,76 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+func (p *Postgres) GetTasksByIDs(ctx context.Context, owner primitive.ObjectID, ids []primitive.ObjectID) ([]entity.Tasks, error) {
+	var tasks []entity.Tasks
+
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT `+taskColumns+` FROM tasks WHERE owner = $1 AND id = ANY($2) AND deleted_at IS NULL`,
+		owner.Hex(), dependenciesColumn(ids),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tasks by ids. error: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error. error: %v", err)
+	}
+
+	log.Printf("get tasks by ids")
+
+	return tasks, nil
+}
+
+func (p *Postgres) AddDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	row := p.db.QueryRowContext(ctx,
+		`UPDATE tasks SET dependencies = CASE WHEN $1 = ANY(dependencies) THEN dependencies ELSE array_append(dependencies, $1) END,
+			version = version + 1
+		WHERE id = $2 AND owner = $3 AND deleted_at IS NULL AND ($4 = 0 OR version = $4)
+		RETURNING `+taskColumns,
+		blocker.Hex(), id.Hex(), owner.Hex(), version,
+	)
+
+	task, err := p.updatedTask(ctx, row, owner, id, "add dependency")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("add dependency")
+
+	return task, nil
+}
+
+func (p *Postgres) RemoveDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	row := p.db.QueryRowContext(ctx,
+		`UPDATE tasks SET dependencies = array_remove(dependencies, $1), version = version + 1
+		WHERE id = $2 AND owner = $3 AND deleted_at IS NULL AND ($4 = 0 OR version = $4)
+		RETURNING `+taskColumns,
+		blocker.Hex(), id.Hex(), owner.Hex(), version,
+	)
+
+	task, err := p.updatedTask(ctx, row, owner, id, "remove dependency")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("remove dependency")
+
+	return task, nil
+}
@@ -0,0 +1,150 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+const historyColumns = `id, owner, task_id, version, action, actor, at, reverted_to, changes, snapshot`
+
+func (p *Postgres) CreateHistoryEntry(ctx context.Context, e *entity.HistoryEntry) error {
+	if e.ID.IsZero() {
+		e.ID = primitive.NewObjectID()
+	}
+
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode history changes: %v", err)
+	}
+
+	snapshot, err := json.Marshal(e.Snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode history snapshot: %v", err)
+	}
+
+	_, err = p.db.ExecContext(ctx,
+		`INSERT INTO task_history (`+historyColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		e.ID.Hex(), e.Owner.Hex(), e.TaskID.Hex(), e.Version, e.Action, e.Actor.Hex(), e.At, e.RevertedTo,
+		string(changes), string(snapshot),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return custom_error.ErrVersionMismatch
+		}
+		return fmt.Errorf("failed to create history entry: %v", err)
+	}
+
+	log.Printf("create history entry")
+
+	return nil
+}
+
+func (p *Postgres) GetHistory(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.HistoryEntry, error) {
+	var history []entity.HistoryEntry
+
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT `+historyColumns+` FROM task_history WHERE owner = $1 AND task_id = $2 ORDER BY version`,
+		owner.Hex(), taskID.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve history. error: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry, err := scanHistoryEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error. error: %v", err)
+	}
+
+	log.Printf("get history")
+
+	return history, nil
+}
+
+func (p *Postgres) GetHistoryEntry(ctx context.Context, owner, taskID primitive.ObjectID, version int64) (*entity.HistoryEntry, error) {
+	row := p.db.QueryRowContext(ctx,
+		`SELECT `+historyColumns+` FROM task_history WHERE owner = $1 AND task_id = $2 AND version = $3`,
+		owner.Hex(), taskID.Hex(), version,
+	)
+
+	entry, err := scanHistoryEntry(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, custom_error.ErrHistoryNotFound
+		}
+		return nil, err
+	}
+
+	log.Printf("get history entry")
+
+	return &entry, nil
+}
+
+func scanHistoryEntry(row scanner) (entity.HistoryEntry, error) {
+	var (
+		entry                    entity.HistoryEntry
+		id, owner, taskID, actor string
+		revertedTo               sql.NullInt64
+		changes, snapshot        []byte
+	)
+
+	err := row.Scan(&id, &owner, &taskID, &entry.Version, &entry.Action, &actor, &entry.At, &revertedTo, &changes, &snapshot)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entry, err
+		}
+		return entry, fmt.Errorf("failed to scan history entry. error: %v", err)
+	}
+
+	entry.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entry, fmt.Errorf("failed to parse history entry id: %v", err)
+	}
+
+	entry.Owner, err = primitive.ObjectIDFromHex(owner)
+	if err != nil {
+		return entry, fmt.Errorf("failed to parse history entry owner: %v", err)
+	}
+
+	entry.TaskID, err = primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return entry, fmt.Errorf("failed to parse history entry task: %v", err)
+	}
+
+	entry.Actor, err = primitive.ObjectIDFromHex(actor)
+	if err != nil {
+		return entry, fmt.Errorf("failed to parse history entry actor: %v", err)
+	}
+
+	entry.At = entry.At.UTC()
+	if revertedTo.Valid {
+		entry.RevertedTo = &revertedTo.Int64
+	}
+
+	err = json.Unmarshal(changes, &entry.Changes)
+	if err != nil {
+		return entry, fmt.Errorf("failed to decode history changes: %v", err)
+	}
+
+	err = json.Unmarshal(snapshot, &entry.Snapshot)
+	if err != nil {
+		return entry, fmt.Errorf("failed to decode history snapshot: %v", err)
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,217 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+func (p *Postgres) CreateList(ctx context.Context, l *entity.List) (*entity.List, error) {
+	if l.ID.IsZero() {
+		l.ID = primitive.NewObjectID()
+	}
+
+	_, err := p.db.ExecContext(ctx,
+		`INSERT INTO lists (id, owner, title) VALUES ($1, $2, $3)`, l.ID.Hex(), l.Owner.Hex(), l.Title,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, custom_error.ErrDuplicateList
+		}
+		return nil, fmt.Errorf("failed to create list: %v", err)
+	}
+
+	log.Printf("create list")
+
+	return l, nil
+}
+
+func (p *Postgres) GetLists(ctx context.Context, owner primitive.ObjectID) ([]entity.List, error) {
+	var lists []entity.List
+
+	rows, err := p.db.QueryContext(ctx, `SELECT id, owner, title FROM lists WHERE owner = $1 ORDER BY id`, owner.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve lists. error: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		list, err := scanList(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		lists = append(lists, list)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error. error: %v", err)
+	}
+
+	log.Printf("get lists")
+
+	return lists, nil
+}
+
+func (p *Postgres) GetListByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.List, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT id, owner, title FROM lists WHERE id = $1 AND owner = $2`, id.Hex(), owner.Hex())
+
+	list, err := scanList(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, custom_error.ErrListNotFound
+		}
+		return nil, err
+	}
+
+	log.Printf("get list")
+
+	return &list, nil
+}
+
+func (p *Postgres) UpdateList(ctx context.Context, l *entity.List) error {
+	result, err := p.db.ExecContext(ctx,
+		`UPDATE lists SET title = $1 WHERE id = $2 AND owner = $3`, l.Title, l.ID.Hex(), l.Owner.Hex(),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return custom_error.ErrDuplicateList
+		}
+		return fmt.Errorf("failed to update list. error: %v", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %v", err)
+	}
+
+	if affected == 0 {
+		return custom_error.ErrListNotFound
+	}
+
+	log.Printf("update list")
+
+	return nil
+}
+
+// DeleteList проверяет задачи и удаляет список в одной транзакции: строка списка
+// блокируется, поэтому параллельно в него не попадет новая задача и переносить во «Входящие»
+// нечего. Вместе с задачами корзины удаляются их комментарии и вложения, вложения возвращаются
+func (p *Postgres) DeleteList(ctx context.Context, owner, id primitive.ObjectID) ([]entity.Attachment, []entity.TaskRevision, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT true FROM lists WHERE id = $1 AND owner = $2 FOR UPDATE`, id.Hex(), owner.Hex(),
+	).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, custom_error.ErrListNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to lock list: %v", err)
+	}
+
+	var live int64
+	err = tx.QueryRowContext(ctx,
+		`SELECT count(*) FROM tasks WHERE list_id = $1 AND deleted_at IS NULL`, id.Hex(),
+	).Scan(&live)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count list tasks: %v", err)
+	}
+	if live > 0 {
+		return nil, nil, custom_error.ErrListNotEmpty
+	}
+
+	// Вложения удаляются до задач, иначе каскад удалит их без возможности вернуть ключи файлов
+	rows, err := tx.QueryContext(ctx,
+		`DELETE FROM task_attachments WHERE task_id IN (SELECT id FROM tasks WHERE list_id = $1 AND deleted_at IS NOT NULL)
+		RETURNING `+attachmentColumns, id.Hex(),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to delete list attachments: %v", err)
+	}
+	attachments, err := scanAttachments(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM tasks WHERE list_id = $1 AND deleted_at IS NOT NULL`, id.Hex())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to delete list tasks from trash: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM lists WHERE id = $1`, id.Hex())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to delete list: %v", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to commit list deletion: %v", err)
+	}
+
+	log.Printf("delete list")
+
+	return attachments, nil, nil
+}
+
+func (p *Postgres) MoveTask(ctx context.Context, owner, id primitive.ObjectID, listID *primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	var list interface{}
+	if listID != nil {
+		list = listID.Hex()
+	}
+
+	row := p.db.QueryRowContext(ctx,
+		`UPDATE tasks SET list_id = $1, version = version + 1
+		WHERE id = $2 AND owner = $3 AND deleted_at IS NULL AND ($4 = 0 OR version = $4)
+		RETURNING `+taskColumns,
+		list, id.Hex(), owner.Hex(), version,
+	)
+
+	task, err := p.updatedTask(ctx, row, owner, id, "move task")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("move task")
+
+	return task, nil
+}
+
+func scanList(row scanner) (entity.List, error) {
+	var (
+		list      entity.List
+		id, owner string
+	)
+
+	err := row.Scan(&id, &owner, &list.Title)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return list, err
+		}
+		return list, fmt.Errorf("failed to scan list. error: %v", err)
+	}
+
+	list.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return list, fmt.Errorf("failed to parse list id: %v", err)
+	}
+
+	list.Owner, err = primitive.ObjectIDFromHex(owner)
+	if err != nil {
+		return list, fmt.Errorf("failed to parse list owner: %v", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,13 @@
+package postgresrepo
+
+import "database/sql"
+
+type Postgres struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Postgres {
+	return &Postgres{
+		db: db,
+	}
+}
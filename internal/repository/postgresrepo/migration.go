@@ -0,0 +1,87 @@
+package postgresrepo
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.up.sql
+var migrations embed.FS
+
+// Migrate применяет еще не примененные миграции из migrations/ по порядку номеров.
+// Примененные версии хранятся в таблице schema_migrations
+func (p *Postgres) Migrate(ctx context.Context) error {
+	_, err := p.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT PRIMARY KEY)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %v", err)
+	}
+
+	files, err := fs.Glob(migrations, "migrations/*.up.sql")
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %v", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		name := strings.TrimPrefix(file, "migrations/")
+		version, err := strconv.ParseInt(strings.SplitN(name, "_", 2)[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid migration name %s: %v", name, err)
+		}
+
+		err = p.applyMigration(ctx, version, file)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Postgres) applyMigration(ctx context.Context, version int64, file string) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %d: %v", version, err)
+	}
+	defer tx.Rollback()
+
+	// Блокировка не дает двум экземплярам приложения применить миграцию одновременно
+	_, err = tx.ExecContext(ctx, `LOCK TABLE schema_migrations IN EXCLUSIVE MODE`)
+	if err != nil {
+		return fmt.Errorf("failed to lock schema_migrations: %v", err)
+	}
+
+	var applied bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version).Scan(&applied)
+	if err != nil {
+		return fmt.Errorf("failed to check migration %d: %v", version, err)
+	}
+	if applied {
+		return nil
+	}
+
+	query, err := migrations.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read migration %d: %v", version, err)
+	}
+
+	_, err = tx.ExecContext(ctx, string(query))
+	if err != nil {
+		return fmt.Errorf("failed to apply migration %d: %v", version, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, version)
+	if err != nil {
+		return fmt.Errorf("failed to record migration %d: %v", version, err)
+	}
+
+	log.Printf("apply migration %d", version)
+
+	return tx.Commit()
+}
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS users
+(
+    id       CHAR(24) PRIMARY KEY,
+    username TEXT NOT NULL,
+    password TEXT NOT NULL,
+    CONSTRAINT users_username_unique UNIQUE (username)
+);
+
+CREATE TABLE IF NOT EXISTS tasks
+(
+    id        CHAR(24) PRIMARY KEY,
+    owner     CHAR(24) NOT NULL,
+    title     TEXT     NOT NULL,
+    active_at DATE     NOT NULL,
+    status    TEXT     NOT NULL,
+    CONSTRAINT tasks_owner_title_unique UNIQUE (owner, title)
+);
+
+CREATE INDEX IF NOT EXISTS tasks_status_idx ON tasks (status);
+CREATE INDEX IF NOT EXISTS tasks_active_at_idx ON tasks (active_at);
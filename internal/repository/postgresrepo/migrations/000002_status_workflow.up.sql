@@ -0,0 +1,2 @@
+-- Старый статус "active" соответствует статусу todo
+UPDATE tasks SET status = 'todo' WHERE status = 'active';
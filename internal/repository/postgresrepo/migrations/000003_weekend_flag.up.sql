@@ -0,0 +1,12 @@
+ALTER TABLE tasks ADD COLUMN weekend BOOLEAN NOT NULL DEFAULT false;
+
+-- Раньше префикс выходного дня сохранялся в названии. Если у владельца уже есть
+-- задача с названием без префикса, название остается как есть
+UPDATE tasks t
+SET weekend = true,
+    title = substr(t.title, length('ВЫХОДНОЙ - ') + 1)
+WHERE t.title LIKE 'ВЫХОДНОЙ - %'
+  AND NOT EXISTS (
+    SELECT 1 FROM tasks d
+    WHERE d.owner = t.owner AND d.title = substr(t.title, length('ВЫХОДНОЙ - ') + 1)
+  );
@@ -0,0 +1,2 @@
+-- Версия задачи для оптимистичной блокировки, отдается клиенту как ETag
+ALTER TABLE tasks ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,7 @@
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Названия уникальны только среди задач не из корзины
+ALTER TABLE tasks DROP CONSTRAINT tasks_owner_title_unique;
+CREATE UNIQUE INDEX tasks_owner_title_unique ON tasks (owner, title) WHERE deleted_at IS NULL;
+
+CREATE INDEX tasks_deleted_at ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
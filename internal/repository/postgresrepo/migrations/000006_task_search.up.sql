@@ -0,0 +1,5 @@
+-- Конфигурация simple: слова не приводятся к основе, стоп-слова не выбрасываются
+ALTER TABLE tasks ADD COLUMN search TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('simple', title)) STORED;
+
+CREATE INDEX tasks_search ON tasks USING GIN (search);
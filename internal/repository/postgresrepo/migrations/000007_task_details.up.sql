@@ -0,0 +1,16 @@
+-- Приоритет хранится числом: -1 low, 0 medium, 1 high, 2 urgent
+ALTER TABLE tasks
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN priority SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN due_time TEXT,
+    ADD COLUMN due_time_zone TEXT;
+
+CREATE INDEX tasks_owner_priority ON tasks (owner, priority);
+
+-- Поиск по названию и описанию, совпадение в названии весит больше
+ALTER TABLE tasks DROP COLUMN search;
+ALTER TABLE tasks ADD COLUMN search TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', title), 'A') || setweight(to_tsvector('simple', description), 'B')
+) STORED;
+
+CREATE INDEX tasks_search ON tasks USING GIN (search);
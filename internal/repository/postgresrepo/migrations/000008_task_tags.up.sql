@@ -0,0 +1,4 @@
+-- Метки задачи, GIN индекс обслуживает поиск задач по меткам (@> и &&)
+ALTER TABLE tasks ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX tasks_tags ON tasks USING GIN (tags);
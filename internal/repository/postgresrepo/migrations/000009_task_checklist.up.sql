@@ -0,0 +1,4 @@
+-- Чек-лист хранится вместе с задачей и всегда меняется целиком
+ALTER TABLE tasks
+    ADD COLUMN checklist JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN auto_complete BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,11 @@
+ALTER TABLE tasks
+    ADD COLUMN recurrence TEXT NOT NULL DEFAULT '',
+    ADD COLUMN series_id CHAR(24),
+    ADD COLUMN occurrence DATE;
+
+-- Завершенные повторения серии отмечены датой occurrence: их названия уникальны
+-- только среди повторений с той же датой и не мешают следующему повторению
+DROP INDEX tasks_owner_title_unique;
+CREATE UNIQUE INDEX tasks_owner_title_unique ON tasks (owner, title) WHERE deleted_at IS NULL AND occurrence IS NULL;
+CREATE UNIQUE INDEX tasks_owner_title_occurrence_unique ON tasks (owner, title, occurrence)
+    WHERE deleted_at IS NULL AND occurrence IS NOT NULL;
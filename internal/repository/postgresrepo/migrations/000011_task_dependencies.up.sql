@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN dependencies TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS lists
+(
+    id    CHAR(24) PRIMARY KEY,
+    owner CHAR(24) NOT NULL,
+    title TEXT     NOT NULL,
+    CONSTRAINT lists_owner_title_unique UNIQUE (owner, title)
+);
+
+ALTER TABLE tasks ADD COLUMN list_id CHAR(24) REFERENCES lists (id);
+
+-- Названия задач уникальны в пределах списка, входящие (list_id IS NULL) - отдельный список
+DROP INDEX tasks_owner_title_unique;
+DROP INDEX tasks_owner_title_occurrence_unique;
+CREATE UNIQUE INDEX tasks_owner_title_unique ON tasks (owner, COALESCE(list_id, ''), title)
+    WHERE deleted_at IS NULL AND occurrence IS NULL;
+CREATE UNIQUE INDEX tasks_owner_title_occurrence_unique ON tasks (owner, COALESCE(list_id, ''), title, occurrence)
+    WHERE deleted_at IS NULL AND occurrence IS NOT NULL;
+CREATE INDEX IF NOT EXISTS tasks_list_id_idx ON tasks (list_id);
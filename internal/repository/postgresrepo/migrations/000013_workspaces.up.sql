@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS workspaces
+(
+    id    CHAR(24) PRIMARY KEY,
+    owner CHAR(24) NOT NULL REFERENCES users (id),
+    title TEXT     NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS workspace_members
+(
+    workspace_id CHAR(24) NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+    user_id      CHAR(24) NOT NULL REFERENCES users (id),
+    username     TEXT     NOT NULL,
+    role         TEXT     NOT NULL,
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS workspace_members_user_id_idx ON workspace_members (user_id);
+
+-- Хранится только хэш токена приглашения
+CREATE TABLE IF NOT EXISTS workspace_invitations
+(
+    token_hash   TEXT PRIMARY KEY,
+    workspace_id CHAR(24)    NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+    role         TEXT        NOT NULL,
+    created_by   CHAR(24)    NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL
+);
+
+-- Задачи и списки пространства хранятся с owner - ID пространства
+ALTER TABLE tasks ADD COLUMN workspace_id CHAR(24) REFERENCES workspaces (id);
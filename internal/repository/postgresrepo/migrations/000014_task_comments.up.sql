@@ -0,0 +1,15 @@
+-- Комментарии удаляются вместе с задачей
+CREATE TABLE IF NOT EXISTS task_comments
+(
+    id          CHAR(24) PRIMARY KEY,
+    owner       CHAR(24)    NOT NULL,
+    task_id     CHAR(24)    NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    author      CHAR(24)    NOT NULL,
+    author_name TEXT        NOT NULL,
+    body        TEXT        NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ,
+    edits       JSONB       NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX IF NOT EXISTS task_comments_task_id_idx ON task_comments (owner, task_id, id);
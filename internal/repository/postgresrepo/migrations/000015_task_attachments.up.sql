@@ -0,0 +1,16 @@
+-- Описания вложений, сами файлы лежат в хранилище файлов под ключом key
+CREATE TABLE IF NOT EXISTS task_attachments
+(
+    id           CHAR(24) PRIMARY KEY,
+    owner        CHAR(24)    NOT NULL,
+    task_id      CHAR(24)    NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    uploaded_by  CHAR(24)    NOT NULL,
+    file_name    TEXT        NOT NULL,
+    content_type TEXT        NOT NULL,
+    size         BIGINT      NOT NULL,
+    checksum     TEXT        NOT NULL,
+    key          TEXT        NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS task_attachments_task_id_idx ON task_attachments (owner, task_id, id);
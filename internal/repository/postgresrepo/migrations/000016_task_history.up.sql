@@ -0,0 +1,16 @@
+-- История не ссылается на tasks: записи остаются после окончательного удаления задачи
+-- и удаляются только вместе с рабочим пространством
+CREATE TABLE IF NOT EXISTS task_history
+(
+    id          CHAR(24) PRIMARY KEY,
+    owner       CHAR(24)    NOT NULL,
+    task_id     CHAR(24)    NOT NULL,
+    version     BIGINT      NOT NULL,
+    action      TEXT        NOT NULL,
+    actor       CHAR(24)    NOT NULL,
+    at          TIMESTAMPTZ NOT NULL,
+    reverted_to BIGINT,
+    changes     JSONB       NOT NULL DEFAULT '[]',
+    snapshot    JSONB       NOT NULL,
+    UNIQUE (owner, task_id, version)
+);
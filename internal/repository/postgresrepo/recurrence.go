@@ -0,0 +1,66 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+func (p *Postgres) SetRecurrence(ctx context.Context, owner, id primitive.ObjectID, rule string, version int64) (*entity.Tasks, error) {
+	row := p.db.QueryRowContext(ctx,
+		`UPDATE tasks SET recurrence = $1, version = version + 1
+		WHERE id = $2 AND owner = $3 AND deleted_at IS NULL AND ($4 = 0 OR version = $4)
+		RETURNING `+taskColumns,
+		rule, id.Hex(), owner.Hex(), version,
+	)
+
+	task, err := p.updatedTask(ctx, row, owner, id, "set recurrence")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("set recurrence")
+
+	return task, nil
+}
+
+// CompleteOccurrence завершает повторение и создает следующее в одной транзакции
+func (p *Postgres) CompleteOccurrence(ctx context.Context, task *entity.Tasks, version int64, next *entity.Tasks) (*entity.Tasks, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	row := tx.QueryRowContext(ctx,
+		`UPDATE tasks SET status = $1, recurrence = '', series_id = $2, occurrence = $3, version = version + 1
+		WHERE id = $4 AND owner = $5 AND deleted_at IS NULL AND ($6 = 0 OR version = $6)
+		RETURNING `+taskColumns,
+		entity.StatusDone, task.Series().Hex(), task.ActiveAt, task.ID.Hex(), task.Owner.Hex(), version,
+	)
+
+	completed, err := p.updatedTask(ctx, row, task.Owner, task.ID, "complete occurrence")
+	if err != nil {
+		return nil, err
+	}
+
+	if next != nil {
+		err = insertTask(ctx, tx, next)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit occurrence: %v", err)
+	}
+
+	log.Printf("complete occurrence")
+
+	return completed, nil
+}
@@ -0,0 +1,130 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+func (p *Postgres) GetTags(ctx context.Context, owner primitive.ObjectID) ([]entity.TagStat, error) {
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT tag, COUNT(*) FROM tasks, unnest(tags) AS tag
+		WHERE owner = $1 AND deleted_at IS NULL
+		GROUP BY tag ORDER BY COUNT(*) DESC, tag COLLATE "C"`, owner.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags. error: %v", err)
+	}
+	defer rows.Close()
+
+	var tags []entity.TagStat
+	for rows.Next() {
+		var tag entity.TagStat
+		if err = rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag. error: %v", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error. error: %v", err)
+	}
+
+	log.Printf("get tags")
+
+	return tags, nil
+}
+
+// RenameTag заменяет from на to в одной транзакции. Если to уже есть у задачи, from просто убирается.
+// Задачи блокируются при чтении, поэтому пары до и после совпадают с тем, что записано.
+// from == to только проверяет, что метка есть
+func (p *Postgres) RenameTag(ctx context.Context, owner primitive.ObjectID, from, to string) ([]entity.TaskRevision, error) {
+	if from == to {
+		var exists bool
+		err := p.db.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM tasks WHERE owner = $1 AND $2 = ANY(tags))`, owner.Hex(), from,
+		).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tag. error: %v", err)
+		}
+		if !exists {
+			return nil, custom_error.ErrTagNotFound
+		}
+		return nil, nil
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	before, err := renamedTasks(tx.QueryContext(ctx,
+		`SELECT `+taskColumns+` FROM tasks WHERE owner = $1 AND $2 = ANY(tags) FOR UPDATE`, owner.Hex(), from,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := renamedTasks(tx.QueryContext(ctx,
+		`UPDATE tasks SET tags = CASE WHEN $3 = ANY(tags) THEN array_remove(tags, $2) ELSE array_replace(tags, $2, $3) END,
+		version = version + 1
+		WHERE owner = $1 AND $2 = ANY(tags)
+		RETURNING `+taskColumns, owner.Hex(), from, to,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(after) == 0 {
+		return nil, custom_error.ErrTagNotFound
+	}
+
+	previous := make(map[primitive.ObjectID]entity.Tasks, len(before))
+	for _, task := range before {
+		previous[task.ID] = task
+	}
+
+	renamed := make([]entity.TaskRevision, 0, len(after))
+	for _, task := range after {
+		renamed = append(renamed, entity.TaskRevision{Before: previous[task.ID], After: task})
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit tag rename: %v", err)
+	}
+
+	log.Printf("rename tag: %d tasks", len(renamed))
+
+	return renamed, nil
+}
+
+// renamedTasks читает задачи, которые вернул запрос переименования тега
+func renamedTasks(rows *sql.Rows, err error) ([]entity.Tasks, error) {
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename tag. error: %v", err)
+	}
+	defer rows.Close()
+
+	var tasks []entity.Tasks
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to rename tag. error: %v", err)
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,602 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+	"strings"
+	"time"
+)
+
+const taskColumns = `id, owner, workspace_id, list_id, title, description, active_at, status, priority, due_time, due_time_zone, weekend, tags, checklist, auto_complete, dependencies, recurrence, series_id, occurrence, version, deleted_at`
+
+// sortColumns сопоставляет поля сортировки API с выражениями SQL.
+// Названия сравниваются побайтово (COLLATE "C"), как в MongoDB
+var sortColumns = map[string]string{
+	"activeAt":  `active_at`,
+	"title":     `title COLLATE "C"`,
+	"priority":  `priority`,
+	"createdAt": `id`,
+}
+
+func (p *Postgres) CreateTask(ctx context.Context, t *entity.Tasks) (*entity.Tasks, error) {
+	err := insertTask(ctx, p.db, t)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("create task")
+
+	return t, nil
+}
+
+// execer - *sql.DB или *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertTask сохраняет новую задачу, пустой ID заменяется новым
+func insertTask(ctx context.Context, db execer, t *entity.Tasks) error {
+	if t.ID.IsZero() {
+		t.ID = primitive.NewObjectID()
+	}
+
+	dueTime, dueTimeZone := dueColumns(t.Due)
+
+	checklist, err := checklistColumn(t.Checklist)
+	if err != nil {
+		return err
+	}
+
+	var workspaceID, listID, seriesID interface{}
+	if t.Workspace != nil {
+		workspaceID = t.Workspace.Hex()
+	}
+	if t.ListID != nil {
+		listID = t.ListID.Hex()
+	}
+	if t.SeriesID != nil {
+		seriesID = t.SeriesID.Hex()
+	}
+
+	_, err = db.ExecContext(ctx,
+		`INSERT INTO tasks (`+taskColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)`,
+		t.ID.Hex(), t.Owner.Hex(), workspaceID, listID, t.Title, t.Description, t.ActiveAt, t.Status, t.Priority, dueTime, dueTimeZone,
+		t.Weekend, tagsColumn(t.Tags), checklist, t.AutoComplete, dependenciesColumn(t.Dependencies), t.Recurrence, seriesID, t.Occurrence,
+		t.Version, t.DeletedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return custom_error.ErrDuplicateTask
+		}
+		return fmt.Errorf("failed to create task: %v", err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) UpdateTask(ctx context.Context, t *entity.Tasks, id primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	dueTime, dueTimeZone := dueColumns(t.Due)
+
+	row := p.db.QueryRowContext(ctx,
+		`UPDATE tasks SET title = $1, description = $2, active_at = $3, status = $4, priority = $5,
+		due_time = $6, due_time_zone = $7, weekend = $8, tags = $9, auto_complete = $10, version = version + 1
+		WHERE id = $11 AND owner = $12 AND deleted_at IS NULL AND ($13 = 0 OR version = $13)
+		RETURNING `+taskColumns,
+		t.Title, t.Description, t.ActiveAt, t.Status, t.Priority, dueTime, dueTimeZone, t.Weekend, tagsColumn(t.Tags),
+		t.AutoComplete, id.Hex(), t.Owner.Hex(), version,
+	)
+
+	task, err := p.updatedTask(ctx, row, t.Owner, id, "update task")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("update task")
+
+	return task, nil
+}
+
+func (p *Postgres) PatchTask(ctx context.Context, owner, id primitive.ObjectID, patch *entity.TaskPatch, version int64) (*entity.Tasks, error) {
+	args := []interface{}{id.Hex(), owner.Hex(), version}
+	set := []string{`version = version + 1`}
+
+	if patch.IsEmpty() {
+		// Пустое изменение только проверяет, что задача существует, и возвращает ее
+		row := p.db.QueryRowContext(ctx,
+			`SELECT `+taskColumns+` FROM tasks WHERE id = $1 AND owner = $2 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)`, args...,
+		)
+		return p.updatedTask(ctx, row, owner, id, "get task by ID")
+	}
+
+	if patch.Title != nil {
+		args = append(args, *patch.Title)
+		set = append(set, fmt.Sprintf(`title = $%d`, len(args)))
+	}
+	if patch.ActiveAt != nil {
+		args = append(args, *patch.ActiveAt)
+		set = append(set, fmt.Sprintf(`active_at = $%d`, len(args)))
+	}
+	if patch.Weekend != nil {
+		args = append(args, *patch.Weekend)
+		set = append(set, fmt.Sprintf(`weekend = $%d`, len(args)))
+	}
+	if patch.Description != nil {
+		args = append(args, *patch.Description)
+		set = append(set, fmt.Sprintf(`description = $%d`, len(args)))
+	}
+	if patch.Priority != nil {
+		args = append(args, *patch.Priority)
+		set = append(set, fmt.Sprintf(`priority = $%d`, len(args)))
+	}
+	if patch.Due != nil || patch.ClearDue {
+		due := patch.Due
+		if patch.ClearDue {
+			due = nil
+		}
+		dueTime, dueTimeZone := dueColumns(due)
+		args = append(args, dueTime, dueTimeZone)
+		set = append(set, fmt.Sprintf(`due_time = $%d, due_time_zone = $%d`, len(args)-1, len(args)))
+	}
+	if patch.Tags != nil {
+		args = append(args, tagsColumn(*patch.Tags))
+		set = append(set, fmt.Sprintf(`tags = $%d`, len(args)))
+	}
+	if patch.AutoComplete != nil {
+		args = append(args, *patch.AutoComplete)
+		set = append(set, fmt.Sprintf(`auto_complete = $%d`, len(args)))
+	}
+
+	row := p.db.QueryRowContext(ctx,
+		`UPDATE tasks SET `+strings.Join(set, `, `)+` WHERE id = $1 AND owner = $2 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)
+		RETURNING `+taskColumns, args...,
+	)
+
+	task, err := p.updatedTask(ctx, row, owner, id, "patch task")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("patch task")
+
+	return task, nil
+}
+
+func (p *Postgres) UpdateTaskStatus(ctx context.Context, owner, id primitive.ObjectID, status string, version int64) (*entity.Tasks, error) {
+	row := p.db.QueryRowContext(ctx,
+		`UPDATE tasks SET status = $1, version = version + 1 WHERE id = $2 AND owner = $3 AND deleted_at IS NULL AND ($4 = 0 OR version = $4)
+		RETURNING `+taskColumns,
+		status, id.Hex(), owner.Hex(), version,
+	)
+
+	return p.updatedTask(ctx, row, owner, id, "update task status")
+}
+
+func (p *Postgres) GetAllTasks(ctx context.Context, f *entity.TaskFilter) ([]entity.Tasks, int64, error) {
+	var tasks []entity.Tasks
+
+	args := []interface{}{f.Owner.Hex()}
+	where := []string{`owner = $1`, `deleted_at IS NULL`}
+	if f.Deleted {
+		where[1] = `deleted_at IS NOT NULL`
+	}
+
+	if !f.ListID.IsZero() {
+		args = append(args, f.ListID.Hex())
+		where = append(where, fmt.Sprintf(`list_id = $%d`, len(args)))
+	}
+
+	if len(f.Statuses) > 0 {
+		args = append(args, pq.Array(f.Statuses))
+		where = append(where, fmt.Sprintf(`status = ANY($%d)`, len(args)))
+	}
+
+	if len(f.Priorities) > 0 {
+		priorities := make([]int64, len(f.Priorities))
+		for i, priority := range f.Priorities {
+			priorities[i] = int64(priority)
+		}
+		args = append(args, pq.Array(priorities))
+		where = append(where, fmt.Sprintf(`priority = ANY($%d)`, len(args)))
+	}
+
+	if len(f.Tags) > 0 {
+		op := `&&`
+		if f.TagsAll {
+			op = `@>`
+		}
+		args = append(args, pq.Array(f.Tags))
+		where = append(where, fmt.Sprintf(`tags %s $%d`, op, len(args)))
+	}
+
+	if !f.ActiveFrom.IsZero() {
+		args = append(args, f.ActiveFrom)
+		where = append(where, fmt.Sprintf(`active_at >= $%d`, len(args)))
+	}
+	if !f.ActiveTo.IsZero() {
+		args = append(args, f.ActiveTo)
+		where = append(where, fmt.Sprintf(`active_at <= $%d`, len(args)))
+	}
+
+	var rank string
+	if len(f.Search) > 0 {
+		args = append(args, prefixQuery(f.Search))
+		where = append(where, fmt.Sprintf(`search @@ to_tsquery('simple', $%d)`, len(args)))
+		// Совпадение целых слов поднимает задачу выше совпадения начала слова
+		args = append(args, strings.Join(f.Search, ` | `))
+		rank = fmt.Sprintf(`ts_rank(search, to_tsquery('simple', $%d)) + ts_rank(search, to_tsquery('simple', $%d))`, len(args)-1, len(args))
+	}
+
+	// Аргументы ранжирования в COUNT не используются
+	countArgs := args
+	if rank != "" {
+		countArgs = args[:len(args)-1]
+	}
+
+	var total int64
+	err := p.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM tasks WHERE `+strings.Join(where, ` AND `), countArgs...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count tasks. error: %v", err)
+	}
+
+	column := sortColumns[f.SortField]
+	op, order := ">", "ASC"
+	if f.SortDesc {
+		op, order = "<", "DESC"
+	}
+
+	if !f.After.IsZero() {
+		var exists bool
+		err = p.db.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND owner = $2)`, f.After.Hex(), f.Owner.Hex(),
+		).Scan(&exists)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get cursor task: %v", err)
+		}
+		if !exists {
+			return nil, 0, custom_error.ErrInvalidCursor
+		}
+
+		args = append(args, f.After.Hex())
+		if column == "id" {
+			where = append(where, fmt.Sprintf(`id %s $%d`, op, len(args)))
+		} else {
+			where = append(where, fmt.Sprintf(`(%[1]s, id) %[2]s (SELECT %[1]s, id FROM tasks WHERE id = $%[3]d)`, column, op, len(args)))
+		}
+	}
+
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE ` + strings.Join(where, ` AND `)
+	switch {
+	case rank != "":
+		query += ` ORDER BY ` + rank + ` DESC, id ASC`
+	case column != "id":
+		query += fmt.Sprintf(` ORDER BY %s %s, id %s`, column, order, order)
+	default:
+		query += fmt.Sprintf(` ORDER BY %s %s`, column, order)
+	}
+	if f.Limit > 0 {
+		args = append(args, f.Limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+	if f.Offset > 0 {
+		args = append(args, f.Offset)
+		query += fmt.Sprintf(` OFFSET $%d`, len(args))
+	}
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to retrieve tasks. error: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error. error: %v", err)
+	}
+
+	log.Printf("get all tasks")
+
+	return tasks, total, nil
+}
+
+func (p *Postgres) GetTaskByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error) {
+	row := p.db.QueryRowContext(ctx,
+		`SELECT `+taskColumns+` FROM tasks WHERE id = $1 AND owner = $2 AND deleted_at IS NULL`, id.Hex(), owner.Hex(),
+	)
+
+	task, err := scanTask(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, custom_error.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	log.Printf("get task")
+
+	return &task, nil
+}
+
+func (p *Postgres) DeleteTask(ctx context.Context, owner, id primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	row := p.db.QueryRowContext(ctx,
+		`UPDATE tasks SET deleted_at = now(), version = version + 1
+		WHERE id = $1 AND owner = $2 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)
+		RETURNING `+taskColumns, id.Hex(), owner.Hex(), version,
+	)
+
+	task, err := p.updatedTask(ctx, row, owner, id, "delete task")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("delete task")
+
+	return task, nil
+}
+
+func (p *Postgres) RestoreTask(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error) {
+	row := p.db.QueryRowContext(ctx,
+		`UPDATE tasks SET deleted_at = NULL, version = version + 1
+		WHERE id = $1 AND owner = $2 AND deleted_at IS NOT NULL
+		RETURNING `+taskColumns, id.Hex(), owner.Hex(),
+	)
+
+	task, err := scanTask(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, custom_error.ErrTaskNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, custom_error.ErrDuplicateTask
+		}
+		return nil, fmt.Errorf("failed to restore task. error: %v", err)
+	}
+
+	log.Printf("restore task")
+
+	return &task, nil
+}
+
+// PurgeDeletedTasks удаляет задачи корзины, комментарии удаляются каскадно. Вложения
+// удаляются до задач в той же транзакции, иначе каскад удалит их без ключей файлов
+func (p *Postgres) PurgeDeletedTasks(ctx context.Context, before time.Time) (int64, []entity.Attachment, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.QueryContext(ctx,
+		`DELETE FROM task_attachments WHERE task_id IN (SELECT id FROM tasks WHERE deleted_at < $1 FOR UPDATE)
+		RETURNING `+attachmentColumns, before,
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to delete attachments of purged tasks: %v", err)
+	}
+	attachments, err := scanAttachments(rows)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM tasks WHERE deleted_at < $1`, before)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to purge tasks. error: %v", err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get affected rows: %v", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to commit purge: %v", err)
+	}
+
+	log.Printf("purge tasks: %d", purged)
+
+	return purged, attachments, nil
+}
+
+// dueColumns возвращает значения колонок due_time и due_time_zone, NULL без времени выполнения
+func dueColumns(due *entity.DueTime) (interface{}, interface{}) {
+	if due == nil {
+		return nil, nil
+	}
+	return due.Time, due.TimeZone
+}
+
+// tagsColumn возвращает значение колонки tags, пустой массив без меток
+func tagsColumn(tags []string) interface{} {
+	if tags == nil {
+		tags = []string{}
+	}
+	return pq.Array(tags)
+}
+
+// dependenciesColumn возвращает значение колонки dependencies: ID задач в hex
+func dependenciesColumn(ids []primitive.ObjectID) interface{} {
+	dependencies := make([]string, 0, len(ids))
+	for _, id := range ids {
+		dependencies = append(dependencies, id.Hex())
+	}
+	return pq.Array(dependencies)
+}
+
+// checklistColumn возвращает значение колонки checklist, пустой массив без пунктов.
+// JSON передается строкой: []byte lib/pq отправил бы как bytea
+func checklistColumn(checklist []entity.ChecklistItem) (string, error) {
+	if checklist == nil {
+		checklist = []entity.ChecklistItem{}
+	}
+
+	value, err := json.Marshal(checklist)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode checklist: %v", err)
+	}
+	return string(value), nil
+}
+
+// prefixQuery строит tsquery, в котором каждое слово поиска совпадает с началом слова.
+// Слова поиска состоят только из букв и цифр, поэтому экранировать их не нужно
+func prefixQuery(search []string) string {
+	terms := make([]string, len(search))
+	for i, term := range search {
+		terms[i] = term + `:*`
+	}
+	return strings.Join(terms, ` & `)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTask(row scanner) (entity.Tasks, error) {
+	var (
+		task                 entity.Tasks
+		id, owner            string
+		workspaceID, listID  sql.NullString
+		dueTime, dueTimeZone sql.NullString
+		checklist            []byte
+		dependencies         []string
+		seriesID             sql.NullString
+		occurrence           sql.NullTime
+		deletedAt            sql.NullTime
+	)
+
+	err := row.Scan(&id, &owner, &workspaceID, &listID, &task.Title, &task.Description, &task.ActiveAt, &task.Status, &task.Priority,
+		&dueTime, &dueTimeZone, &task.Weekend, pq.Array(&task.Tags), &checklist, &task.AutoComplete,
+		pq.Array(&dependencies), &task.Recurrence, &seriesID, &occurrence, &task.Version, &deletedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return task, err
+		}
+		// %w сохраняет ошибку драйвера: UPDATE ... RETURNING сообщает о нарушении индекса при чтении строки
+		return task, fmt.Errorf("failed to scan task. error: %w", err)
+	}
+
+	task.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return task, fmt.Errorf("failed to parse task id: %v", err)
+	}
+
+	task.Owner, err = primitive.ObjectIDFromHex(owner)
+	if err != nil {
+		return task, fmt.Errorf("failed to parse task owner: %v", err)
+	}
+
+	if workspaceID.Valid {
+		workspace, err := primitive.ObjectIDFromHex(workspaceID.String)
+		if err != nil {
+			return task, fmt.Errorf("failed to parse task workspace: %v", err)
+		}
+		task.Workspace = &workspace
+	}
+
+	if listID.Valid {
+		list, err := primitive.ObjectIDFromHex(listID.String)
+		if err != nil {
+			return task, fmt.Errorf("failed to parse task list: %v", err)
+		}
+		task.ListID = &list
+	}
+
+	if dueTime.Valid {
+		task.Due = &entity.DueTime{Time: dueTime.String, TimeZone: dueTimeZone.String}
+	}
+
+	if len(task.Tags) == 0 {
+		task.Tags = nil
+	}
+
+	err = json.Unmarshal(checklist, &task.Checklist)
+	if err != nil {
+		return task, fmt.Errorf("failed to parse task checklist: %v", err)
+	}
+	if len(task.Checklist) == 0 {
+		task.Checklist = nil
+	}
+
+	for _, dependency := range dependencies {
+		blocker, err := primitive.ObjectIDFromHex(dependency)
+		if err != nil {
+			return task, fmt.Errorf("failed to parse task dependency: %v", err)
+		}
+		task.Dependencies = append(task.Dependencies, blocker)
+	}
+
+	if seriesID.Valid {
+		series, err := primitive.ObjectIDFromHex(seriesID.String)
+		if err != nil {
+			return task, fmt.Errorf("failed to parse task series: %v", err)
+		}
+		task.SeriesID = &series
+	}
+
+	if occurrence.Valid {
+		date := entity.DateOf(occurrence.Time)
+		task.Occurrence = &date
+	}
+
+	if deletedAt.Valid {
+		deleted := deletedAt.Time.UTC()
+		task.DeletedAt = &deleted
+	}
+
+	return task, nil
+}
+
+// updatedTask читает задачу, которую вернул UPDATE ... RETURNING. Нет строки - запрос
+// не затронул задачу. action попадает в текст ошибки
+func (p *Postgres) updatedTask(ctx context.Context, row *sql.Row, owner, id primitive.ObjectID, action string) (*entity.Tasks, error) {
+	task, err := scanTask(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, p.missError(ctx, owner, id)
+		}
+		if isUniqueViolation(err) {
+			return nil, custom_error.ErrDuplicateTask
+		}
+		return nil, fmt.Errorf("failed to %s. error: %v", action, err)
+	}
+
+	return &task, nil
+}
+
+// missError объясняет, почему изменение не затронуло задачу:
+// задачи нет у владельца или ее версия уже изменилась
+func (p *Postgres) missError(ctx context.Context, owner, id primitive.ObjectID) error {
+	var exists bool
+	err := p.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND owner = $2 AND deleted_at IS NULL)`, id.Hex(), owner.Hex(),
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to get task by ID: %v", err)
+	}
+
+	if !exists {
+		return custom_error.ErrTaskNotFound
+	}
+
+	return custom_error.ErrVersionMismatch
+}
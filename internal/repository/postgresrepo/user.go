@@ -0,0 +1,77 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+func (p *Postgres) CreateUser(ctx context.Context, u *entity.User) (*entity.User, error) {
+	if u.ID.IsZero() {
+		u.ID = primitive.NewObjectID()
+	}
+
+	_, err := p.db.ExecContext(ctx,
+		`INSERT INTO users (id, username, password) VALUES ($1, $2, $3)`,
+		u.ID.Hex(), u.Username, u.Password,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, custom_error.ErrDuplicateUser
+		}
+		return nil, fmt.Errorf("failed to create user: %v", err)
+	}
+
+	log.Printf("create user")
+
+	return u, nil
+}
+
+func (p *Postgres) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	var (
+		user entity.User
+		id   string
+	)
+
+	err := p.db.QueryRowContext(ctx,
+		`SELECT id, username, password FROM users WHERE username = $1`, username,
+	).Scan(&id, &user.Username, &user.Password)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, custom_error.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by username: %v", err)
+	}
+
+	user.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse user id: %v", err)
+	}
+
+	log.Printf("get user")
+
+	return &user, nil
+}
+
+func (p *Postgres) GetUserByID(ctx context.Context, id primitive.ObjectID) (*entity.User, error) {
+	user := entity.User{ID: id}
+
+	err := p.db.QueryRowContext(ctx,
+		`SELECT username, password FROM users WHERE id = $1`, id.Hex(),
+	).Scan(&user.Username, &user.Password)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, custom_error.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by ID: %v", err)
+	}
+
+	log.Printf("get user")
+
+	return &user, nil
+}
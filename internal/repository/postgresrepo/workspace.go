@@ -0,0 +1,408 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+// CreateWorkspace создает пространство и его владельца в одной транзакции
+func (p *Postgres) CreateWorkspace(ctx context.Context, w *entity.Workspace, owner *entity.Member) (*entity.Workspace, error) {
+	if w.ID.IsZero() {
+		w.ID = primitive.NewObjectID()
+	}
+	owner.Workspace = w.ID
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO workspaces (id, owner, title) VALUES ($1, $2, $3)`, w.ID.Hex(), w.Owner.Hex(), w.Title,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %v", err)
+	}
+
+	err = insertMember(ctx, tx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit workspace creation: %v", err)
+	}
+
+	log.Printf("create workspace")
+
+	return w, nil
+}
+
+func (p *Postgres) GetWorkspaces(ctx context.Context, userID primitive.ObjectID) ([]entity.Workspace, error) {
+	var workspaces []entity.Workspace
+
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT w.id, w.owner, w.title, m.role FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id
+		WHERE m.user_id = $1 ORDER BY w.id`, userID.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve workspaces. error: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		workspace, err := scanWorkspace(rows, true)
+		if err != nil {
+			return nil, err
+		}
+
+		workspaces = append(workspaces, workspace)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error. error: %v", err)
+	}
+
+	log.Printf("get workspaces")
+
+	return workspaces, nil
+}
+
+func (p *Postgres) GetWorkspaceByID(ctx context.Context, id primitive.ObjectID) (*entity.Workspace, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT id, owner, title FROM workspaces WHERE id = $1`, id.Hex())
+
+	workspace, err := scanWorkspace(row, false)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, custom_error.ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+
+	log.Printf("get workspace")
+
+	return &workspace, nil
+}
+
+func (p *Postgres) UpdateWorkspace(ctx context.Context, w *entity.Workspace) error {
+	result, err := p.db.ExecContext(ctx, `UPDATE workspaces SET title = $1 WHERE id = $2`, w.Title, w.ID.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to update workspace. error: %v", err)
+	}
+
+	err = checkRows(result, custom_error.ErrWorkspaceNotFound)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("update workspace")
+
+	return nil
+}
+
+// DeleteWorkspace удаляет задачи, списки, историю задач и само пространство в одной транзакции.
+// Участники и приглашения удаляются каскадно
+func (p *Postgres) DeleteWorkspace(ctx context.Context, id primitive.ObjectID) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM tasks WHERE owner = $1`, id.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace tasks: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM lists WHERE owner = $1`, id.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace lists: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM task_history WHERE owner = $1`, id.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace task history: %v", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM workspaces WHERE id = $1`, id.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace: %v", err)
+	}
+
+	err = checkRows(result, custom_error.ErrWorkspaceNotFound)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit workspace deletion: %v", err)
+	}
+
+	log.Printf("delete workspace")
+
+	return nil
+}
+
+func (p *Postgres) GetMember(ctx context.Context, workspace, userID primitive.ObjectID) (*entity.Member, error) {
+	row := p.db.QueryRowContext(ctx,
+		`SELECT workspace_id, user_id, username, role FROM workspace_members WHERE workspace_id = $1 AND user_id = $2`,
+		workspace.Hex(), userID.Hex(),
+	)
+
+	member, err := scanMember(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, custom_error.ErrMemberNotFound
+		}
+		return nil, err
+	}
+
+	log.Printf("get member")
+
+	return &member, nil
+}
+
+func (p *Postgres) GetMembers(ctx context.Context, workspace primitive.ObjectID) ([]entity.Member, error) {
+	var members []entity.Member
+
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT workspace_id, user_id, username, role FROM workspace_members
+		WHERE workspace_id = $1 ORDER BY username COLLATE "C"`, workspace.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve members. error: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error. error: %v", err)
+	}
+
+	log.Printf("get members")
+
+	return members, nil
+}
+
+func (p *Postgres) AddMember(ctx context.Context, m *entity.Member) error {
+	err := insertMember(ctx, p.db, m)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("add member")
+
+	return nil
+}
+
+func (p *Postgres) UpdateMemberRole(ctx context.Context, workspace, userID primitive.ObjectID, role entity.Role) error {
+	result, err := p.db.ExecContext(ctx,
+		`UPDATE workspace_members SET role = $1 WHERE workspace_id = $2 AND user_id = $3`,
+		role, workspace.Hex(), userID.Hex(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update member role. error: %v", err)
+	}
+
+	err = checkRows(result, custom_error.ErrMemberNotFound)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("update member role")
+
+	return nil
+}
+
+func (p *Postgres) RemoveMember(ctx context.Context, workspace, userID primitive.ObjectID) error {
+	result, err := p.db.ExecContext(ctx,
+		`DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2`, workspace.Hex(), userID.Hex(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove member. error: %v", err)
+	}
+
+	err = checkRows(result, custom_error.ErrMemberNotFound)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("remove member")
+
+	return nil
+}
+
+func (p *Postgres) CreateInvitation(ctx context.Context, i *entity.Invitation) error {
+	_, err := p.db.ExecContext(ctx,
+		`INSERT INTO workspace_invitations (token_hash, workspace_id, role, created_by, expires_at) VALUES ($1, $2, $3, $4, $5)`,
+		i.TokenHash, i.Workspace.Hex(), i.Role, i.CreatedBy.Hex(), i.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %v", err)
+	}
+
+	log.Printf("create invitation")
+
+	return nil
+}
+
+func (p *Postgres) GetInvitation(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	var (
+		invitation             entity.Invitation
+		workspaceID, createdBy string
+	)
+
+	err := p.db.QueryRowContext(ctx,
+		`SELECT token_hash, workspace_id, role, created_by, expires_at FROM workspace_invitations WHERE token_hash = $1`, tokenHash,
+	).Scan(&invitation.TokenHash, &workspaceID, &invitation.Role, &createdBy, &invitation.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, custom_error.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to get invitation: %v", err)
+	}
+
+	invitation.Workspace, err = primitive.ObjectIDFromHex(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse invitation workspace: %v", err)
+	}
+
+	invitation.CreatedBy, err = primitive.ObjectIDFromHex(createdBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse invitation author: %v", err)
+	}
+	invitation.ExpiresAt = invitation.ExpiresAt.UTC()
+
+	log.Printf("get invitation")
+
+	return &invitation, nil
+}
+
+func (p *Postgres) DeleteInvitation(ctx context.Context, tokenHash string) error {
+	result, err := p.db.ExecContext(ctx, `DELETE FROM workspace_invitations WHERE token_hash = $1`, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation. error: %v", err)
+	}
+
+	err = checkRows(result, custom_error.ErrInvitationNotFound)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("delete invitation")
+
+	return nil
+}
+
+func insertMember(ctx context.Context, db execer, m *entity.Member) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO workspace_members (workspace_id, user_id, username, role) VALUES ($1, $2, $3, $4)`,
+		m.Workspace.Hex(), m.UserID.Hex(), m.Username, m.Role,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return custom_error.ErrAlreadyMember
+		}
+		if isForeignKeyViolation(err) {
+			return custom_error.ErrWorkspaceNotFound
+		}
+		return fmt.Errorf("failed to add member: %v", err)
+	}
+
+	return nil
+}
+
+// checkRows возвращает notFound, если запрос не затронул ни одной строки
+func checkRows(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %v", err)
+	}
+
+	if affected == 0 {
+		return notFound
+	}
+
+	return nil
+}
+
+func scanWorkspace(row scanner, withRole bool) (entity.Workspace, error) {
+	var (
+		workspace entity.Workspace
+		id, owner string
+	)
+
+	dest := []interface{}{&id, &owner, &workspace.Title}
+	if withRole {
+		dest = append(dest, &workspace.Role)
+	}
+
+	err := row.Scan(dest...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return workspace, err
+		}
+		return workspace, fmt.Errorf("failed to scan workspace. error: %v", err)
+	}
+
+	workspace.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return workspace, fmt.Errorf("failed to parse workspace id: %v", err)
+	}
+
+	workspace.Owner, err = primitive.ObjectIDFromHex(owner)
+	if err != nil {
+		return workspace, fmt.Errorf("failed to parse workspace owner: %v", err)
+	}
+
+	return workspace, nil
+}
+
+func scanMember(row scanner) (entity.Member, error) {
+	var (
+		member              entity.Member
+		workspaceID, userID string
+	)
+
+	err := row.Scan(&workspaceID, &userID, &member.Username, &member.Role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return member, err
+		}
+		return member, fmt.Errorf("failed to scan member. error: %v", err)
+	}
+
+	member.Workspace, err = primitive.ObjectIDFromHex(workspaceID)
+	if err != nil {
+		return member, fmt.Errorf("failed to parse member workspace: %v", err)
+	}
+
+	member.UserID, err = primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return member, fmt.Errorf("failed to parse member user: %v", err)
+	}
+
+	return member, nil
+}
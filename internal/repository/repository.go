@@ -4,17 +4,131 @@ import (
 	"context"
 	"github.com/khussa1n/todo-list/internal/entity"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
 )
 
+// TodoList - задачи пользователя. Все операции ограничены задачами владельца:
+// e.Owner для создания и изменения, owner для остальных операций.
+// Изменения выполняются, только если версия задачи равна version (0 - любая версия),
+// иначе возвращается custom_error.ErrVersionMismatch. Каждое изменение увеличивает версию
+// и возвращает задачу в том виде, в котором она записана.
+// DeleteTask перемещает задачу в корзину: она видна только через GetAllTasks с filter.Deleted,
+// ее можно вернуть RestoreTask вместе с комментариями и вложениями, а PurgeDeletedTasks
+// удаляет ее насовсем вместе с ними и возвращает вложения, чтобы удалить их файлы.
+// GetTags считает метки задач не из корзины, RenameTag переименовывает метку у всех
+// задач владельца, включая корзину, и сливает ее с меткой to, если она уже есть у задачи,
+// и возвращает измененные задачи до и после переименования. from == to ничего не меняет,
+// но ErrTagNotFound возвращается так же, если метки нет ни у одной задачи.
+// UpdateChecklist заменяет чек-лист и статус задачи, version здесь обязательна.
+// SetRecurrence меняет правило повторения, пустое правило останавливает серию.
+// CompleteOccurrence переводит повторение task в статус done, убирает у него правило,
+// отмечает его датой повторения, создает следующее повторение next и возвращает завершенное.
+// next == nil - серия закончилась.
+// GetTasksByIDs возвращает задачи ids не из корзины в любом порядке, отсутствующие пропускаются.
+// AddDependency и RemoveDependency добавляют и убирают blocker из зависимостей задачи.
+// MoveTask переносит задачу в список listID, nil - во входящие
 type TodoList interface {
 	CreateTask(ctx context.Context, e *entity.Tasks) (*entity.Tasks, error)
-	UpdateTask(ctx context.Context, e *entity.Tasks, id primitive.ObjectID) error
-	UpdateTaskStatus(ctx context.Context, id primitive.ObjectID, status string) error
-	GetAllTasks(ctx context.Context, status string) ([]entity.Tasks, error)
-	GetTaskByID(ctx context.Context, id primitive.ObjectID) (*entity.Tasks, error)
-	DeleteTask(ctx context.Context, id primitive.ObjectID) error
+	UpdateTask(ctx context.Context, e *entity.Tasks, id primitive.ObjectID, version int64) (*entity.Tasks, error)
+	PatchTask(ctx context.Context, owner, id primitive.ObjectID, patch *entity.TaskPatch, version int64) (*entity.Tasks, error)
+	UpdateTaskStatus(ctx context.Context, owner, id primitive.ObjectID, status string, version int64) (*entity.Tasks, error)
+	GetAllTasks(ctx context.Context, filter *entity.TaskFilter) ([]entity.Tasks, int64, error)
+	GetTaskByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error)
+	DeleteTask(ctx context.Context, owner, id primitive.ObjectID, version int64) (*entity.Tasks, error)
+	RestoreTask(ctx context.Context, owner, id primitive.ObjectID) (*entity.Tasks, error)
+	PurgeDeletedTasks(ctx context.Context, before time.Time) (int64, []entity.Attachment, error)
+	GetTags(ctx context.Context, owner primitive.ObjectID) ([]entity.TagStat, error)
+	RenameTag(ctx context.Context, owner primitive.ObjectID, from, to string) ([]entity.TaskRevision, error)
+	UpdateChecklist(ctx context.Context, owner, id primitive.ObjectID, checklist []entity.ChecklistItem, status string, version int64) (*entity.Tasks, error)
+	SetRecurrence(ctx context.Context, owner, id primitive.ObjectID, rule string, version int64) (*entity.Tasks, error)
+	CompleteOccurrence(ctx context.Context, task *entity.Tasks, version int64, next *entity.Tasks) (*entity.Tasks, error)
+	GetTasksByIDs(ctx context.Context, owner primitive.ObjectID, ids []primitive.ObjectID) ([]entity.Tasks, error)
+	AddDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error)
+	RemoveDependency(ctx context.Context, owner, id, blocker primitive.ObjectID, version int64) (*entity.Tasks, error)
+	MoveTask(ctx context.Context, owner, id primitive.ObjectID, listID *primitive.ObjectID, version int64) (*entity.Tasks, error)
+}
+
+// List - списки задач пользователя. DeleteList удаляет только список без задач,
+// иначе возвращает custom_error.ErrListNotEmpty. Задачи списка из корзины удаляются вместе с ним
+// и со своими комментариями и вложениями, вложения возвращаются, чтобы удалить их файлы.
+// Задачи, которые хранилище перенесло из удаляемого списка во «Входящие», возвращаются
+// до и после переноса, чтобы записать их в историю. При ошибке они и вложения тоже могут вернуться
+type List interface {
+	CreateList(ctx context.Context, l *entity.List) (*entity.List, error)
+	GetLists(ctx context.Context, owner primitive.ObjectID) ([]entity.List, error)
+	GetListByID(ctx context.Context, owner, id primitive.ObjectID) (*entity.List, error)
+	UpdateList(ctx context.Context, l *entity.List) error
+	DeleteList(ctx context.Context, owner, id primitive.ObjectID) ([]entity.Attachment, []entity.TaskRevision, error)
+}
+
+// Comment - комментарии к задачам владельца owner, GetComments возвращает их в порядке создания.
+// UpdateComment сохраняет текст, время и историю правок комментария c.
+// Комментарии задачи удаляются вместе с ней, когда она удаляется насовсем
+type Comment interface {
+	CreateComment(ctx context.Context, c *entity.Comment) (*entity.Comment, error)
+	GetComments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Comment, error)
+	GetCommentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Comment, error)
+	UpdateComment(ctx context.Context, c *entity.Comment) error
+	DeleteComment(ctx context.Context, owner, taskID, id primitive.ObjectID) error
+}
+
+// Attachment - описания вложений задач владельца owner, GetAttachments возвращает их в порядке загрузки.
+// DeleteOwnerAttachments возвращает удаленные описания, чтобы удалить и их файлы
+type Attachment interface {
+	CreateAttachment(ctx context.Context, a *entity.Attachment) error
+	GetAttachments(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.Attachment, error)
+	GetAttachmentByID(ctx context.Context, owner, taskID, id primitive.ObjectID) (*entity.Attachment, error)
+	DeleteAttachment(ctx context.Context, owner, taskID, id primitive.ObjectID) error
+	DeleteOwnerAttachments(ctx context.Context, owner primitive.ObjectID) ([]entity.Attachment, error)
+}
+
+// History - неизменяемая история изменений задач владельца owner, GetHistory возвращает записи
+// по возрастанию версии. Версия задачи записывается один раз: повторная запись возвращает
+// custom_error.ErrVersionMismatch. GetHistoryEntry возвращает custom_error.ErrHistoryNotFound,
+// если записи с версией version нет. История остается после окончательного удаления задачи
+type History interface {
+	CreateHistoryEntry(ctx context.Context, e *entity.HistoryEntry) error
+	GetHistory(ctx context.Context, owner, taskID primitive.ObjectID) ([]entity.HistoryEntry, error)
+	GetHistoryEntry(ctx context.Context, owner, taskID primitive.ObjectID, version int64) (*entity.HistoryEntry, error)
+}
+
+// Workspace - рабочие пространства и их участники. Задачи и списки пространства
+// хранятся с владельцем - ID пространства, поэтому TodoList и List работают с ними как с задачами пользователя.
+// CreateWorkspace создает пространство вместе с участником owner.
+// GetWorkspaces возвращает пространства, где участвует userID, с его ролью.
+// GetMember возвращает custom_error.ErrMemberNotFound, если пользователь не участник пространства.
+// DeleteWorkspace удаляет пространство с участниками, приглашениями, списками, задачами, комментариями
+// и историей задач.
+// DeleteInvitation возвращает custom_error.ErrInvitationNotFound, если приглашение уже удалено:
+// так приглашение принимается только один раз
+type Workspace interface {
+	CreateWorkspace(ctx context.Context, w *entity.Workspace, owner *entity.Member) (*entity.Workspace, error)
+	GetWorkspaces(ctx context.Context, userID primitive.ObjectID) ([]entity.Workspace, error)
+	GetWorkspaceByID(ctx context.Context, id primitive.ObjectID) (*entity.Workspace, error)
+	UpdateWorkspace(ctx context.Context, w *entity.Workspace) error
+	DeleteWorkspace(ctx context.Context, id primitive.ObjectID) error
+	GetMember(ctx context.Context, workspace, userID primitive.ObjectID) (*entity.Member, error)
+	GetMembers(ctx context.Context, workspace primitive.ObjectID) ([]entity.Member, error)
+	AddMember(ctx context.Context, m *entity.Member) error
+	UpdateMemberRole(ctx context.Context, workspace, userID primitive.ObjectID, role entity.Role) error
+	RemoveMember(ctx context.Context, workspace, userID primitive.ObjectID) error
+	CreateInvitation(ctx context.Context, i *entity.Invitation) error
+	GetInvitation(ctx context.Context, tokenHash string) (*entity.Invitation, error)
+	DeleteInvitation(ctx context.Context, tokenHash string) error
+}
+
+type User interface {
+	CreateUser(ctx context.Context, u *entity.User) (*entity.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+	GetUserByID(ctx context.Context, id primitive.ObjectID) (*entity.User, error)
 }
 
 type Repository interface {
 	TodoList
+	List
+	Comment
+	Attachment
+	History
+	Workspace
+	User
 }
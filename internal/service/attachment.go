@@ -0,0 +1,195 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/pkg/blobstorage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"unicode"
+)
+
+const (
+	// sniffLen - сколько первых байт файла нужно для определения его типа
+	sniffLen = 512
+	// maxFileNameLen - наибольшая длина имени файла в символах, длинное имя обрезается
+	maxFileNameLen = 255
+)
+
+// UploadAttachment прикрепляет к задаче файл из r размером size байт. Тип файла определяется
+// по его содержимому, контрольная сумма считается во время записи в хранилище
+func (m *Manager) UploadAttachment(ctx context.Context, access *entity.Access, taskID primitive.ObjectID, name string, size int64, r io.Reader) (*entity.Attachment, error) {
+	if size > m.Config.Attachment.MaxSize {
+		return nil, custom_error.ErrAttachmentTooLarge
+	}
+
+	_, err := m.Repository.GetTaskByID(ctx, access.Owner, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	head = head[:n]
+
+	contentType := detectContentType(head)
+	if !m.allowedAttachmentType(contentType) {
+		return nil, custom_error.ErrAttachmentType
+	}
+
+	attachment := &entity.Attachment{
+		ID:          primitive.NewObjectID(),
+		TaskID:      taskID,
+		Owner:       access.Owner,
+		UploadedBy:  access.UserID,
+		FileName:    attachmentFileName(name),
+		ContentType: contentType,
+		Size:        size,
+		CreatedAt:   m.now().UTC(),
+	}
+	attachment.Key = attachmentKey(attachment)
+
+	hash := sha256.New()
+	body := io.TeeReader(io.MultiReader(bytes.NewReader(head), r), hash)
+
+	err = m.Blobs.Put(ctx, attachment.Key, body, size, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	err = m.Repository.CreateAttachment(ctx, attachment)
+	if err != nil {
+		m.deleteBlobs(ctx, []entity.Attachment{*attachment})
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (m *Manager) GetAttachments(ctx context.Context, access *entity.Access, taskID primitive.ObjectID) ([]entity.Attachment, error) {
+	_, err := m.Repository.GetTaskByID(ctx, access.Owner, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Repository.GetAttachments(ctx, access.Owner, taskID)
+}
+
+// GetAttachmentContent читает файл вложения целиком и отдает его, только если
+// размер и контрольная сумма совпадают с сохраненными при загрузке
+func (m *Manager) GetAttachmentContent(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) (*entity.Attachment, []byte, error) {
+	attachment, err := m.Repository.GetAttachmentByID(ctx, access.Owner, taskID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := m.Blobs.Get(ctx, attachment.Key)
+	if err != nil {
+		if errors.Is(err, blobstorage.ErrNotFound) {
+			return nil, nil, custom_error.ErrAttachmentCorrupted
+		}
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, attachment.Size+1))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	if int64(len(content)) != attachment.Size || hex.EncodeToString(sum[:]) != attachment.Checksum {
+		log.Printf("attachment %s checksum mismatch", attachment.Key)
+		return nil, nil, custom_error.ErrAttachmentCorrupted
+	}
+
+	return attachment, content, nil
+}
+
+func (m *Manager) DeleteAttachment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) error {
+	attachment, err := m.Repository.GetAttachmentByID(ctx, access.Owner, taskID, id)
+	if err != nil {
+		return err
+	}
+
+	err = m.Repository.DeleteAttachment(ctx, access.Owner, taskID, id)
+	if err != nil {
+		return err
+	}
+
+	m.deleteBlobs(ctx, []entity.Attachment{*attachment})
+
+	return nil
+}
+
+// deleteBlobs удаляет файлы вложений, описания которых уже удалены. Ошибка только
+// записывается в лог: без описания файл недоступен, а вложение уже считается удаленным
+func (m *Manager) deleteBlobs(ctx context.Context, attachments []entity.Attachment) {
+	for _, attachment := range attachments {
+		err := m.Blobs.Delete(ctx, attachment.Key)
+		if err != nil {
+			log.Printf("delete attachment blob %s err: %s", attachment.Key, err.Error())
+		}
+	}
+}
+
+func (m *Manager) allowedAttachmentType(contentType string) bool {
+	for _, allowed := range m.Config.Attachment.AllowedTypes {
+		if strings.EqualFold(strings.TrimSpace(allowed), contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectContentType возвращает MIME-тип содержимого без параметров вроде charset
+func detectContentType(head []byte) string {
+	contentType := http.DetectContentType(head)
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mediaType
+}
+
+// attachmentFileName оставляет от имени файла из запроса только последний
+// элемент пути без управляющих символов
+func attachmentFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+
+	if runes := []rune(name); len(runes) > maxFileNameLen {
+		name = string(runes[:maxFileNameLen])
+	}
+
+	return name
+}
+
+// attachmentKey - ключ файла вложения в хранилище: владелец/задача/вложение
+func attachmentKey(a *entity.Attachment) string {
+	return a.Owner.Hex() + "/" + a.TaskID.Hex() + "/" + a.ID.Hex()
+}
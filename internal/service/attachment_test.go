@@ -0,0 +1,209 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	mock_repository "github.com/khussa1n/todo-list/internal/repository/mock"
+	"github.com/khussa1n/todo-list/pkg/blobstorage"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"testing"
+	"time"
+)
+
+var pngContent = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 600)...)
+
+func Test_UploadAttachment(t *testing.T) {
+	taskID := primitive.NewObjectID()
+	now := time.Date(2023, 8, 4, 12, 0, 0, 0, time.UTC)
+	errCreate := errors.New("insert failed")
+
+	table := []struct {
+		name            string
+		fileName        string
+		content         []byte
+		size            int64
+		expectedRepoErr error
+		expectedSrvcErr error
+	}{
+		{
+			name:     "ok",
+			fileName: `C:\Users\dana\screenshot.png`,
+			content:  pngContent,
+		},
+		{
+			name:            "too large",
+			fileName:        "screenshot.png",
+			content:         pngContent,
+			size:            11 << 20,
+			expectedSrvcErr: custom_error.ErrAttachmentTooLarge,
+		},
+		{
+			name:            "zip is not allowed",
+			fileName:        "photo.png",
+			content:         []byte("PK\x03\x04 archive"),
+			expectedSrvcErr: custom_error.ErrAttachmentType,
+		},
+		{
+			name:            "metadata not saved",
+			fileName:        "screenshot.png",
+			content:         pngContent,
+			expectedRepoErr: errCreate,
+			expectedSrvcErr: errCreate,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			blobs, err := blobstorage.NewLocal(t.TempDir())
+			require.NoError(t, err)
+
+			size := testCase.size
+			if size == 0 {
+				size = int64(len(testCase.content))
+			}
+
+			var stored *entity.Attachment
+			switch testCase.name {
+			case "ok", "metadata not saved":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, taskID).Return(&entity.Tasks{ID: taskID, Owner: userID}, nil).Times(1)
+				mockRepo.EXPECT().CreateAttachment(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, a *entity.Attachment) error {
+					stored = a
+					return testCase.expectedRepoErr
+				}).Times(1)
+				break
+			case "too large":
+				mockRepo.EXPECT().GetTaskByID(ctx, gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "zip is not allowed":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, taskID).Return(&entity.Tasks{ID: taskID, Owner: userID}, nil).Times(1)
+				mockRepo.EXPECT().CreateAttachment(ctx, gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+			service.Blobs = blobs
+			service.now = func() time.Time {
+				return now
+			}
+
+			attachment, err := service.UploadAttachment(ctx, entity.PersonalAccess(userID), taskID, testCase.fileName, size, bytes.NewReader(testCase.content))
+			require.Equal(t, testCase.expectedSrvcErr, err)
+
+			switch testCase.name {
+			case "ok":
+				sum := sha256.Sum256(testCase.content)
+				require.Equal(t, "screenshot.png", attachment.FileName)
+				require.Equal(t, "image/png", attachment.ContentType)
+				require.Equal(t, hex.EncodeToString(sum[:]), attachment.Checksum)
+				require.Equal(t, now, attachment.CreatedAt)
+				require.Equal(t, attachment, stored)
+
+				file, err := blobs.Get(ctx, attachment.Key)
+				require.NoError(t, err)
+				content, err := io.ReadAll(file)
+				require.NoError(t, err)
+				require.NoError(t, file.Close())
+				require.Equal(t, testCase.content, content)
+				break
+			case "metadata not saved":
+				// Файл без описания не остается в хранилище
+				_, err = blobs.Get(ctx, stored.Key)
+				require.Equal(t, blobstorage.ErrNotFound, err)
+				break
+			}
+		})
+	}
+}
+
+func Test_GetAttachmentContent(t *testing.T) {
+	taskID := primitive.NewObjectID()
+	id := primitive.NewObjectID()
+	sum := sha256.Sum256(pngContent)
+
+	table := []struct {
+		name            string
+		blob            []byte
+		expectedSrvcErr error
+	}{
+		{
+			name: "ok",
+			blob: pngContent,
+		},
+		{
+			name:            "content changed",
+			blob:            append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{1}, 600)...),
+			expectedSrvcErr: custom_error.ErrAttachmentCorrupted,
+		},
+		{
+			name:            "content truncated",
+			blob:            pngContent[:100],
+			expectedSrvcErr: custom_error.ErrAttachmentCorrupted,
+		},
+		{
+			name:            "file lost",
+			expectedSrvcErr: custom_error.ErrAttachmentCorrupted,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			blobs, err := blobstorage.NewLocal(t.TempDir())
+			require.NoError(t, err)
+
+			attachment := &entity.Attachment{
+				ID:          id,
+				TaskID:      taskID,
+				Owner:       userID,
+				ContentType: "image/png",
+				Size:        int64(len(pngContent)),
+				Checksum:    hex.EncodeToString(sum[:]),
+			}
+			attachment.Key = attachmentKey(attachment)
+
+			if testCase.blob != nil {
+				err = blobs.Put(ctx, attachment.Key, bytes.NewReader(testCase.blob), int64(len(testCase.blob)), "image/png")
+				require.NoError(t, err)
+			}
+
+			mockRepo.EXPECT().GetAttachmentByID(ctx, userID, taskID, id).Return(attachment, nil).Times(1)
+
+			service := New(mockRepo, cfg)
+			service.Blobs = blobs
+
+			_, content, err := service.GetAttachmentContent(ctx, entity.PersonalAccess(userID), taskID, id)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+			if testCase.expectedSrvcErr == nil {
+				require.Equal(t, pngContent, content)
+			}
+		})
+	}
+}
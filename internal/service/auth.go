@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
+	"log"
+)
+
+func (m *Manager) SignUp(ctx context.Context, u *dto.UserDTO) (*entity.User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &entity.User{
+		Username: u.Username,
+		Password: string(hash),
+	}
+
+	newUser, err := m.Repository.CreateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUser, nil
+}
+
+func (m *Manager) SignIn(ctx context.Context, u *dto.UserDTO) (*dto.TokenDTO, error) {
+	user, err := m.Repository.GetUserByUsername(ctx, u.Username)
+	if err != nil {
+		if err == custom_error.ErrUserNotFound {
+			return nil, custom_error.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(u.Password))
+	if err != nil {
+		return nil, custom_error.ErrInvalidCredentials
+	}
+
+	token, err := m.Token.CreateToken(user.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TokenDTO{Token: token}, nil
+}
+
+func (m *Manager) ParseToken(ctx context.Context, token string) (primitive.ObjectID, error) {
+	subject, err := m.Token.ValidateToken(token)
+	if err != nil {
+		log.Printf("validate token err: %s", err.Error())
+		return primitive.ObjectID{}, custom_error.ErrInvalidToken
+	}
+
+	userID, err := primitive.ObjectIDFromHex(subject)
+	if err != nil {
+		return primitive.ObjectID{}, custom_error.ErrInvalidToken
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_repository "github.com/khussa1n/todo-list/internal/repository/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+)
+
+func Test_SignUp(t *testing.T) {
+	cfg, err := config.InitConfig("../../config.yaml")
+	require.NoError(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRepo := mock_repository.NewMockRepository(controller)
+
+	ctx := context.Background()
+
+	service := New(mockRepo, cfg)
+
+	req := dto.UserDTO{Username: "khussain", Password: "password123"}
+
+	mockRepo.EXPECT().CreateUser(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, u *entity.User) (*entity.User, error) {
+		require.Equal(t, req.Username, u.Username)
+		require.NoError(t, bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(req.Password)))
+		u.ID = userID
+		return u, nil
+	}).Times(1)
+
+	user, err := service.SignUp(ctx, &req)
+	require.NoError(t, err)
+	require.Equal(t, userID, user.ID)
+}
+
+func Test_SignIn(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	table := []struct {
+		name            string
+		dto             dto.UserDTO
+		expectedRepo    *entity.User
+		expectedRepoErr error
+		expectedSrvcErr error
+	}{
+		{
+			name:         "ok",
+			dto:          dto.UserDTO{Username: "khussain", Password: "password123"},
+			expectedRepo: &entity.User{ID: userID, Username: "khussain", Password: string(hash)},
+		},
+		{
+			name:            "wrong password",
+			dto:             dto.UserDTO{Username: "khussain", Password: "password321"},
+			expectedRepo:    &entity.User{ID: userID, Username: "khussain", Password: string(hash)},
+			expectedSrvcErr: custom_error.ErrInvalidCredentials,
+		},
+		{
+			name:            "user not found",
+			dto:             dto.UserDTO{Username: "unknown", Password: "password123"},
+			expectedRepoErr: custom_error.ErrUserNotFound,
+			expectedSrvcErr: custom_error.ErrInvalidCredentials,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			service := New(mockRepo, cfg)
+
+			mockRepo.EXPECT().GetUserByUsername(ctx, testCase.dto.Username).Return(testCase.expectedRepo, testCase.expectedRepoErr).Times(1)
+
+			token, err := service.SignIn(ctx, &testCase.dto)
+			if testCase.expectedSrvcErr != nil {
+				require.Equal(t, testCase.expectedSrvcErr, err)
+				return
+			}
+			require.NoError(t, err)
+
+			parsedID, err := service.ParseToken(ctx, token.Token)
+			require.NoError(t, err)
+			require.Equal(t, userID, parsedID)
+		})
+	}
+}
+
+func Test_ParseToken(t *testing.T) {
+	cfg, err := config.InitConfig("../../config.yaml")
+	require.NoError(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	service := New(mock_repository.NewMockRepository(controller), cfg)
+
+	_, err = service.ParseToken(context.Background(), "abc")
+	require.Equal(t, custom_error.ErrInvalidToken, err)
+
+	token, err := service.Token.CreateToken("not-an-object-id")
+	require.NoError(t, err)
+
+	_, err = service.ParseToken(context.Background(), token)
+	require.Equal(t, custom_error.ErrInvalidToken, err)
+
+	cfg.Auth.JWTSecret = "another-secret"
+	otherService := New(mock_repository.NewMockRepository(controller), cfg)
+
+	token, err = otherService.Token.CreateToken(primitive.NewObjectID().Hex())
+	require.NoError(t, err)
+
+	_, err = service.ParseToken(context.Background(), token)
+	require.Equal(t, custom_error.ErrInvalidToken, err)
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+)
+
+// maxChecklistAttempts - сколько раз повторить изменение чек-листа, если задачу
+// изменили параллельно, а клиент не передал If-Match
+const maxChecklistAttempts = 3
+
+// AddChecklistItem добавляет пункт в конец чек-листа
+func (m *Manager) AddChecklistItem(ctx context.Context, userID, id primitive.ObjectID, r *dto.ChecklistItemDTO, version int64) (*entity.Tasks, error) {
+	title, err := checklistItemTitle(r.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.changeChecklist(ctx, userID, id, version, func(t *entity.Tasks) error {
+		if len(t.Checklist) >= entity.MaxChecklistItems {
+			return custom_error.ErrChecklistFull
+		}
+		t.Checklist = append(t.Checklist, entity.ChecklistItem{ID: primitive.NewObjectID(), Title: title})
+		return nil
+	})
+}
+
+// UpdateChecklistItem меняет название пункта и отмечает его выполненным или нет
+func (m *Manager) UpdateChecklistItem(ctx context.Context, userID, id, itemID primitive.ObjectID, p *dto.ChecklistItemPatchDTO, version int64) (*entity.Tasks, error) {
+	var title string
+	if p.Title != nil {
+		var err error
+		title, err = checklistItemTitle(*p.Title)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m.changeChecklist(ctx, userID, id, version, func(t *entity.Tasks) error {
+		i := t.ChecklistItemIndex(itemID)
+		if i < 0 {
+			return custom_error.ErrChecklistItemNotFound
+		}
+		if p.Title != nil {
+			t.Checklist[i].Title = title
+		}
+		if p.Done != nil {
+			t.Checklist[i].Done = *p.Done
+		}
+		return nil
+	})
+}
+
+func (m *Manager) DeleteChecklistItem(ctx context.Context, userID, id, itemID primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	return m.changeChecklist(ctx, userID, id, version, func(t *entity.Tasks) error {
+		i := t.ChecklistItemIndex(itemID)
+		if i < 0 {
+			return custom_error.ErrChecklistItemNotFound
+		}
+		t.Checklist = append(t.Checklist[:i:i], t.Checklist[i+1:]...)
+		return nil
+	})
+}
+
+// ReorderChecklist расставляет пункты в порядке r.IDs
+func (m *Manager) ReorderChecklist(ctx context.Context, userID, id primitive.ObjectID, r *dto.ChecklistOrderDTO, version int64) (*entity.Tasks, error) {
+	return m.changeChecklist(ctx, userID, id, version, func(t *entity.Tasks) error {
+		if len(r.IDs) != len(t.Checklist) {
+			return custom_error.ErrInvalidChecklistOrder
+		}
+
+		checklist := make([]entity.ChecklistItem, 0, len(r.IDs))
+		seen := make(map[primitive.ObjectID]struct{}, len(r.IDs))
+		for _, itemID := range r.IDs {
+			i := t.ChecklistItemIndex(itemID)
+			if _, ok := seen[itemID]; ok || i < 0 {
+				return custom_error.ErrInvalidChecklistOrder
+			}
+			seen[itemID] = struct{}{}
+			checklist = append(checklist, t.Checklist[i])
+		}
+
+		t.Checklist = checklist
+		return nil
+	})
+}
+
+// changeChecklist применяет change к чек-листу задачи и сохраняет его вместе со статусом.
+// Если у задачи включено автозавершение, выполнены все пункты и ее не блокируют
+// открытые задачи, задача переходит в done.
+// Возвращает оформленную задачу после изменения
+func (m *Manager) changeChecklist(ctx context.Context, userID, id primitive.ObjectID, version int64, change func(t *entity.Tasks) error) (*entity.Tasks, error) {
+	for attempt := 1; ; attempt++ {
+		task, err := m.Repository.GetTaskByID(ctx, userID, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if version > 0 && task.Version != version {
+			return nil, custom_error.ErrVersionMismatch
+		}
+
+		// change меняет task, поэтому для истории сохраняется копия состояния до изменения
+		before := *task
+		before.Checklist = append([]entity.ChecklistItem(nil), task.Checklist...)
+
+		err = change(task)
+		if err != nil {
+			return nil, err
+		}
+
+		complete := false
+		if task.AutoComplete && task.ChecklistDone() && entity.CanTransition(task.Status, entity.StatusDone) {
+			// Заблокированная задача остается открытой до выполнения блокирующих задач
+			err = m.checkBlockers(ctx, task)
+			if err != nil && !errors.Is(err, custom_error.ErrTaskBlocked) {
+				return nil, err
+			}
+			complete = err == nil
+		}
+
+		// Повторение серии завершается после сохранения чек-листа, как при смене статуса
+		if complete && task.Recurrence == "" {
+			task.Status = entity.StatusDone
+		}
+
+		// Чек-лист сохраняется целиком, поэтому версия проверяется всегда:
+		// иначе параллельное изменение другого пункта потерялось бы
+		saved, err := m.Repository.UpdateChecklist(ctx, userID, id, task.Checklist, task.Status, task.Version)
+		if errors.Is(err, custom_error.ErrVersionMismatch) && version == 0 && attempt < maxChecklistAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		m.track(ctx, entity.HistoryUpdate, &before, saved)
+
+		if complete && saved.Recurrence != "" {
+			completed, err := m.completeOccurrence(ctx, saved)
+			if err != nil {
+				return nil, err
+			}
+			m.track(ctx, entity.HistoryStatus, saved, completed)
+
+			saved = completed
+		}
+
+		return m.decorate(saved), nil
+	}
+}
+
+func checklistItemTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", custom_error.ErrEmptyTitle
+	}
+	return title, nil
+}
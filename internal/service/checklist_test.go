@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_repository "github.com/khussa1n/todo-list/internal/repository/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+)
+
+func Test_UpdateChecklistItem(t *testing.T) {
+	id := primitive.NewObjectID()
+	item1, item2 := primitive.NewObjectID(), primitive.NewObjectID()
+	done := true
+
+	checklist := func(done1, done2 bool) []entity.ChecklistItem {
+		return []entity.ChecklistItem{{ID: item1, Title: "Паспорт", Done: done1}, {ID: item2, Title: "Билеты", Done: done2}}
+	}
+	progress := func(p int) *int {
+		return &p
+	}
+
+	table := []struct {
+		name            string
+		itemID          primitive.ObjectID
+		version         int64
+		taskRepo        entity.Tasks
+		checklistRepo   []entity.ChecklistItem
+		statusRepo      string
+		expectedSrvc    entity.Tasks
+		expectedSrvcErr error
+	}{
+		{
+			name:          "ok",
+			itemID:        item1,
+			taskRepo:      entity.Tasks{ID: id, Title: "Поездка", Status: "todo", Checklist: checklist(false, false), Owner: userID, Version: 3},
+			checklistRepo: checklist(true, false),
+			statusRepo:    "todo",
+			expectedSrvc:  entity.Tasks{ID: id, Title: "Поездка", Status: "todo", Checklist: checklist(true, false), Progress: progress(50), Owner: userID, Version: 4},
+		},
+		{
+			name:          "ok auto complete",
+			itemID:        item2,
+			taskRepo:      entity.Tasks{ID: id, Title: "Поездка", Status: "in_progress", Checklist: checklist(true, false), AutoComplete: true, Owner: userID, Version: 3},
+			checklistRepo: checklist(true, true),
+			statusRepo:    "done",
+			expectedSrvc:  entity.Tasks{ID: id, Title: "Поездка", Status: "done", Checklist: checklist(true, true), Progress: progress(100), AutoComplete: true, Owner: userID, Version: 4},
+		},
+		{
+			name:          "ok archived is not completed",
+			itemID:        item2,
+			taskRepo:      entity.Tasks{ID: id, Title: "Поездка", Status: "archived", Checklist: checklist(true, false), AutoComplete: true, Owner: userID, Version: 3},
+			checklistRepo: checklist(true, true),
+			statusRepo:    "archived",
+			expectedSrvc:  entity.Tasks{ID: id, Title: "Поездка", Status: "archived", Checklist: checklist(true, true), Progress: progress(100), AutoComplete: true, Owner: userID, Version: 4},
+		},
+		{
+			name:            "item not found",
+			itemID:          primitive.NewObjectID(),
+			taskRepo:        entity.Tasks{ID: id, Title: "Поездка", Status: "todo", Checklist: checklist(false, false), Owner: userID, Version: 3},
+			expectedSrvcErr: custom_error.ErrChecklistItemNotFound,
+		},
+		{
+			name:            "version mismatch",
+			itemID:          item1,
+			version:         2,
+			taskRepo:        entity.Tasks{ID: id, Title: "Поездка", Status: "todo", Checklist: checklist(false, false), Owner: userID, Version: 3},
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&testCase.taskRepo, nil).Times(1)
+
+			switch testCase.name {
+			case "ok", "ok auto complete", "ok archived is not completed":
+				after := testCase.expectedSrvc
+				after.Progress = nil
+				mockRepo.EXPECT().UpdateChecklist(ctx, userID, id, testCase.checklistRepo, testCase.statusRepo, int64(3)).Return(&after, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, id, after.Version, entity.HistoryUpdate)
+				break
+			case "item not found", "version mismatch":
+				mockRepo.EXPECT().UpdateChecklist(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			result, err := service.UpdateChecklistItem(ctx, userID, id, testCase.itemID, &dto.ChecklistItemPatchDTO{Done: &done}, testCase.version)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+			if testCase.expectedSrvcErr == nil {
+				require.Equal(t, testCase.expectedSrvc, *result)
+			}
+		})
+	}
+}
+
+func Test_AddChecklistItem(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	cfg, err := config.InitConfig("../../config.yaml")
+	require.NoError(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRepo := mock_repository.NewMockRepository(controller)
+
+	ctx := context.Background()
+
+	// Первая попытка проигрывает параллельному изменению, вторая сохраняет пункт
+	gomock.InOrder(
+		mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&entity.Tasks{ID: id, Status: "todo", Owner: userID, Version: 1}, nil),
+		mockRepo.EXPECT().UpdateChecklist(ctx, userID, id, gomock.Len(1), "todo", int64(1)).Return(nil, custom_error.ErrVersionMismatch),
+		mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&entity.Tasks{ID: id, Status: "todo", Owner: userID, Version: 2}, nil),
+		mockRepo.EXPECT().UpdateChecklist(ctx, userID, id, gomock.Len(1), "todo", int64(2)).DoAndReturn(
+			func(_ context.Context, _, _ primitive.ObjectID, checklist []entity.ChecklistItem, status string, _ int64) (*entity.Tasks, error) {
+				return &entity.Tasks{ID: id, Status: status, Checklist: checklist, Owner: userID, Version: 3}, nil
+			}),
+	)
+	expectHistory(t, mockRepo, ctx, id, 3, entity.HistoryUpdate)
+
+	service := New(mockRepo, cfg)
+
+	result, err := service.AddChecklistItem(ctx, userID, id, &dto.ChecklistItemDTO{Title: " Паспорт "}, 0)
+	require.NoError(t, err)
+	require.Len(t, result.Checklist, 1)
+	require.Equal(t, "Паспорт", result.Checklist[0].Title)
+	require.False(t, result.Checklist[0].ID.IsZero())
+	require.Equal(t, 0, *result.Progress)
+	require.Equal(t, int64(3), result.Version)
+}
+
+func Test_ReorderChecklist(t *testing.T) {
+	id := primitive.NewObjectID()
+	item1, item2 := primitive.NewObjectID(), primitive.NewObjectID()
+	checklist := []entity.ChecklistItem{{ID: item1, Title: "Паспорт"}, {ID: item2, Title: "Билеты"}}
+
+	table := []struct {
+		name            string
+		ids             []primitive.ObjectID
+		expectedRepo    []entity.ChecklistItem
+		expectedSrvcErr error
+	}{
+		{
+			name:         "ok",
+			ids:          []primitive.ObjectID{item2, item1},
+			expectedRepo: []entity.ChecklistItem{{ID: item2, Title: "Билеты"}, {ID: item1, Title: "Паспорт"}},
+		},
+		{
+			name:            "missing item",
+			ids:             []primitive.ObjectID{item2},
+			expectedSrvcErr: custom_error.ErrInvalidChecklistOrder,
+		},
+		{
+			name:            "duplicate item",
+			ids:             []primitive.ObjectID{item2, item2},
+			expectedSrvcErr: custom_error.ErrInvalidChecklistOrder,
+		},
+		{
+			name:            "unknown item",
+			ids:             []primitive.ObjectID{item1, primitive.NewObjectID()},
+			expectedSrvcErr: custom_error.ErrInvalidChecklistOrder,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			task := entity.Tasks{ID: id, Status: "todo", Checklist: append([]entity.ChecklistItem(nil), checklist...), Owner: userID, Version: 1}
+			mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&task, nil).Times(1)
+
+			switch testCase.name {
+			case "ok":
+				mockRepo.EXPECT().UpdateChecklist(ctx, userID, id, testCase.expectedRepo, "todo", int64(1)).
+					Return(&entity.Tasks{ID: id, Status: "todo", Checklist: testCase.expectedRepo, Owner: userID, Version: 2}, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, id, 2, entity.HistoryUpdate)
+				break
+			case "missing item", "duplicate item", "unknown item":
+				mockRepo.EXPECT().UpdateChecklist(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			_, err = service.ReorderChecklist(ctx, userID, id, &dto.ChecklistOrderDTO{IDs: testCase.ids}, 0)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+		})
+	}
+}
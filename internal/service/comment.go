@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+)
+
+func (m *Manager) GetComments(ctx context.Context, access *entity.Access, taskID primitive.ObjectID) ([]entity.Comment, error) {
+	_, err := m.Repository.GetTaskByID(ctx, access.Owner, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Repository.GetComments(ctx, access.Owner, taskID)
+}
+
+// CreateComment добавляет к задаче комментарий от имени пользователя access.UserID
+func (m *Manager) CreateComment(ctx context.Context, access *entity.Access, taskID primitive.ObjectID, r *dto.CommentDTO) (*entity.Comment, error) {
+	body, err := commentBody(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.Repository.GetTaskByID(ctx, access.Owner, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := m.Repository.GetUserByID(ctx, access.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &entity.Comment{
+		TaskID:     taskID,
+		Owner:      access.Owner,
+		Author:     access.UserID,
+		AuthorName: user.Username,
+		Body:       body,
+		CreatedAt:  m.now().UTC(),
+	}
+
+	return m.Repository.CreateComment(ctx, comment)
+}
+
+// UpdateComment меняет текст комментария, прежний текст попадает в историю правок.
+// Править комментарий может только его автор
+func (m *Manager) UpdateComment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID, r *dto.CommentDTO) (*entity.Comment, error) {
+	body, err := commentBody(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := m.Repository.GetCommentByID(ctx, access.Owner, taskID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.Author != access.UserID {
+		return nil, custom_error.ErrNotCommentAuthor
+	}
+
+	if comment.Body == body {
+		return comment, nil
+	}
+
+	comment.Edit(body, m.now().UTC())
+
+	err = m.Repository.UpdateComment(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment удаляет комментарий. Удалить может автор или владелец пространства
+func (m *Manager) DeleteComment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) error {
+	comment, err := m.Repository.GetCommentByID(ctx, access.Owner, taskID, id)
+	if err != nil {
+		return err
+	}
+
+	if comment.Author != access.UserID && !access.Can(entity.RoleOwner) {
+		return custom_error.ErrNotCommentAuthor
+	}
+
+	return m.Repository.DeleteComment(ctx, access.Owner, taskID, id)
+}
+
+func commentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", custom_error.ErrEmptyComment
+	}
+	return body, nil
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_repository "github.com/khussa1n/todo-list/internal/repository/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+func Test_CreateComment(t *testing.T) {
+	taskID := primitive.NewObjectID()
+	now := time.Date(2023, 8, 4, 12, 0, 0, 0, time.UTC)
+
+	table := []struct {
+		name            string
+		body            string
+		expectedRepoErr error
+		expectedSrvcErr error
+	}{
+		{
+			name: "ok",
+			body: "  Нужен **паспорт**  ",
+		},
+		{
+			name:            "empty body",
+			body:            "   ",
+			expectedSrvcErr: custom_error.ErrEmptyComment,
+		},
+		{
+			name:            "task not found",
+			body:            "Нужен паспорт",
+			expectedRepoErr: custom_error.ErrTaskNotFound,
+			expectedSrvcErr: custom_error.ErrTaskNotFound,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			switch testCase.name {
+			case "ok":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, taskID).Return(&entity.Tasks{ID: taskID, Owner: userID}, nil).Times(1)
+				mockRepo.EXPECT().GetUserByID(ctx, userID).Return(&entity.User{ID: userID, Username: "dana"}, nil).Times(1)
+				mockRepo.EXPECT().CreateComment(ctx, &entity.Comment{
+					TaskID:     taskID,
+					Owner:      userID,
+					Author:     userID,
+					AuthorName: "dana",
+					Body:       "Нужен **паспорт**",
+					CreatedAt:  now,
+				}).DoAndReturn(func(_ context.Context, c *entity.Comment) (*entity.Comment, error) {
+					return c, nil
+				}).Times(1)
+				break
+			case "empty body":
+				mockRepo.EXPECT().CreateComment(ctx, gomock.Any()).Times(0)
+				break
+			case "task not found":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, taskID).Return(nil, testCase.expectedRepoErr).Times(1)
+				mockRepo.EXPECT().CreateComment(ctx, gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+			service.now = func() time.Time {
+				return now
+			}
+
+			_, err = service.CreateComment(ctx, entity.PersonalAccess(userID), taskID, &dto.CommentDTO{Body: testCase.body})
+			require.Equal(t, testCase.expectedSrvcErr, err)
+		})
+	}
+}
+
+func Test_UpdateComment(t *testing.T) {
+	taskID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+	authorID := primitive.NewObjectID()
+	created := time.Date(2023, 8, 4, 12, 0, 0, 0, time.UTC)
+	now := created.Add(time.Hour)
+
+	table := []struct {
+		name            string
+		author          primitive.ObjectID
+		body            string
+		expectedSrvcErr error
+	}{
+		{
+			name:   "ok",
+			author: userID,
+			body:   "Нужен паспорт и виза",
+		},
+		{
+			name:   "same body",
+			author: userID,
+			body:   "Нужен паспорт",
+		},
+		{
+			name:            "not the author",
+			author:          authorID,
+			body:            "Нужен паспорт и виза",
+			expectedSrvcErr: custom_error.ErrNotCommentAuthor,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			comment := &entity.Comment{ID: commentID, TaskID: taskID, Owner: userID, Author: testCase.author, Body: "Нужен паспорт", CreatedAt: created}
+			mockRepo.EXPECT().GetCommentByID(ctx, userID, taskID, commentID).Return(comment, nil).Times(1)
+
+			switch testCase.name {
+			case "ok":
+				mockRepo.EXPECT().UpdateComment(ctx, &entity.Comment{
+					ID:        commentID,
+					TaskID:    taskID,
+					Owner:     userID,
+					Author:    userID,
+					Body:      "Нужен паспорт и виза",
+					CreatedAt: created,
+					UpdatedAt: &now,
+					Edits:     []entity.CommentEdit{{Body: "Нужен паспорт", EditedAt: now}},
+				}).Return(nil).Times(1)
+				break
+			case "same body", "not the author":
+				mockRepo.EXPECT().UpdateComment(ctx, gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+			service.now = func() time.Time {
+				return now
+			}
+
+			_, err = service.UpdateComment(ctx, entity.PersonalAccess(userID), taskID, commentID, &dto.CommentDTO{Body: testCase.body})
+			require.Equal(t, testCase.expectedSrvcErr, err)
+		})
+	}
+}
+
+func Test_DeleteComment(t *testing.T) {
+	taskID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+	workspaceID := primitive.NewObjectID()
+	authorID := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		role            entity.Role
+		author          primitive.ObjectID
+		expectedSrvcErr error
+	}{
+		{
+			name:   "ok author",
+			role:   entity.RoleEditor,
+			author: userID,
+		},
+		{
+			name:   "ok workspace owner",
+			role:   entity.RoleOwner,
+			author: authorID,
+		},
+		{
+			name:            "editor deletes other's comment",
+			role:            entity.RoleEditor,
+			author:          authorID,
+			expectedSrvcErr: custom_error.ErrNotCommentAuthor,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			access := &entity.Access{UserID: userID, Owner: workspaceID, Workspace: &workspaceID, Role: testCase.role}
+			comment := &entity.Comment{ID: commentID, TaskID: taskID, Owner: workspaceID, Author: testCase.author}
+			mockRepo.EXPECT().GetCommentByID(ctx, workspaceID, taskID, commentID).Return(comment, nil).Times(1)
+
+			switch testCase.name {
+			case "ok author", "ok workspace owner":
+				mockRepo.EXPECT().DeleteComment(ctx, workspaceID, taskID, commentID).Return(nil).Times(1)
+				break
+			case "editor deletes other's comment":
+				mockRepo.EXPECT().DeleteComment(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			err = service.DeleteComment(ctx, access, taskID, commentID)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"encoding/base64"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strconv"
+)
+
+// encodeCursor превращает ID последней задачи страницы в непрозрачный курсор
+func encodeCursor(id primitive.ObjectID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+func decodeCursor(cursor string) (primitive.ObjectID, error) {
+	var id primitive.ObjectID
+
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return id, err
+	}
+
+	if len(b) != len(id) {
+		return id, base64.CorruptInputError(len(b))
+	}
+
+	copy(id[:], b)
+
+	return id, nil
+}
+
+// encodeOffsetCursor превращает число уже отданных задач в курсор страниц поиска
+func encodeOffsetCursor(offset int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(offset, 10)))
+}
+
+func decodeOffsetCursor(cursor string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	offset, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if offset < 0 {
+		return 0, strconv.ErrRange
+	}
+
+	return offset, nil
+}
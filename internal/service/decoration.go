@@ -0,0 +1,98 @@
+package service
+
+import (
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Decorator - этап оформления задач.
+// Prepare вызывается перед сохранением задачи, Decorate - перед отдачей клиенту
+type Decorator interface {
+	// Prepare очищает название от оформления и вычисляет признаки задачи
+	Prepare(t *entity.Tasks)
+	// Decorate оформляет задачу для ответа, сохраненная задача не меняется
+	Decorate(t *entity.Tasks)
+}
+
+// weekendPrefixes - префиксы выходного дня по локали
+var weekendPrefixes = map[string]string{
+	"ru": "ВЫХОДНОЙ - ",
+	"en": "WEEKEND - ",
+}
+
+const defaultLocale = "ru"
+
+// weekendDecorator отмечает задачи на выходные и праздничные дни
+// и добавляет к их названию префикс
+type weekendDecorator struct {
+	prefix   string
+	weekdays map[time.Weekday]bool
+	// holidays - праздничные дни в формате 2006-01-02
+	holidays map[string]bool
+}
+
+// newWeekendDecorator строит декоратор из конфигурации.
+// Неизвестные дни недели и даты в неверном формате пропускаются
+func newWeekendDecorator(cfg config.DecorationConfig) *weekendDecorator {
+	d := &weekendDecorator{
+		prefix:   cfg.WeekendPrefix,
+		weekdays: make(map[time.Weekday]bool),
+		holidays: make(map[string]bool),
+	}
+
+	if d.prefix == "" {
+		prefix, ok := weekendPrefixes[cfg.Locale]
+		if !ok {
+			if cfg.Locale != "" {
+				log.Printf("unknown decoration locale %q, using %q", cfg.Locale, defaultLocale)
+			}
+			prefix = weekendPrefixes[defaultLocale]
+		}
+		d.prefix = prefix
+	}
+
+	for _, name := range cfg.WeekendDays {
+		weekday, ok := parseWeekday(name)
+		if !ok {
+			log.Printf("unknown weekend day %q", name)
+			continue
+		}
+		d.weekdays[weekday] = true
+	}
+
+	for _, holiday := range cfg.Holidays {
+		date, err := entity.ParseDate(holiday)
+		if err != nil {
+			log.Printf("invalid holiday %q: %s", holiday, err)
+			continue
+		}
+		d.holidays[date.String()] = true
+	}
+
+	return d
+}
+
+func (d *weekendDecorator) Prepare(t *entity.Tasks) {
+	// Клиент может прислать название в том виде, в котором его получил
+	t.Title = strings.TrimSpace(strings.TrimPrefix(strings.TrimLeftFunc(t.Title, unicode.IsSpace), d.prefix))
+	t.Weekend = d.weekdays[t.ActiveAt.Weekday()] || d.holidays[t.ActiveAt.String()]
+}
+
+func (d *weekendDecorator) Decorate(t *entity.Tasks) {
+	if t.Weekend {
+		t.Title = d.prefix + t.Title
+	}
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_weekendDecorator(t *testing.T) {
+	table := []struct {
+		name          string
+		cfg           config.DecorationConfig
+		task          entity.Tasks
+		expectedSaved entity.Tasks
+		expectedShown string
+	}{
+		{
+			name:          "weekend",
+			cfg:           config.DecorationConfig{Locale: "ru", WeekendDays: []string{"saturday", "sunday"}},
+			task:          entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-05")},
+			expectedSaved: entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-05"), Weekend: true},
+			expectedShown: "ВЫХОДНОЙ - Купить",
+		},
+		{
+			name:          "weekday",
+			cfg:           config.DecorationConfig{Locale: "ru", WeekendDays: []string{"saturday", "sunday"}},
+			task:          entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-04")},
+			expectedSaved: entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-04")},
+			expectedShown: "Купить",
+		},
+		{
+			name:          "decorated title",
+			cfg:           config.DecorationConfig{Locale: "ru", WeekendDays: []string{"saturday", "sunday"}},
+			task:          entity.Tasks{Title: "ВЫХОДНОЙ - Купить", ActiveAt: mustDate("2023-08-04")},
+			expectedSaved: entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-04")},
+			expectedShown: "Купить",
+		},
+		{
+			name:          "spaces around decorated title",
+			cfg:           config.DecorationConfig{Locale: "ru", WeekendDays: []string{"saturday", "sunday"}},
+			task:          entity.Tasks{Title: "  ВЫХОДНОЙ - Купить  ", ActiveAt: mustDate("2023-08-04")},
+			expectedSaved: entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-04")},
+			expectedShown: "Купить",
+		},
+		{
+			name:          "only prefix",
+			cfg:           config.DecorationConfig{Locale: "ru", WeekendDays: []string{"saturday", "sunday"}},
+			task:          entity.Tasks{Title: "ВЫХОДНОЙ - ", ActiveAt: mustDate("2023-08-04")},
+			expectedSaved: entity.Tasks{Title: "", ActiveAt: mustDate("2023-08-04")},
+			expectedShown: "",
+		},
+		{
+			name:          "holiday",
+			cfg:           config.DecorationConfig{Locale: "en", WeekendDays: []string{"Saturday"}, Holidays: []string{"2023-08-30"}},
+			task:          entity.Tasks{Title: "Buy", ActiveAt: mustDate("2023-08-30")},
+			expectedSaved: entity.Tasks{Title: "Buy", ActiveAt: mustDate("2023-08-30"), Weekend: true},
+			expectedShown: "WEEKEND - Buy",
+		},
+		{
+			name:          "custom weekend days",
+			cfg:           config.DecorationConfig{WeekendPrefix: "[off] ", WeekendDays: []string{"friday", "noday"}},
+			task:          entity.Tasks{Title: "Buy", ActiveAt: mustDate("2023-08-04")},
+			expectedSaved: entity.Tasks{Title: "Buy", ActiveAt: mustDate("2023-08-04"), Weekend: true},
+			expectedShown: "[off] Buy",
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			decorator := newWeekendDecorator(testCase.cfg)
+
+			task := testCase.task
+			decorator.Prepare(&task)
+			require.Equal(t, testCase.expectedSaved, task)
+
+			decorator.Decorate(&task)
+			require.Equal(t, testCase.expectedShown, task.Title)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxRollbackAttempts - сколько раз попытаться снять связь, замкнувшую цикл, если задачу
+// параллельно изменили
+const maxRollbackAttempts = 3
+
+// AddDependency отмечает, что задачу id блокирует задача blockerID. Связь, которая
+// замкнула бы цепочку зависимостей в цикл, не добавляется
+func (m *Manager) AddDependency(ctx context.Context, userID, id, blockerID primitive.ObjectID, version int64) error {
+	if id == blockerID {
+		return custom_error.ErrDependencyCycle
+	}
+
+	task, err := m.Repository.GetTaskByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if version > 0 && task.Version != version {
+		return custom_error.ErrVersionMismatch
+	}
+
+	if task.DependsOn(blockerID) {
+		return nil
+	}
+
+	if len(task.Dependencies) >= entity.MaxTaskDependencies {
+		return custom_error.ErrTooManyDependencies
+	}
+
+	blocker, err := m.Repository.GetTaskByID(ctx, userID, blockerID)
+	if err != nil {
+		if errors.Is(err, custom_error.ErrTaskNotFound) {
+			return custom_error.ErrBlockerNotFound
+		}
+		return err
+	}
+
+	cycle, err := m.closesCycle(ctx, userID, id, blocker)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return custom_error.ErrDependencyCycle
+	}
+
+	// Запись поверх прочитанной версии защищает только зависимости задачи id. Связь,
+	// параллельно добавленная blocker или задачам его цепочки, могла замкнуть цикл,
+	// поэтому после записи цикл проверяется заново по сохраненным задачам
+	updated, err := m.Repository.AddDependency(ctx, userID, id, blockerID, task.Version)
+	if err != nil {
+		return err
+	}
+	m.track(ctx, entity.HistoryUpdate, task, updated)
+
+	blocker, err = m.Repository.GetTaskByID(ctx, userID, blockerID)
+	if errors.Is(err, custom_error.ErrTaskNotFound) {
+		// Блокирующая задача в корзине не входит в цепочки, цикла через нее нет
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cycle, err = m.closesCycle(ctx, userID, id, blocker)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return m.rollbackDependency(ctx, updated, blockerID)
+	}
+
+	return nil
+}
+
+// closesCycle проверяет, замкнет ли связь id -> blocker цикл: blocker уже зависит
+// от задачи id напрямую или через другие задачи
+func (m *Manager) closesCycle(ctx context.Context, userID, id primitive.ObjectID, blocker *entity.Tasks) (bool, error) {
+	blockers, err := m.collectBlockers(ctx, userID, blocker)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := blockers[id]
+	return ok, nil
+}
+
+// rollbackDependency снимает с task связь с blockerID, замкнувшую цикл, и возвращает
+// ErrDependencyCycle. Снятие записывается поверх последней прочитанной версии и попадает в историю
+func (m *Manager) rollbackDependency(ctx context.Context, task *entity.Tasks, blockerID primitive.ObjectID) error {
+	for attempt := 1; ; attempt++ {
+		removed, err := m.Repository.RemoveDependency(ctx, task.Owner, task.ID, blockerID, task.Version)
+		if errors.Is(err, custom_error.ErrVersionMismatch) && attempt < maxRollbackAttempts {
+			task, err = m.Repository.GetTaskByID(ctx, task.Owner, task.ID)
+			if err != nil {
+				return err
+			}
+			if !task.DependsOn(blockerID) {
+				return custom_error.ErrDependencyCycle
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		m.track(ctx, entity.HistoryUpdate, task, removed)
+
+		return custom_error.ErrDependencyCycle
+	}
+}
+
+func (m *Manager) RemoveDependency(ctx context.Context, userID, id, blockerID primitive.ObjectID, version int64) error {
+	task, err := m.Repository.GetTaskByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if version > 0 && task.Version != version {
+		return custom_error.ErrVersionMismatch
+	}
+
+	if !task.DependsOn(blockerID) {
+		return custom_error.ErrDependencyNotFound
+	}
+
+	updated, err := m.Repository.RemoveDependency(ctx, userID, id, blockerID, task.Version)
+	if err != nil {
+		return err
+	}
+	m.track(ctx, entity.HistoryUpdate, task, updated)
+
+	return nil
+}
+
+// GetDependencyGraph возвращает задачи, которые блокируют задачу id напрямую
+// или через другие задачи, и связи между ними. Задачи из корзины в граф не попадают
+func (m *Manager) GetDependencyGraph(ctx context.Context, userID, id primitive.ObjectID) (*entity.DependencyGraph, error) {
+	task, err := m.Repository.GetTaskByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	blockers, err := m.collectBlockers(ctx, userID, task)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.dependencyGraph(task, blockers), nil
+}
+
+// checkBlockers возвращает custom_error.ErrTaskBlocked, если задачу блокирует открытая задача
+func (m *Manager) checkBlockers(ctx context.Context, task *entity.Tasks) error {
+	if len(task.Dependencies) == 0 {
+		return nil
+	}
+
+	blockers, err := m.Repository.GetTasksByIDs(ctx, task.Owner, task.Dependencies)
+	if err != nil {
+		return err
+	}
+
+	for i := range blockers {
+		if blockers[i].IsOpen() {
+			return custom_error.ErrTaskBlocked
+		}
+	}
+
+	return nil
+}
+
+// collectBlockers загружает все задачи, от которых task зависит напрямую или через
+// другие задачи, по одному запросу на уровень дерева
+func (m *Manager) collectBlockers(ctx context.Context, userID primitive.ObjectID, task *entity.Tasks) (map[primitive.ObjectID]entity.Tasks, error) {
+	blockers := make(map[primitive.ObjectID]entity.Tasks)
+	requested := map[primitive.ObjectID]bool{task.ID: true}
+
+	next := task.Dependencies
+	for len(next) > 0 {
+		var ids []primitive.ObjectID
+		for _, id := range next {
+			if !requested[id] {
+				requested[id] = true
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		tasks, err := m.Repository.GetTasksByIDs(ctx, userID, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		next = nil
+		for _, t := range tasks {
+			blockers[t.ID] = t
+			next = append(next, t.Dependencies...)
+		}
+	}
+
+	return blockers, nil
+}
+
+// dependencyGraph обходит зависимости task в ширину. Каждая задача посещается один раз,
+// поэтому граф строится за время, линейное от числа задач и связей, даже при цикле
+func (m *Manager) dependencyGraph(task *entity.Tasks, blockers map[primitive.ObjectID]entity.Tasks) *entity.DependencyGraph {
+	graph := &entity.DependencyGraph{Root: task.ID, Nodes: []entity.DependencyNode{}, Edges: []entity.DependencyEdge{}}
+
+	visited := map[primitive.ObjectID]bool{task.ID: true}
+	queue := []*entity.Tasks{task}
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+
+		graph.Nodes = append(graph.Nodes, entity.DependencyNode{ID: t.ID, Title: m.decorate(t).Title, Status: t.Status})
+
+		for _, id := range t.Dependencies {
+			blocker, ok := blockers[id]
+			if !ok {
+				continue
+			}
+			graph.Edges = append(graph.Edges, entity.DependencyEdge{Task: t.ID, BlockedBy: id})
+
+			if !visited[id] {
+				visited[id] = true
+				queue = append(queue, &blocker)
+			}
+		}
+	}
+
+	return graph
+}
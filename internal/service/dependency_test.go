@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	mock_repository "github.com/khussa1n/todo-list/internal/repository/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+)
+
+func Test_AddDependency(t *testing.T) {
+	id, blockerID, otherID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+
+	tooMany := make([]primitive.ObjectID, entity.MaxTaskDependencies)
+	for i := range tooMany {
+		tooMany[i] = primitive.NewObjectID()
+	}
+
+	table := []struct {
+		name            string
+		blockerID       primitive.ObjectID
+		version         int64
+		taskRepo        entity.Tasks
+		blockerRepo     entity.Tasks
+		chainRepo       []entity.Tasks
+		expectedSrvcErr error
+	}{
+		{
+			name:        "ok",
+			blockerID:   blockerID,
+			version:     2,
+			taskRepo:    entity.Tasks{ID: id, Owner: userID, Version: 2},
+			blockerRepo: entity.Tasks{ID: blockerID, Dependencies: []primitive.ObjectID{otherID}, Owner: userID, Version: 1},
+			chainRepo:   []entity.Tasks{{ID: otherID, Owner: userID, Version: 1}},
+		},
+		{
+			name:        "ok without If-Match",
+			blockerID:   blockerID,
+			taskRepo:    entity.Tasks{ID: id, Owner: userID, Version: 2},
+			blockerRepo: entity.Tasks{ID: blockerID, Dependencies: []primitive.ObjectID{otherID}, Owner: userID, Version: 1},
+			chainRepo:   []entity.Tasks{{ID: otherID, Owner: userID, Version: 1}},
+		},
+		{
+			name:      "ok already blocked",
+			blockerID: blockerID,
+			taskRepo:  entity.Tasks{ID: id, Dependencies: []primitive.ObjectID{blockerID}, Owner: userID, Version: 2},
+		},
+		{
+			name:            "self dependency",
+			blockerID:       id,
+			expectedSrvcErr: custom_error.ErrDependencyCycle,
+		},
+		{
+			name:            "concurrent cycle",
+			blockerID:       blockerID,
+			taskRepo:        entity.Tasks{ID: id, Owner: userID, Version: 2},
+			blockerRepo:     entity.Tasks{ID: blockerID, Dependencies: []primitive.ObjectID{otherID}, Owner: userID, Version: 1},
+			chainRepo:       []entity.Tasks{{ID: otherID, Owner: userID, Version: 1}},
+			expectedSrvcErr: custom_error.ErrDependencyCycle,
+		},
+		{
+			name:            "cycle",
+			blockerID:       blockerID,
+			taskRepo:        entity.Tasks{ID: id, Owner: userID, Version: 2},
+			blockerRepo:     entity.Tasks{ID: blockerID, Dependencies: []primitive.ObjectID{otherID}, Owner: userID, Version: 1},
+			chainRepo:       []entity.Tasks{{ID: otherID, Dependencies: []primitive.ObjectID{id}, Owner: userID, Version: 1}},
+			expectedSrvcErr: custom_error.ErrDependencyCycle,
+		},
+		{
+			name:            "blocker not found",
+			blockerID:       blockerID,
+			taskRepo:        entity.Tasks{ID: id, Owner: userID, Version: 2},
+			expectedSrvcErr: custom_error.ErrBlockerNotFound,
+		},
+		{
+			name:            "too many dependencies",
+			blockerID:       blockerID,
+			taskRepo:        entity.Tasks{ID: id, Dependencies: tooMany, Owner: userID, Version: 2},
+			expectedSrvcErr: custom_error.ErrTooManyDependencies,
+		},
+		{
+			name:            "version mismatch",
+			blockerID:       blockerID,
+			version:         1,
+			taskRepo:        entity.Tasks{ID: id, Owner: userID, Version: 2},
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			switch testCase.name {
+			case "ok", "ok without If-Match":
+				// Цикл проверяется до и после записи, связь записывается поверх версии, по которой проверен цикл
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&testCase.taskRepo, nil).Times(1)
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, blockerID).Return(&testCase.blockerRepo, nil).Times(2)
+				mockRepo.EXPECT().GetTasksByIDs(ctx, userID, []primitive.ObjectID{otherID}).Return(testCase.chainRepo, nil).Times(2)
+				mockRepo.EXPECT().AddDependency(ctx, userID, id, blockerID, int64(2)).Return(&entity.Tasks{ID: id, Dependencies: []primitive.ObjectID{blockerID}, Owner: userID, Version: 3}, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, id, 3, entity.HistoryUpdate)
+				break
+			case "concurrent cycle":
+				// Пока связь записывалась, задача цепочки стала зависеть от задачи id: связь снимается
+				added := &entity.Tasks{ID: id, Dependencies: []primitive.ObjectID{blockerID}, Owner: userID, Version: 3}
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&testCase.taskRepo, nil).Times(1)
+				gomock.InOrder(
+					mockRepo.EXPECT().GetTaskByID(ctx, userID, blockerID).Return(&testCase.blockerRepo, nil),
+					mockRepo.EXPECT().GetTasksByIDs(ctx, userID, []primitive.ObjectID{otherID}).Return(testCase.chainRepo, nil),
+					mockRepo.EXPECT().AddDependency(ctx, userID, id, blockerID, int64(2)).Return(added, nil),
+					mockRepo.EXPECT().GetTaskByID(ctx, userID, blockerID).Return(&testCase.blockerRepo, nil),
+					mockRepo.EXPECT().GetTasksByIDs(ctx, userID, []primitive.ObjectID{otherID}).
+						Return([]entity.Tasks{{ID: otherID, Dependencies: []primitive.ObjectID{id}, Owner: userID, Version: 2}}, nil),
+					mockRepo.EXPECT().GetTasksByIDs(ctx, userID, []primitive.ObjectID{id}).Return([]entity.Tasks{*added}, nil),
+					mockRepo.EXPECT().RemoveDependency(ctx, userID, id, blockerID, int64(3)).Return(&entity.Tasks{ID: id, Owner: userID, Version: 4}, nil),
+				)
+				expectHistory(t, mockRepo, ctx, id, 3, entity.HistoryUpdate)
+				expectHistory(t, mockRepo, ctx, id, 4, entity.HistoryUpdate)
+				break
+			case "cycle":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&testCase.taskRepo, nil).Times(1)
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, blockerID).Return(&testCase.blockerRepo, nil).Times(1)
+				mockRepo.EXPECT().GetTasksByIDs(ctx, userID, []primitive.ObjectID{otherID}).Return(testCase.chainRepo, nil).Times(1)
+				mockRepo.EXPECT().GetTasksByIDs(ctx, userID, []primitive.ObjectID{id}).Return([]entity.Tasks{testCase.taskRepo}, nil).Times(1)
+				mockRepo.EXPECT().AddDependency(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "blocker not found":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&testCase.taskRepo, nil).Times(1)
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, blockerID).Return(nil, custom_error.ErrTaskNotFound).Times(1)
+				mockRepo.EXPECT().AddDependency(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "ok already blocked", "too many dependencies", "version mismatch":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&testCase.taskRepo, nil).Times(1)
+				mockRepo.EXPECT().AddDependency(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "self dependency":
+				mockRepo.EXPECT().GetTaskByID(ctx, gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			err = service.AddDependency(ctx, userID, id, testCase.blockerID, testCase.version)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+		})
+	}
+}
+
+func Test_RemoveDependency(t *testing.T) {
+	id, blockerID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	cfg, err := config.InitConfig("../../config.yaml")
+	require.NoError(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRepo := mock_repository.NewMockRepository(controller)
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&entity.Tasks{ID: id, Owner: userID, Version: 1}, nil).Times(1)
+	mockRepo.EXPECT().RemoveDependency(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	service := New(mockRepo, cfg)
+
+	err = service.RemoveDependency(ctx, userID, id, blockerID, 0)
+	require.Equal(t, custom_error.ErrDependencyNotFound, err)
+}
+
+func Test_UpdateTaskStatusBlocked(t *testing.T) {
+	id, blockerID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		blockerRepo     []entity.Tasks
+		expectedSrvcErr error
+	}{
+		{
+			name:        "ok blocker done",
+			blockerRepo: []entity.Tasks{{ID: blockerID, Status: "done", Owner: userID}},
+		},
+		{
+			name: "ok blocker in trash",
+		},
+		{
+			name:            "blocked",
+			blockerRepo:     []entity.Tasks{{ID: blockerID, Status: "in_progress", Owner: userID}},
+			expectedSrvcErr: custom_error.ErrTaskBlocked,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			task := &entity.Tasks{ID: id, Status: "todo", Dependencies: []primitive.ObjectID{blockerID}, Owner: userID, Version: 1}
+			mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(task, nil).Times(1)
+			mockRepo.EXPECT().GetTasksByIDs(ctx, userID, task.Dependencies).Return(testCase.blockerRepo, nil).Times(1)
+
+			switch testCase.name {
+			case "ok blocker done", "ok blocker in trash":
+				mockRepo.EXPECT().UpdateTaskStatus(ctx, userID, id, "done", int64(1)).Return(&entity.Tasks{ID: id, Status: "done", Dependencies: task.Dependencies, Owner: userID, Version: 2}, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, id, 2, entity.HistoryStatus)
+				break
+			case "blocked":
+				mockRepo.EXPECT().UpdateTaskStatus(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			err = service.UpdateTaskStatus(ctx, userID, id, "done", 0)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+		})
+	}
+}
+
+func Test_GetDependencyGraph(t *testing.T) {
+	id, a, b, c := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+
+	cfg, err := config.InitConfig("../../config.yaml")
+	require.NoError(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRepo := mock_repository.NewMockRepository(controller)
+
+	ctx := context.Background()
+
+	// a и b блокируют задачу, c блокирует обе, задача d в корзине
+	d := primitive.NewObjectID()
+	mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&entity.Tasks{ID: id, Title: "Релиз", Status: "todo", Dependencies: []primitive.ObjectID{a, b}, Owner: userID}, nil).Times(1)
+	mockRepo.EXPECT().GetTasksByIDs(ctx, userID, []primitive.ObjectID{a, b}).Return([]entity.Tasks{
+		{ID: a, Title: "Тесты", Status: "in_progress", Dependencies: []primitive.ObjectID{c, d}, Owner: userID},
+		{ID: b, Title: "Документация", Status: "done", Dependencies: []primitive.ObjectID{c}, Owner: userID},
+	}, nil).Times(1)
+	mockRepo.EXPECT().GetTasksByIDs(ctx, userID, []primitive.ObjectID{c, d}).Return([]entity.Tasks{
+		{ID: c, Title: "Сборка", Status: "done", Owner: userID},
+	}, nil).Times(1)
+
+	service := New(mockRepo, cfg)
+
+	result, err := service.GetDependencyGraph(ctx, userID, id)
+	require.NoError(t, err)
+
+	// Общая блокирующая задача c входит в граф один раз
+	require.Equal(t, entity.DependencyGraph{
+		Root: id,
+		Nodes: []entity.DependencyNode{
+			{ID: id, Title: "Релиз", Status: "todo"},
+			{ID: a, Title: "Тесты", Status: "in_progress"},
+			{ID: b, Title: "Документация", Status: "done"},
+			{ID: c, Title: "Сборка", Status: "done"},
+		},
+		Edges: []entity.DependencyEdge{{Task: id, BlockedBy: a}, {Task: id, BlockedBy: b}, {Task: a, BlockedBy: c}, {Task: b, BlockedBy: c}},
+	}, *result)
+}
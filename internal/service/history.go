@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+type actorKey struct{}
+
+// WithActor отмечает ctx пользователем, который меняет задачи: он попадает в их историю
+func WithActor(ctx context.Context, userID primitive.ObjectID) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+func actorFrom(ctx context.Context) primitive.ObjectID {
+	userID, _ := ctx.Value(actorKey{}).(primitive.ObjectID)
+	return userID
+}
+
+// GetHistory возвращает историю задачи id от старых версий к новым.
+// История задачи из корзины тоже доступна
+func (m *Manager) GetHistory(ctx context.Context, userID, id primitive.ObjectID) ([]entity.HistoryEntry, error) {
+	history, err := m.Repository.GetHistory(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(history) == 0 {
+		// У задачи, созданной до появления истории, записей нет, но сама задача есть
+		_, err = m.Repository.GetTaskByID(ctx, userID, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return history, nil
+}
+
+// RevertTask возвращает задаче поля, которые меняет UpdateTask, из версии target ее истории.
+// Статус, чек-лист, список, повторение и зависимости остаются текущими: у них свои правила изменения.
+// Откат создает новую версию задачи, прежние версии истории не меняются. Откат к текущей версии ничего не меняет
+func (m *Manager) RevertTask(ctx context.Context, userID, id primitive.ObjectID, target, version int64) (*entity.Tasks, error) {
+	entry, err := m.Repository.GetHistoryEntry(ctx, userID, id, target)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := m.Repository.GetTaskByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version > 0 && task.Version != version {
+		return nil, custom_error.ErrVersionMismatch
+	}
+
+	if task.Version == target {
+		return m.decorate(task), nil
+	}
+
+	snapshot := entry.Snapshot
+	reverted := &entity.Tasks{
+		Title:        snapshot.Title,
+		Description:  snapshot.Description,
+		ActiveAt:     snapshot.ActiveAt,
+		Status:       task.Status,
+		Priority:     snapshot.Priority,
+		Due:          snapshot.Due,
+		Tags:         snapshot.Tags,
+		AutoComplete: snapshot.AutoComplete,
+		Owner:        userID,
+	}
+
+	// Признак выходного дня вычисляется заново: календарь праздников мог измениться
+	m.Decorator.Prepare(reverted)
+
+	// Поля отката взяты из прочитанной версии, поэтому и записываются только поверх нее
+	after, err := m.Repository.UpdateTask(ctx, reverted, id, task.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	revert := m.historyEntry(ctx, entity.HistoryRevert, task, after)
+	revert.RevertedTo = &target
+	m.record(ctx, revert)
+
+	return m.decorate(after), nil
+}
+
+// track записывает в историю изменение задачи before -> after. after - задача в том виде,
+// в котором ее записало хранилище, изменение без новой версии не записывается
+func (m *Manager) track(ctx context.Context, action string, before, after *entity.Tasks) {
+	if after.Version == before.Version {
+		return
+	}
+
+	m.record(ctx, m.historyEntry(ctx, action, before, after))
+}
+
+// historyEntry возвращает запись истории об изменении задачи before -> after.
+// before == nil - задача создана
+func (m *Manager) historyEntry(ctx context.Context, action string, before, after *entity.Tasks) *entity.HistoryEntry {
+	return &entity.HistoryEntry{
+		TaskID:   after.ID,
+		Owner:    after.Owner,
+		Version:  after.Version,
+		Action:   action,
+		Actor:    actorFrom(ctx),
+		At:       m.now().UTC(),
+		Changes:  entity.TaskChanges(before, after),
+		Snapshot: *after,
+	}
+}
+
+// record сохраняет запись истории. Изменение задачи к этому моменту уже сохранено,
+// поэтому ошибка записи не отменяет его, а только записывается в лог
+func (m *Manager) record(ctx context.Context, e *entity.HistoryEntry) {
+	err := m.Repository.CreateHistoryEntry(ctx, e)
+	if err != nil {
+		log.Printf("can not record history of task %s: %s", e.TaskID.Hex(), err.Error())
+	}
+}
+
+// lastSnapshot возвращает состояние задачи id из последней записи ее истории, nil - записей нет
+func (m *Manager) lastSnapshot(ctx context.Context, userID, id primitive.ObjectID) *entity.Tasks {
+	history, err := m.Repository.GetHistory(ctx, userID, id)
+	if err != nil {
+		log.Printf("can not get history of task %s: %s", id.Hex(), err.Error())
+		return nil
+	}
+
+	if len(history) == 0 {
+		return nil
+	}
+
+	return &history[len(history)-1].Snapshot
+}
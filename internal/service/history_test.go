@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_repository "github.com/khussa1n/todo-list/internal/repository/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+// expectHistory ждет записи в историю задачи id версии version с действием action
+func expectHistory(t *testing.T, mockRepo *mock_repository.MockRepository, ctx context.Context, id primitive.ObjectID, version int64, action string) {
+	mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *entity.HistoryEntry) error {
+		require.Equal(t, id, e.TaskID)
+		require.Equal(t, version, e.Version)
+		require.Equal(t, action, e.Action)
+		return nil
+	}).Times(1)
+}
+
+func Test_HistoryEntry(t *testing.T) {
+	cfg, err := config.InitConfig("../../config.yaml")
+	require.NoError(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRepo := mock_repository.NewMockRepository(controller)
+
+	actor := primitive.NewObjectID()
+	ctx := WithActor(context.Background(), actor)
+	id := primitive.NewObjectID()
+	now := time.Date(2023, 8, 4, 12, 0, 0, 0, time.UTC)
+
+	before := &entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Tags: []string{"дом"}, Owner: userID, Version: 1}
+	after := &entity.Tasks{ID: id, Title: "Продать", ActiveAt: mustDate("2023-08-04"), Status: "todo", Due: &entity.DueTime{Time: "18:30", TimeZone: "UTC"}, Owner: userID, Version: 2}
+
+	mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(before, nil).Times(1)
+	mockRepo.EXPECT().UpdateTask(ctx, gomock.Any(), id, int64(1)).Return(after, nil).Times(1)
+
+	var stored *entity.HistoryEntry
+	mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *entity.HistoryEntry) error {
+		stored = e
+		return nil
+	}).Times(1)
+
+	service := New(mockRepo, cfg)
+	service.now = func() time.Time {
+		return now
+	}
+
+	err = service.UpdateTask(ctx, userID, &dto.TasksDTO{Title: "Продать", ActiveAt: "2023-08-04", Due: &dto.DueDTO{Time: "18:30"}}, id, 0)
+	require.NoError(t, err)
+
+	require.Equal(t, &entity.HistoryEntry{
+		TaskID:  id,
+		Owner:   userID,
+		Version: 2,
+		Action:  entity.HistoryUpdate,
+		Actor:   actor,
+		At:      now,
+		Changes: []entity.FieldChange{
+			{Field: "title", Before: json.RawMessage(`"Купить"`), After: json.RawMessage(`"Продать"`)},
+			{Field: "due", Before: json.RawMessage(`null`), After: json.RawMessage(`{"time":"18:30","timeZone":"UTC"}`)},
+			{Field: "tags", Before: json.RawMessage(`["дом"]`), After: json.RawMessage(`null`)},
+		},
+		Snapshot: *after,
+	}, stored)
+}
+
+func Test_GetHistory(t *testing.T) {
+	id := primitive.NewObjectID()
+	entry := entity.HistoryEntry{ID: primitive.NewObjectID(), TaskID: id, Owner: userID, Version: 1, Action: entity.HistoryCreate}
+
+	table := []struct {
+		name            string
+		historyRepo     []entity.HistoryEntry
+		expectedSrvc    []entity.HistoryEntry
+		expectedSrvcErr error
+	}{
+		{
+			name:         "ok",
+			historyRepo:  []entity.HistoryEntry{entry},
+			expectedSrvc: []entity.HistoryEntry{entry},
+		},
+		{
+			name: "ok task without history",
+		},
+		{
+			name:            "task not found",
+			expectedSrvcErr: custom_error.ErrTaskNotFound,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			mockRepo.EXPECT().GetHistory(ctx, userID, id).Return(testCase.historyRepo, nil).Times(1)
+
+			switch testCase.name {
+			case "ok":
+				mockRepo.EXPECT().GetTaskByID(ctx, gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "ok task without history":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&entity.Tasks{ID: id, Owner: userID}, nil).Times(1)
+				break
+			case "task not found":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(nil, custom_error.ErrTaskNotFound).Times(1)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			history, err := service.GetHistory(ctx, userID, id)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+			require.Equal(t, testCase.expectedSrvc, history)
+		})
+	}
+}
+
+func Test_RevertTask(t *testing.T) {
+	id := primitive.NewObjectID()
+	snapshot := entity.Tasks{ID: id, Title: "Купить", Description: "**Молоко**", ActiveAt: mustDate("2023-08-05"), Status: "todo", Priority: entity.PriorityHigh, Tags: []string{"дом"}, Owner: userID, Version: 1}
+	current := entity.Tasks{ID: id, Title: "Продать", ActiveAt: mustDate("2023-08-04"), Status: "in_progress", Owner: userID, Version: 3}
+
+	table := []struct {
+		name            string
+		target          int64
+		version         int64
+		expectedRepoErr error
+		expectedSrvcErr error
+	}{
+		{
+			name:   "ok",
+			target: 1,
+		},
+		{
+			name:   "ok current version",
+			target: 3,
+		},
+		{
+			name:            "version not in history",
+			target:          7,
+			expectedRepoErr: custom_error.ErrHistoryNotFound,
+			expectedSrvcErr: custom_error.ErrHistoryNotFound,
+		},
+		{
+			name:            "stale version",
+			target:          1,
+			version:         2,
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+		},
+		{
+			name:            "title taken",
+			target:          1,
+			expectedRepoErr: custom_error.ErrDuplicateTask,
+			expectedSrvcErr: custom_error.ErrDuplicateTask,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+			entry := &entity.HistoryEntry{TaskID: id, Owner: userID, Version: testCase.target, Snapshot: snapshot}
+			task := current
+
+			// Статус остается текущим, выходной день вычисляется заново
+			reverted := &entity.Tasks{Title: "Купить", Description: "**Молоко**", ActiveAt: mustDate("2023-08-05"), Status: "in_progress", Priority: entity.PriorityHigh, Tags: []string{"дом"}, Weekend: true, Owner: userID}
+			after := *reverted
+			after.ID = id
+			after.Version = 4
+
+			switch testCase.name {
+			case "ok":
+				mockRepo.EXPECT().GetHistoryEntry(ctx, userID, id, testCase.target).Return(entry, nil).Times(1)
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&task, nil).Times(1)
+				mockRepo.EXPECT().UpdateTask(ctx, reverted, id, current.Version).Return(&after, nil).Times(1)
+				mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *entity.HistoryEntry) error {
+					require.Equal(t, entity.HistoryRevert, e.Action)
+					require.Equal(t, int64(4), e.Version)
+					require.Equal(t, int64(1), *e.RevertedTo)
+					return nil
+				}).Times(1)
+				break
+			case "ok current version", "stale version":
+				mockRepo.EXPECT().GetHistoryEntry(ctx, userID, id, testCase.target).Return(entry, nil).Times(1)
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&task, nil).Times(1)
+				mockRepo.EXPECT().UpdateTask(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "version not in history":
+				mockRepo.EXPECT().GetHistoryEntry(ctx, userID, id, testCase.target).Return(nil, testCase.expectedRepoErr).Times(1)
+				mockRepo.EXPECT().UpdateTask(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "title taken":
+				mockRepo.EXPECT().GetHistoryEntry(ctx, userID, id, testCase.target).Return(entry, nil).Times(1)
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&task, nil).Times(1)
+				mockRepo.EXPECT().UpdateTask(ctx, reverted, id, current.Version).Return(nil, testCase.expectedRepoErr).Times(1)
+				mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			result, err := service.RevertTask(ctx, userID, id, testCase.target, testCase.version)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+
+			switch testCase.name {
+			case "ok":
+				require.Equal(t, int64(4), result.Version)
+				require.Equal(t, "ВЫХОДНОЙ - Купить", result.Title)
+				break
+			case "ok current version":
+				require.Equal(t, int64(3), result.Version)
+				break
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+)
+
+func (m *Manager) CreateList(ctx context.Context, userID primitive.ObjectID, l *dto.ListDTO) (*entity.List, error) {
+	title, err := listTitle(l.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Repository.CreateList(ctx, &entity.List{Title: title, Owner: userID})
+}
+
+func (m *Manager) GetLists(ctx context.Context, userID primitive.ObjectID) ([]entity.List, error) {
+	return m.Repository.GetLists(ctx, userID)
+}
+
+func (m *Manager) GetListByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.List, error) {
+	return m.Repository.GetListByID(ctx, userID, id)
+}
+
+// UpdateList переименовывает список
+func (m *Manager) UpdateList(ctx context.Context, userID, id primitive.ObjectID, l *dto.ListDTO) error {
+	title, err := listTitle(l.Title)
+	if err != nil {
+		return err
+	}
+
+	return m.Repository.UpdateList(ctx, &entity.List{ID: id, Title: title, Owner: userID})
+}
+
+// DeleteList удаляет пустой список. Задачи списка из корзины удаляются насовсем
+// вместе с комментариями и файлами вложений
+func (m *Manager) DeleteList(ctx context.Context, userID, id primitive.ObjectID) error {
+	// Корзина списка могла быть очищена, а задачи перенесены и при ошибке,
+	// поэтому файлы удаляются и история записывается до ее проверки
+	attachments, moved, err := m.Repository.DeleteList(ctx, userID, id)
+	m.deleteBlobs(ctx, attachments)
+	for i := range moved {
+		m.track(ctx, entity.HistoryUpdate, &moved[i].Before, &moved[i].After)
+	}
+
+	return err
+}
+
+// GetListTasks возвращает задачи списка id с теми же фильтрами, что и GetAllTasks
+func (m *Manager) GetListTasks(ctx context.Context, userID, id primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error) {
+	_, err := m.Repository.GetListByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &entity.TaskFilter{
+		Owner:     userID,
+		ListID:    id,
+		Statuses:  entity.OpenStatuses,
+		SortField: "createdAt",
+		Limit:     defaultLimit,
+	}
+
+	return m.listTasks(ctx, filter, q)
+}
+
+// MoveTask переносит задачу в другой список. Название задачи должно быть
+// свободно в новом списке
+func (m *Manager) MoveTask(ctx context.Context, userID, id primitive.ObjectID, r *dto.MoveTaskDTO, version int64) error {
+	listID, err := m.resolveList(ctx, userID, r.ListID)
+	if err != nil {
+		return err
+	}
+
+	task, err := m.Repository.GetTaskByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if version > 0 && task.Version != version {
+		return custom_error.ErrVersionMismatch
+	}
+
+	// Изменение для истории считается от прочитанной версии, поэтому и записывается только поверх нее
+	moved, err := m.Repository.MoveTask(ctx, userID, id, listID, task.Version)
+	if err != nil {
+		return err
+	}
+	m.track(ctx, entity.HistoryUpdate, task, moved)
+
+	return nil
+}
+
+// resolveList проверяет, что список с ID s есть у пользователя. Пустая строка - входящие
+func (m *Manager) resolveList(ctx context.Context, userID primitive.ObjectID, s string) (*primitive.ObjectID, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	id, err := primitive.ObjectIDFromHex(s)
+	if err != nil {
+		return nil, custom_error.ErrInvalidListID
+	}
+
+	_, err = m.Repository.GetListByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func listTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", custom_error.ErrEmptyTitle
+	}
+	return title, nil
+}
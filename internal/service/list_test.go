@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_repository "github.com/khussa1n/todo-list/internal/repository/mock"
+	"github.com/khussa1n/todo-list/pkg/blobstorage"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+	"testing"
+)
+
+func Test_CreateList(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		dto             dto.ListDTO
+		listRepo        entity.List
+		expectedRepoErr error
+		expectedSrvcErr error
+	}{
+		{
+			name:     "ok",
+			dto:      dto.ListDTO{Title: " Sprint backlog "},
+			listRepo: entity.List{Title: "Sprint backlog", Owner: userID},
+		},
+		{
+			name:            "duplicate",
+			dto:             dto.ListDTO{Title: "Ops"},
+			listRepo:        entity.List{Title: "Ops", Owner: userID},
+			expectedRepoErr: custom_error.ErrDuplicateList,
+			expectedSrvcErr: custom_error.ErrDuplicateList,
+		},
+		{
+			name:            "empty title",
+			dto:             dto.ListDTO{Title: "  "},
+			expectedSrvcErr: custom_error.ErrEmptyTitle,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			switch testCase.name {
+			case "ok":
+				created := testCase.listRepo
+				created.ID = id
+				mockRepo.EXPECT().CreateList(ctx, &testCase.listRepo).Return(&created, nil).Times(1)
+				break
+			case "duplicate":
+				mockRepo.EXPECT().CreateList(ctx, &testCase.listRepo).Return(nil, testCase.expectedRepoErr).Times(1)
+				break
+			case "empty title":
+				mockRepo.EXPECT().CreateList(ctx, gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			result, err := service.CreateList(ctx, userID, &testCase.dto)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+			if testCase.expectedSrvcErr == nil {
+				require.Equal(t, entity.List{ID: id, Title: "Sprint backlog", Owner: userID}, *result)
+			}
+		})
+	}
+}
+
+func Test_DeleteList(t *testing.T) {
+	id, taskID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	// Задача попала в список после проверки и перенесена во «Входящие»
+	moved := []entity.TaskRevision{{
+		Before: entity.Tasks{ID: taskID, ListID: &id, Title: "Деплой", Status: "todo", Owner: userID, Version: 1},
+		After:  entity.Tasks{ID: taskID, Title: "Деплой", Status: "todo", Owner: userID, Version: 2},
+	}}
+
+	table := []struct {
+		name            string
+		movedRepo       []entity.TaskRevision
+		expectedRepoErr error
+		blobDeleted     bool
+	}{
+		{
+			name:        "ok",
+			blobDeleted: true,
+		},
+		{
+			name:        "ok stray task moved",
+			movedRepo:   moved,
+			blobDeleted: true,
+		},
+		{
+			name:            "not empty",
+			expectedRepoErr: custom_error.ErrListNotEmpty,
+		},
+		{
+			name:            "title taken in inbox",
+			expectedRepoErr: custom_error.ErrDuplicateTask,
+			blobDeleted:     true,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			blobs, err := blobstorage.NewLocal(t.TempDir())
+			require.NoError(t, err)
+
+			attachment := entity.Attachment{ID: primitive.NewObjectID(), TaskID: primitive.NewObjectID(), Owner: userID}
+			attachment.Key = attachmentKey(&attachment)
+			err = blobs.Put(ctx, attachment.Key, strings.NewReader("file"), 4, "text/plain")
+			require.NoError(t, err)
+
+			switch testCase.name {
+			case "ok", "ok stray task moved":
+				mockRepo.EXPECT().DeleteList(ctx, userID, id).Return([]entity.Attachment{attachment}, testCase.movedRepo, nil).Times(1)
+				for _, m := range testCase.movedRepo {
+					expectHistory(t, mockRepo, ctx, m.After.ID, m.After.Version, entity.HistoryUpdate)
+				}
+				break
+			case "not empty":
+				mockRepo.EXPECT().DeleteList(ctx, userID, id).Return(nil, nil, testCase.expectedRepoErr).Times(1)
+				break
+			case "title taken in inbox":
+				// Корзина уже очищена, список остается: файлы все равно удаляются
+				mockRepo.EXPECT().DeleteList(ctx, userID, id).Return([]entity.Attachment{attachment}, nil, testCase.expectedRepoErr).Times(1)
+				mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+			service.Blobs = blobs
+
+			err = service.DeleteList(ctx, userID, id)
+			require.Equal(t, testCase.expectedRepoErr, err)
+
+			// Файлы вложений задач из корзины удаляются вместе со списком
+			_, err = blobs.Get(ctx, attachment.Key)
+			if testCase.blobDeleted {
+				require.Equal(t, blobstorage.ErrNotFound, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_MoveTask(t *testing.T) {
+	id, listID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		dto             dto.MoveTaskDTO
+		version         int64
+		expectedSrvcErr error
+	}{
+		{
+			name:    "ok",
+			dto:     dto.MoveTaskDTO{ListID: listID.Hex()},
+			version: 3,
+		},
+		{
+			name: "ok inbox",
+			dto:  dto.MoveTaskDTO{},
+		},
+		{
+			name:            "version mismatch",
+			dto:             dto.MoveTaskDTO{ListID: listID.Hex()},
+			version:         2,
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+		},
+		{
+			name:            "invalid list id",
+			dto:             dto.MoveTaskDTO{ListID: "sprint"},
+			expectedSrvcErr: custom_error.ErrInvalidListID,
+		},
+		{
+			name:            "list not found",
+			dto:             dto.MoveTaskDTO{ListID: listID.Hex()},
+			expectedSrvcErr: custom_error.ErrListNotFound,
+		},
+		{
+			name:            "duplicate title",
+			dto:             dto.MoveTaskDTO{ListID: listID.Hex()},
+			expectedSrvcErr: custom_error.ErrDuplicateTask,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			task := &entity.Tasks{ID: id, Title: "Деплой", Status: "todo", Owner: userID, Version: 3}
+
+			switch testCase.name {
+			case "ok":
+				mockRepo.EXPECT().GetListByID(ctx, userID, listID).Return(&entity.List{ID: listID, Title: "Ops", Owner: userID}, nil).Times(1)
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(task, nil).Times(1)
+				mockRepo.EXPECT().MoveTask(ctx, userID, id, &listID, task.Version).Return(&entity.Tasks{ID: id, ListID: &listID, Title: "Деплой", Status: "todo", Owner: userID, Version: 4}, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, id, 4, entity.HistoryUpdate)
+				break
+			case "duplicate title":
+				mockRepo.EXPECT().GetListByID(ctx, userID, listID).Return(&entity.List{ID: listID, Title: "Ops", Owner: userID}, nil).Times(1)
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(task, nil).Times(1)
+				mockRepo.EXPECT().MoveTask(ctx, userID, id, &listID, task.Version).Return(nil, testCase.expectedSrvcErr).Times(1)
+				mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).Times(0)
+				break
+			case "ok inbox":
+				mockRepo.EXPECT().GetListByID(ctx, gomock.Any(), gomock.Any()).Times(0)
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(task, nil).Times(1)
+				mockRepo.EXPECT().MoveTask(ctx, userID, id, nil, task.Version).Return(&entity.Tasks{ID: id, Title: "Деплой", Status: "todo", Owner: userID, Version: 4}, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, id, 4, entity.HistoryUpdate)
+				break
+			case "version mismatch":
+				mockRepo.EXPECT().GetListByID(ctx, userID, listID).Return(&entity.List{ID: listID, Title: "Ops", Owner: userID}, nil).Times(1)
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(task, nil).Times(1)
+				mockRepo.EXPECT().MoveTask(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "invalid list id":
+				mockRepo.EXPECT().MoveTask(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "list not found":
+				mockRepo.EXPECT().GetListByID(ctx, userID, listID).Return(nil, custom_error.ErrListNotFound).Times(1)
+				mockRepo.EXPECT().MoveTask(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			err = service.MoveTask(ctx, userID, id, &testCase.dto, testCase.version)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+		})
+	}
+}
+
+func Test_GetListTasks(t *testing.T) {
+	listID := primitive.NewObjectID()
+
+	cfg, err := config.InitConfig("../../config.yaml")
+	require.NoError(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRepo := mock_repository.NewMockRepository(controller)
+
+	ctx := context.Background()
+
+	task := entity.Tasks{ID: primitive.NewObjectID(), ListID: &listID, Title: "Деплой", ActiveAt: mustDate("2023-08-04"), Status: "done", Owner: userID, Version: 1}
+	filter := &entity.TaskFilter{Owner: userID, ListID: listID, Statuses: []string{"done"}, SortField: "createdAt", Limit: defaultLimit + 1}
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetListByID(ctx, userID, listID).Return(&entity.List{ID: listID, Title: "Ops", Owner: userID}, nil),
+		mockRepo.EXPECT().GetAllTasks(ctx, filter).Return([]entity.Tasks{task}, int64(1), nil),
+	)
+
+	service := New(mockRepo, cfg)
+
+	result, err := service.GetListTasks(ctx, userID, listID, &dto.TasksQueryDTO{Status: "done"})
+	require.NoError(t, err)
+	require.Equal(t, dto.TasksPageDTO{Tasks: []entity.Tasks{task}, Total: 1}, *result)
+}
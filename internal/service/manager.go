@@ -3,16 +3,30 @@ package service
 import (
 	"github.com/khussa1n/todo-list/internal/config"
 	"github.com/khussa1n/todo-list/internal/repository"
+	"github.com/khussa1n/todo-list/pkg/blobstorage"
+	"github.com/khussa1n/todo-list/pkg/jwttoken"
+	"time"
 )
 
 type Manager struct {
 	Repository repository.Repository
 	Config     *config.Config
+	Token      *jwttoken.JWTToken
+	// Decorator оформляет задачи, по умолчанию - префикс выходного дня из config.Decoration
+	Decorator Decorator
+	// Blobs хранит файлы вложений, задается при запуске по config.Attachment.Storage
+	Blobs blobstorage.Storage
+
+	// now - источник текущего времени, подменяется в тестах
+	now func() time.Time
 }
 
 func New(repository repository.Repository, config *config.Config) *Manager {
 	return &Manager{
 		Repository: repository,
 		Config:     config,
+		Token:      jwttoken.New(config.Auth.JWTSecret, config.Auth.TokenTTL),
+		Decorator:  newWeekendDecorator(config.Decoration),
+		now:        time.Now,
 	}
 }
@@ -6,6 +6,7 @@ package mock_service
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -37,169 +38,1689 @@ func (m *MockTodoList) EXPECT() *MockTodoListMockRecorder {
 	return m.recorder
 }
 
+// AddChecklistItem mocks base method.
+func (m *MockTodoList) AddChecklistItem(ctx context.Context, userID, id primitive.ObjectID, r *dto.ChecklistItemDTO, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", ctx, userID, id, r, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *MockTodoListMockRecorder) AddChecklistItem(ctx, userID, id, r, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*MockTodoList)(nil).AddChecklistItem), ctx, userID, id, r, version)
+}
+
+// AddDependency mocks base method.
+func (m *MockTodoList) AddDependency(ctx context.Context, userID, id, blockerID primitive.ObjectID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", ctx, userID, id, blockerID, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockTodoListMockRecorder) AddDependency(ctx, userID, id, blockerID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockTodoList)(nil).AddDependency), ctx, userID, id, blockerID, version)
+}
+
 // CreateTask mocks base method.
-func (m *MockTodoList) CreateTask(ctx context.Context, t *dto.TasksDTO) (*entity.Tasks, error) {
+func (m *MockTodoList) CreateTask(ctx context.Context, access *entity.Access, t *dto.TasksDTO) (*entity.Tasks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", ctx, t)
+	ret := m.ctrl.Call(m, "CreateTask", ctx, access, t)
 	ret0, _ := ret[0].(*entity.Tasks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTask indicates an expected call of CreateTask.
-func (mr *MockTodoListMockRecorder) CreateTask(ctx, t interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) CreateTask(ctx, access, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTodoList)(nil).CreateTask), ctx, access, t)
+}
+
+// DeleteChecklistItem mocks base method.
+func (m *MockTodoList) DeleteChecklistItem(ctx context.Context, userID, id, itemID primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", ctx, userID, id, itemID, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *MockTodoListMockRecorder) DeleteChecklistItem(ctx, userID, id, itemID, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTodoList)(nil).CreateTask), ctx, t)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*MockTodoList)(nil).DeleteChecklistItem), ctx, userID, id, itemID, version)
 }
 
 // DeleteTask mocks base method.
-func (m *MockTodoList) DeleteTask(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockTodoList) DeleteTask(ctx context.Context, userID, id primitive.ObjectID, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, userID, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTodoListMockRecorder) DeleteTask(ctx, id interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) DeleteTask(ctx, userID, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTodoList)(nil).DeleteTask), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTodoList)(nil).DeleteTask), ctx, userID, id, version)
 }
 
 // GetAllTasks mocks base method.
-func (m *MockTodoList) GetAllTasks(ctx context.Context, status string) ([]entity.Tasks, error) {
+func (m *MockTodoList) GetAllTasks(ctx context.Context, userID primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTasks", ctx, status)
-	ret0, _ := ret[0].([]entity.Tasks)
+	ret := m.ctrl.Call(m, "GetAllTasks", ctx, userID, q)
+	ret0, _ := ret[0].(*dto.TasksPageDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllTasks indicates an expected call of GetAllTasks.
-func (mr *MockTodoListMockRecorder) GetAllTasks(ctx, status interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) GetAllTasks(ctx, userID, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTodoList)(nil).GetAllTasks), ctx, userID, q)
+}
+
+// GetDependencyGraph mocks base method.
+func (m *MockTodoList) GetDependencyGraph(ctx context.Context, userID, id primitive.ObjectID) (*entity.DependencyGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependencyGraph", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.DependencyGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDependencyGraph indicates an expected call of GetDependencyGraph.
+func (mr *MockTodoListMockRecorder) GetDependencyGraph(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependencyGraph", reflect.TypeOf((*MockTodoList)(nil).GetDependencyGraph), ctx, userID, id)
+}
+
+// GetTags mocks base method.
+func (m *MockTodoList) GetTags(ctx context.Context, userID primitive.ObjectID) ([]entity.TagStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx, userID)
+	ret0, _ := ret[0].([]entity.TagStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockTodoListMockRecorder) GetTags(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockTodoList)(nil).GetTags), ctx, userID)
+}
+
+// GetTaskByID mocks base method.
+func (m *MockTodoList) GetTaskByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByID", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByID indicates an expected call of GetTaskByID.
+func (mr *MockTodoListMockRecorder) GetTaskByID(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTodoList)(nil).GetTaskByID), ctx, userID, id)
+}
+
+// GetTrash mocks base method.
+func (m *MockTodoList) GetTrash(ctx context.Context, userID primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", ctx, userID, q)
+	ret0, _ := ret[0].(*dto.TasksPageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockTodoListMockRecorder) GetTrash(ctx, userID, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockTodoList)(nil).GetTrash), ctx, userID, q)
+}
+
+// MoveTask mocks base method.
+func (m *MockTodoList) MoveTask(ctx context.Context, userID, id primitive.ObjectID, r *dto.MoveTaskDTO, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", ctx, userID, id, r, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockTodoListMockRecorder) MoveTask(ctx, userID, id, r, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockTodoList)(nil).MoveTask), ctx, userID, id, r, version)
+}
+
+// PatchTask mocks base method.
+func (m *MockTodoList) PatchTask(ctx context.Context, userID primitive.ObjectID, p *dto.TasksPatchDTO, id primitive.ObjectID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTask", ctx, userID, p, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchTask indicates an expected call of PatchTask.
+func (mr *MockTodoListMockRecorder) PatchTask(ctx, userID, p, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTask", reflect.TypeOf((*MockTodoList)(nil).PatchTask), ctx, userID, p, id, version)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockTodoList) RemoveDependency(ctx context.Context, userID, id, blockerID primitive.ObjectID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", ctx, userID, id, blockerID, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockTodoListMockRecorder) RemoveDependency(ctx, userID, id, blockerID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockTodoList)(nil).RemoveDependency), ctx, userID, id, blockerID, version)
+}
+
+// RenameTag mocks base method.
+func (m *MockTodoList) RenameTag(ctx context.Context, userID primitive.ObjectID, tag string, r *dto.RenameTagDTO) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, userID, tag, r)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockTodoListMockRecorder) RenameTag(ctx, userID, tag, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockTodoList)(nil).RenameTag), ctx, userID, tag, r)
+}
+
+// ReorderChecklist mocks base method.
+func (m *MockTodoList) ReorderChecklist(ctx context.Context, userID, id primitive.ObjectID, r *dto.ChecklistOrderDTO, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderChecklist", ctx, userID, id, r, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderChecklist indicates an expected call of ReorderChecklist.
+func (mr *MockTodoListMockRecorder) ReorderChecklist(ctx, userID, id, r, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderChecklist", reflect.TypeOf((*MockTodoList)(nil).ReorderChecklist), ctx, userID, id, r, version)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTodoList) RestoreTask(ctx context.Context, userID, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTodoListMockRecorder) RestoreTask(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTodoList)(nil).RestoreTask), ctx, userID, id)
+}
+
+// SearchTasks mocks base method.
+func (m *MockTodoList) SearchTasks(ctx context.Context, userID primitive.ObjectID, q *dto.TasksSearchDTO) (*dto.TasksPageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTasks", ctx, userID, q)
+	ret0, _ := ret[0].(*dto.TasksPageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTasks indicates an expected call of SearchTasks.
+func (mr *MockTodoListMockRecorder) SearchTasks(ctx, userID, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTasks", reflect.TypeOf((*MockTodoList)(nil).SearchTasks), ctx, userID, q)
+}
+
+// SetRecurrence mocks base method.
+func (m *MockTodoList) SetRecurrence(ctx context.Context, userID, id primitive.ObjectID, r *dto.RecurrenceDTO, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecurrence", ctx, userID, id, r, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecurrence indicates an expected call of SetRecurrence.
+func (mr *MockTodoListMockRecorder) SetRecurrence(ctx, userID, id, r, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecurrence", reflect.TypeOf((*MockTodoList)(nil).SetRecurrence), ctx, userID, id, r, version)
+}
+
+// StopRecurrence mocks base method.
+func (m *MockTodoList) StopRecurrence(ctx context.Context, userID, id primitive.ObjectID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopRecurrence", ctx, userID, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopRecurrence indicates an expected call of StopRecurrence.
+func (mr *MockTodoListMockRecorder) StopRecurrence(ctx, userID, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopRecurrence", reflect.TypeOf((*MockTodoList)(nil).StopRecurrence), ctx, userID, id, version)
+}
+
+// UpdateChecklistItem mocks base method.
+func (m *MockTodoList) UpdateChecklistItem(ctx context.Context, userID, id, itemID primitive.ObjectID, p *dto.ChecklistItemPatchDTO, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklistItem", ctx, userID, id, itemID, p, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChecklistItem indicates an expected call of UpdateChecklistItem.
+func (mr *MockTodoListMockRecorder) UpdateChecklistItem(ctx, userID, id, itemID, p, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTodoList)(nil).GetAllTasks), ctx, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklistItem", reflect.TypeOf((*MockTodoList)(nil).UpdateChecklistItem), ctx, userID, id, itemID, p, version)
 }
 
 // UpdateTask mocks base method.
-func (m *MockTodoList) UpdateTask(ctx context.Context, t *dto.TasksDTO, id primitive.ObjectID) error {
+func (m *MockTodoList) UpdateTask(ctx context.Context, userID primitive.ObjectID, t *dto.TasksDTO, id primitive.ObjectID, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", ctx, t, id)
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, userID, t, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTodoListMockRecorder) UpdateTask(ctx, t, id interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) UpdateTask(ctx, userID, t, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTodoList)(nil).UpdateTask), ctx, t, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTodoList)(nil).UpdateTask), ctx, userID, t, id, version)
 }
 
 // UpdateTaskStatus mocks base method.
-func (m *MockTodoList) UpdateTaskStatus(ctx context.Context, id primitive.ObjectID, status string) error {
+func (m *MockTodoList) UpdateTaskStatus(ctx context.Context, userID, id primitive.ObjectID, status string, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, id, status)
+	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, userID, id, status, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTaskStatus indicates an expected call of UpdateTaskStatus.
-func (mr *MockTodoListMockRecorder) UpdateTaskStatus(ctx, id, status interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) UpdateTaskStatus(ctx, userID, id, status, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockTodoList)(nil).UpdateTaskStatus), ctx, id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockTodoList)(nil).UpdateTaskStatus), ctx, userID, id, status, version)
 }
 
-// MockService is a mock of Service interface.
-type MockService struct {
+// MockList is a mock of List interface.
+type MockList struct {
 	ctrl     *gomock.Controller
-	recorder *MockServiceMockRecorder
+	recorder *MockListMockRecorder
 }
 
-// MockServiceMockRecorder is the mock recorder for MockService.
-type MockServiceMockRecorder struct {
-	mock *MockService
+// MockListMockRecorder is the mock recorder for MockList.
+type MockListMockRecorder struct {
+	mock *MockList
 }
 
-// NewMockService creates a new mock instance.
-func NewMockService(ctrl *gomock.Controller) *MockService {
-	mock := &MockService{ctrl: ctrl}
-	mock.recorder = &MockServiceMockRecorder{mock}
+// NewMockList creates a new mock instance.
+func NewMockList(ctrl *gomock.Controller) *MockList {
+	mock := &MockList{ctrl: ctrl}
+	mock.recorder = &MockListMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockService) EXPECT() *MockServiceMockRecorder {
+func (m *MockList) EXPECT() *MockListMockRecorder {
 	return m.recorder
 }
 
-// CreateTask mocks base method.
-func (m *MockService) CreateTask(ctx context.Context, t *dto.TasksDTO) (*entity.Tasks, error) {
+// CreateList mocks base method.
+func (m *MockList) CreateList(ctx context.Context, userID primitive.ObjectID, l *dto.ListDTO) (*entity.List, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", ctx, t)
-	ret0, _ := ret[0].(*entity.Tasks)
+	ret := m.ctrl.Call(m, "CreateList", ctx, userID, l)
+	ret0, _ := ret[0].(*entity.List)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTask indicates an expected call of CreateTask.
-func (mr *MockServiceMockRecorder) CreateTask(ctx, t interface{}) *gomock.Call {
+// CreateList indicates an expected call of CreateList.
+func (mr *MockListMockRecorder) CreateList(ctx, userID, l interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockService)(nil).CreateTask), ctx, t)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateList", reflect.TypeOf((*MockList)(nil).CreateList), ctx, userID, l)
 }
 
-// DeleteTask mocks base method.
-func (m *MockService) DeleteTask(ctx context.Context, id primitive.ObjectID) error {
+// DeleteList mocks base method.
+func (m *MockList) DeleteList(ctx context.Context, userID, id primitive.ObjectID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteList", ctx, userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockServiceMockRecorder) DeleteTask(ctx, id interface{}) *gomock.Call {
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockListMockRecorder) DeleteList(ctx, userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockService)(nil).DeleteTask), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockList)(nil).DeleteList), ctx, userID, id)
 }
 
-// GetAllTasks mocks base method.
-func (m *MockService) GetAllTasks(ctx context.Context, status string) ([]entity.Tasks, error) {
+// GetListByID mocks base method.
+func (m *MockList) GetListByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.List, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTasks", ctx, status)
-	ret0, _ := ret[0].([]entity.Tasks)
+	ret := m.ctrl.Call(m, "GetListByID", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.List)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllTasks indicates an expected call of GetAllTasks.
-func (mr *MockServiceMockRecorder) GetAllTasks(ctx, status interface{}) *gomock.Call {
+// GetListByID indicates an expected call of GetListByID.
+func (mr *MockListMockRecorder) GetListByID(ctx, userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockService)(nil).GetAllTasks), ctx, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListByID", reflect.TypeOf((*MockList)(nil).GetListByID), ctx, userID, id)
 }
 
-// UpdateTask mocks base method.
-func (m *MockService) UpdateTask(ctx context.Context, t *dto.TasksDTO, id primitive.ObjectID) error {
+// GetListTasks mocks base method.
+func (m *MockList) GetListTasks(ctx context.Context, userID, id primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", ctx, t, id)
+	ret := m.ctrl.Call(m, "GetListTasks", ctx, userID, id, q)
+	ret0, _ := ret[0].(*dto.TasksPageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListTasks indicates an expected call of GetListTasks.
+func (mr *MockListMockRecorder) GetListTasks(ctx, userID, id, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListTasks", reflect.TypeOf((*MockList)(nil).GetListTasks), ctx, userID, id, q)
+}
+
+// GetLists mocks base method.
+func (m *MockList) GetLists(ctx context.Context, userID primitive.ObjectID) ([]entity.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLists", ctx, userID)
+	ret0, _ := ret[0].([]entity.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLists indicates an expected call of GetLists.
+func (mr *MockListMockRecorder) GetLists(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockList)(nil).GetLists), ctx, userID)
+}
+
+// UpdateList mocks base method.
+func (m *MockList) UpdateList(ctx context.Context, userID, id primitive.ObjectID, l *dto.ListDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateList", ctx, userID, id, l)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockServiceMockRecorder) UpdateTask(ctx, t, id interface{}) *gomock.Call {
+// UpdateList indicates an expected call of UpdateList.
+func (mr *MockListMockRecorder) UpdateList(ctx, userID, id, l interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockService)(nil).UpdateTask), ctx, t, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateList", reflect.TypeOf((*MockList)(nil).UpdateList), ctx, userID, id, l)
 }
 
-// UpdateTaskStatus mocks base method.
-func (m *MockService) UpdateTaskStatus(ctx context.Context, id primitive.ObjectID, status string) error {
+// MockWorkspace is a mock of Workspace interface.
+type MockWorkspace struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceMockRecorder
+}
+
+// MockWorkspaceMockRecorder is the mock recorder for MockWorkspace.
+type MockWorkspaceMockRecorder struct {
+	mock *MockWorkspace
+}
+
+// NewMockWorkspace creates a new mock instance.
+func NewMockWorkspace(ctrl *gomock.Controller) *MockWorkspace {
+	mock := &MockWorkspace{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspace) EXPECT() *MockWorkspaceMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockWorkspace) AcceptInvitation(ctx context.Context, userID primitive.ObjectID, token string) (*entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, userID, token)
+	ret0, _ := ret[0].(*entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockWorkspaceMockRecorder) AcceptInvitation(ctx, userID, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockWorkspace)(nil).AcceptInvitation), ctx, userID, token)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockWorkspace) CreateInvitation(ctx context.Context, userID, id primitive.ObjectID, r *dto.InvitationDTO) (*entity.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, userID, id, r)
+	ret0, _ := ret[0].(*entity.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockWorkspaceMockRecorder) CreateInvitation(ctx, userID, id, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockWorkspace)(nil).CreateInvitation), ctx, userID, id, r)
+}
+
+// CreateWorkspace mocks base method.
+func (m *MockWorkspace) CreateWorkspace(ctx context.Context, userID primitive.ObjectID, w *dto.WorkspaceDTO) (*entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", ctx, userID, w)
+	ret0, _ := ret[0].(*entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockWorkspaceMockRecorder) CreateWorkspace(ctx, userID, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockWorkspace)(nil).CreateWorkspace), ctx, userID, w)
+}
+
+// DeleteWorkspace mocks base method.
+func (m *MockWorkspace) DeleteWorkspace(ctx context.Context, userID, id primitive.ObjectID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, id, status)
+	ret := m.ctrl.Call(m, "DeleteWorkspace", ctx, userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateTaskStatus indicates an expected call of UpdateTaskStatus.
-func (mr *MockServiceMockRecorder) UpdateTaskStatus(ctx, id, status interface{}) *gomock.Call {
+// DeleteWorkspace indicates an expected call of DeleteWorkspace.
+func (mr *MockWorkspaceMockRecorder) DeleteWorkspace(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspace", reflect.TypeOf((*MockWorkspace)(nil).DeleteWorkspace), ctx, userID, id)
+}
+
+// GetAccess mocks base method.
+func (m *MockWorkspace) GetAccess(ctx context.Context, userID, workspace primitive.ObjectID) (*entity.Access, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccess", ctx, userID, workspace)
+	ret0, _ := ret[0].(*entity.Access)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccess indicates an expected call of GetAccess.
+func (mr *MockWorkspaceMockRecorder) GetAccess(ctx, userID, workspace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccess", reflect.TypeOf((*MockWorkspace)(nil).GetAccess), ctx, userID, workspace)
+}
+
+// GetMembers mocks base method.
+func (m *MockWorkspace) GetMembers(ctx context.Context, userID, id primitive.ObjectID) ([]entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, userID, id)
+	ret0, _ := ret[0].([]entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockWorkspaceMockRecorder) GetMembers(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockWorkspace)(nil).GetMembers), ctx, userID, id)
+}
+
+// GetWorkspaceByID mocks base method.
+func (m *MockWorkspace) GetWorkspaceByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceByID", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceByID indicates an expected call of GetWorkspaceByID.
+func (mr *MockWorkspaceMockRecorder) GetWorkspaceByID(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceByID", reflect.TypeOf((*MockWorkspace)(nil).GetWorkspaceByID), ctx, userID, id)
+}
+
+// GetWorkspaces mocks base method.
+func (m *MockWorkspace) GetWorkspaces(ctx context.Context, userID primitive.ObjectID) ([]entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaces", ctx, userID)
+	ret0, _ := ret[0].([]entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaces indicates an expected call of GetWorkspaces.
+func (mr *MockWorkspaceMockRecorder) GetWorkspaces(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaces", reflect.TypeOf((*MockWorkspace)(nil).GetWorkspaces), ctx, userID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockWorkspace) RemoveMember(ctx context.Context, userID, id, memberID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, userID, id, memberID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockWorkspaceMockRecorder) RemoveMember(ctx, userID, id, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockWorkspace)(nil).RemoveMember), ctx, userID, id, memberID)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockWorkspace) UpdateMemberRole(ctx context.Context, userID, id, memberID primitive.ObjectID, r *dto.MemberRoleDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", ctx, userID, id, memberID, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockWorkspaceMockRecorder) UpdateMemberRole(ctx, userID, id, memberID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockWorkspace)(nil).UpdateMemberRole), ctx, userID, id, memberID, r)
+}
+
+// UpdateWorkspace mocks base method.
+func (m *MockWorkspace) UpdateWorkspace(ctx context.Context, userID, id primitive.ObjectID, w *dto.WorkspaceDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspace", ctx, userID, id, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspace indicates an expected call of UpdateWorkspace.
+func (mr *MockWorkspaceMockRecorder) UpdateWorkspace(ctx, userID, id, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockWorkspace)(nil).UpdateWorkspace), ctx, userID, id, w)
+}
+
+// MockComment is a mock of Comment interface.
+type MockComment struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentMockRecorder
+}
+
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
+}
+
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockComment) CreateComment(ctx context.Context, access *entity.Access, taskID primitive.ObjectID, r *dto.CommentDTO) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, access, taskID, r)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentMockRecorder) CreateComment(ctx, access, taskID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockComment)(nil).CreateComment), ctx, access, taskID, r)
+}
+
+// DeleteComment mocks base method.
+func (m *MockComment) DeleteComment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, access, taskID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentMockRecorder) DeleteComment(ctx, access, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockComment)(nil).DeleteComment), ctx, access, taskID, id)
+}
+
+// GetComments mocks base method.
+func (m *MockComment) GetComments(ctx context.Context, access *entity.Access, taskID primitive.ObjectID) ([]entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, access, taskID)
+	ret0, _ := ret[0].([]entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentMockRecorder) GetComments(ctx, access, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockComment)(nil).GetComments), ctx, access, taskID)
+}
+
+// UpdateComment mocks base method.
+func (m *MockComment) UpdateComment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID, r *dto.CommentDTO) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, access, taskID, id, r)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockCommentMockRecorder) UpdateComment(ctx, access, taskID, id, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockComment)(nil).UpdateComment), ctx, access, taskID, id, r)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachment) DeleteAttachment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, access, taskID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentMockRecorder) DeleteAttachment(ctx, access, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachment)(nil).DeleteAttachment), ctx, access, taskID, id)
+}
+
+// GetAttachmentContent mocks base method.
+func (m *MockAttachment) GetAttachmentContent(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) (*entity.Attachment, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentContent", ctx, access, taskID, id)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAttachmentContent indicates an expected call of GetAttachmentContent.
+func (mr *MockAttachmentMockRecorder) GetAttachmentContent(ctx, access, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentContent", reflect.TypeOf((*MockAttachment)(nil).GetAttachmentContent), ctx, access, taskID, id)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAttachment) GetAttachments(ctx context.Context, access *entity.Access, taskID primitive.ObjectID) ([]entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, access, taskID)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAttachmentMockRecorder) GetAttachments(ctx, access, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAttachment)(nil).GetAttachments), ctx, access, taskID)
+}
+
+// UploadAttachment mocks base method.
+func (m *MockAttachment) UploadAttachment(ctx context.Context, access *entity.Access, taskID primitive.ObjectID, name string, size int64, r io.Reader) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", ctx, access, taskID, name, size, r)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment.
+func (mr *MockAttachmentMockRecorder) UploadAttachment(ctx, access, taskID, name, size, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockAttachment)(nil).UploadAttachment), ctx, access, taskID, name, size, r)
+}
+
+// MockHistory is a mock of History interface.
+type MockHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryMockRecorder
+}
+
+// MockHistoryMockRecorder is the mock recorder for MockHistory.
+type MockHistoryMockRecorder struct {
+	mock *MockHistory
+}
+
+// NewMockHistory creates a new mock instance.
+func NewMockHistory(ctrl *gomock.Controller) *MockHistory {
+	mock := &MockHistory{ctrl: ctrl}
+	mock.recorder = &MockHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistory) EXPECT() *MockHistoryMockRecorder {
+	return m.recorder
+}
+
+// GetHistory mocks base method.
+func (m *MockHistory) GetHistory(ctx context.Context, userID, id primitive.ObjectID) ([]entity.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, userID, id)
+	ret0, _ := ret[0].([]entity.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockHistoryMockRecorder) GetHistory(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockHistory)(nil).GetHistory), ctx, userID, id)
+}
+
+// RevertTask mocks base method.
+func (m *MockHistory) RevertTask(ctx context.Context, userID, id primitive.ObjectID, target, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertTask", ctx, userID, id, target, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertTask indicates an expected call of RevertTask.
+func (mr *MockHistoryMockRecorder) RevertTask(ctx, userID, id, target, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertTask", reflect.TypeOf((*MockHistory)(nil).RevertTask), ctx, userID, id, target, version)
+}
+
+// MockAuth is a mock of Auth interface.
+type MockAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthMockRecorder
+}
+
+// MockAuthMockRecorder is the mock recorder for MockAuth.
+type MockAuthMockRecorder struct {
+	mock *MockAuth
+}
+
+// NewMockAuth creates a new mock instance.
+func NewMockAuth(ctrl *gomock.Controller) *MockAuth {
+	mock := &MockAuth{ctrl: ctrl}
+	mock.recorder = &MockAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuth) EXPECT() *MockAuthMockRecorder {
+	return m.recorder
+}
+
+// ParseToken mocks base method.
+func (m *MockAuth) ParseToken(ctx context.Context, token string) (primitive.ObjectID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", ctx, token)
+	ret0, _ := ret[0].(primitive.ObjectID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockAuthMockRecorder) ParseToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAuth)(nil).ParseToken), ctx, token)
+}
+
+// SignIn mocks base method.
+func (m *MockAuth) SignIn(ctx context.Context, u *dto.UserDTO) (*dto.TokenDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", ctx, u)
+	ret0, _ := ret[0].(*dto.TokenDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockAuthMockRecorder) SignIn(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockAuth)(nil).SignIn), ctx, u)
+}
+
+// SignUp mocks base method.
+func (m *MockAuth) SignUp(ctx context.Context, u *dto.UserDTO) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignUp", ctx, u)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignUp indicates an expected call of SignUp.
+func (mr *MockAuthMockRecorder) SignUp(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockAuth)(nil).SignUp), ctx, u)
+}
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockService) AcceptInvitation(ctx context.Context, userID primitive.ObjectID, token string) (*entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, userID, token)
+	ret0, _ := ret[0].(*entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockServiceMockRecorder) AcceptInvitation(ctx, userID, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockService)(nil).AcceptInvitation), ctx, userID, token)
+}
+
+// AddChecklistItem mocks base method.
+func (m *MockService) AddChecklistItem(ctx context.Context, userID, id primitive.ObjectID, r *dto.ChecklistItemDTO, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", ctx, userID, id, r, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *MockServiceMockRecorder) AddChecklistItem(ctx, userID, id, r, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*MockService)(nil).AddChecklistItem), ctx, userID, id, r, version)
+}
+
+// AddDependency mocks base method.
+func (m *MockService) AddDependency(ctx context.Context, userID, id, blockerID primitive.ObjectID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", ctx, userID, id, blockerID, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockServiceMockRecorder) AddDependency(ctx, userID, id, blockerID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockService)(nil).AddDependency), ctx, userID, id, blockerID, version)
+}
+
+// CreateComment mocks base method.
+func (m *MockService) CreateComment(ctx context.Context, access *entity.Access, taskID primitive.ObjectID, r *dto.CommentDTO) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, access, taskID, r)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockServiceMockRecorder) CreateComment(ctx, access, taskID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockService)(nil).CreateComment), ctx, access, taskID, r)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockService) CreateInvitation(ctx context.Context, userID, id primitive.ObjectID, r *dto.InvitationDTO) (*entity.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, userID, id, r)
+	ret0, _ := ret[0].(*entity.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockServiceMockRecorder) CreateInvitation(ctx, userID, id, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockService)(nil).CreateInvitation), ctx, userID, id, r)
+}
+
+// CreateList mocks base method.
+func (m *MockService) CreateList(ctx context.Context, userID primitive.ObjectID, l *dto.ListDTO) (*entity.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateList", ctx, userID, l)
+	ret0, _ := ret[0].(*entity.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateList indicates an expected call of CreateList.
+func (mr *MockServiceMockRecorder) CreateList(ctx, userID, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateList", reflect.TypeOf((*MockService)(nil).CreateList), ctx, userID, l)
+}
+
+// CreateTask mocks base method.
+func (m *MockService) CreateTask(ctx context.Context, access *entity.Access, t *dto.TasksDTO) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTask", ctx, access, t)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockServiceMockRecorder) CreateTask(ctx, access, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockService)(nil).CreateTask), ctx, access, t)
+}
+
+// CreateWorkspace mocks base method.
+func (m *MockService) CreateWorkspace(ctx context.Context, userID primitive.ObjectID, w *dto.WorkspaceDTO) (*entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", ctx, userID, w)
+	ret0, _ := ret[0].(*entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockServiceMockRecorder) CreateWorkspace(ctx, userID, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockService)(nil).CreateWorkspace), ctx, userID, w)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockService) DeleteAttachment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, access, taskID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockServiceMockRecorder) DeleteAttachment(ctx, access, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockService)(nil).DeleteAttachment), ctx, access, taskID, id)
+}
+
+// DeleteChecklistItem mocks base method.
+func (m *MockService) DeleteChecklistItem(ctx context.Context, userID, id, itemID primitive.ObjectID, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", ctx, userID, id, itemID, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *MockServiceMockRecorder) DeleteChecklistItem(ctx, userID, id, itemID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*MockService)(nil).DeleteChecklistItem), ctx, userID, id, itemID, version)
+}
+
+// DeleteComment mocks base method.
+func (m *MockService) DeleteComment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, access, taskID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockServiceMockRecorder) DeleteComment(ctx, access, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockService)(nil).DeleteComment), ctx, access, taskID, id)
+}
+
+// DeleteList mocks base method.
+func (m *MockService) DeleteList(ctx context.Context, userID, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteList", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockServiceMockRecorder) DeleteList(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockService)(nil).DeleteList), ctx, userID, id)
+}
+
+// DeleteTask mocks base method.
+func (m *MockService) DeleteTask(ctx context.Context, userID, id primitive.ObjectID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, userID, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockServiceMockRecorder) DeleteTask(ctx, userID, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockService)(nil).DeleteTask), ctx, userID, id, version)
+}
+
+// DeleteWorkspace mocks base method.
+func (m *MockService) DeleteWorkspace(ctx context.Context, userID, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspace", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspace indicates an expected call of DeleteWorkspace.
+func (mr *MockServiceMockRecorder) DeleteWorkspace(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspace", reflect.TypeOf((*MockService)(nil).DeleteWorkspace), ctx, userID, id)
+}
+
+// GetAccess mocks base method.
+func (m *MockService) GetAccess(ctx context.Context, userID, workspace primitive.ObjectID) (*entity.Access, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccess", ctx, userID, workspace)
+	ret0, _ := ret[0].(*entity.Access)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccess indicates an expected call of GetAccess.
+func (mr *MockServiceMockRecorder) GetAccess(ctx, userID, workspace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccess", reflect.TypeOf((*MockService)(nil).GetAccess), ctx, userID, workspace)
+}
+
+// GetAllTasks mocks base method.
+func (m *MockService) GetAllTasks(ctx context.Context, userID primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTasks", ctx, userID, q)
+	ret0, _ := ret[0].(*dto.TasksPageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTasks indicates an expected call of GetAllTasks.
+func (mr *MockServiceMockRecorder) GetAllTasks(ctx, userID, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockService)(nil).GetAllTasks), ctx, userID, q)
+}
+
+// GetAttachmentContent mocks base method.
+func (m *MockService) GetAttachmentContent(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) (*entity.Attachment, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentContent", ctx, access, taskID, id)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAttachmentContent indicates an expected call of GetAttachmentContent.
+func (mr *MockServiceMockRecorder) GetAttachmentContent(ctx, access, taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentContent", reflect.TypeOf((*MockService)(nil).GetAttachmentContent), ctx, access, taskID, id)
+}
+
+// GetAttachments mocks base method.
+func (m *MockService) GetAttachments(ctx context.Context, access *entity.Access, taskID primitive.ObjectID) ([]entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, access, taskID)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockServiceMockRecorder) GetAttachments(ctx, access, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockService)(nil).GetAttachments), ctx, access, taskID)
+}
+
+// GetComments mocks base method.
+func (m *MockService) GetComments(ctx context.Context, access *entity.Access, taskID primitive.ObjectID) ([]entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, access, taskID)
+	ret0, _ := ret[0].([]entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockServiceMockRecorder) GetComments(ctx, access, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockService)(nil).GetComments), ctx, access, taskID)
+}
+
+// GetDependencyGraph mocks base method.
+func (m *MockService) GetDependencyGraph(ctx context.Context, userID, id primitive.ObjectID) (*entity.DependencyGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependencyGraph", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.DependencyGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDependencyGraph indicates an expected call of GetDependencyGraph.
+func (mr *MockServiceMockRecorder) GetDependencyGraph(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependencyGraph", reflect.TypeOf((*MockService)(nil).GetDependencyGraph), ctx, userID, id)
+}
+
+// GetHistory mocks base method.
+func (m *MockService) GetHistory(ctx context.Context, userID, id primitive.ObjectID) ([]entity.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, userID, id)
+	ret0, _ := ret[0].([]entity.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockServiceMockRecorder) GetHistory(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockService)(nil).GetHistory), ctx, userID, id)
+}
+
+// GetListByID mocks base method.
+func (m *MockService) GetListByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListByID", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListByID indicates an expected call of GetListByID.
+func (mr *MockServiceMockRecorder) GetListByID(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListByID", reflect.TypeOf((*MockService)(nil).GetListByID), ctx, userID, id)
+}
+
+// GetListTasks mocks base method.
+func (m *MockService) GetListTasks(ctx context.Context, userID, id primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListTasks", ctx, userID, id, q)
+	ret0, _ := ret[0].(*dto.TasksPageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListTasks indicates an expected call of GetListTasks.
+func (mr *MockServiceMockRecorder) GetListTasks(ctx, userID, id, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListTasks", reflect.TypeOf((*MockService)(nil).GetListTasks), ctx, userID, id, q)
+}
+
+// GetLists mocks base method.
+func (m *MockService) GetLists(ctx context.Context, userID primitive.ObjectID) ([]entity.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLists", ctx, userID)
+	ret0, _ := ret[0].([]entity.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLists indicates an expected call of GetLists.
+func (mr *MockServiceMockRecorder) GetLists(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockService)(nil).GetLists), ctx, userID)
+}
+
+// GetMembers mocks base method.
+func (m *MockService) GetMembers(ctx context.Context, userID, id primitive.ObjectID) ([]entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, userID, id)
+	ret0, _ := ret[0].([]entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockServiceMockRecorder) GetMembers(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockService)(nil).GetMembers), ctx, userID, id)
+}
+
+// GetTags mocks base method.
+func (m *MockService) GetTags(ctx context.Context, userID primitive.ObjectID) ([]entity.TagStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx, userID)
+	ret0, _ := ret[0].([]entity.TagStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockServiceMockRecorder) GetTags(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockService)(nil).GetTags), ctx, userID)
+}
+
+// GetTaskByID mocks base method.
+func (m *MockService) GetTaskByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByID", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByID indicates an expected call of GetTaskByID.
+func (mr *MockServiceMockRecorder) GetTaskByID(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockService)(nil).GetTaskByID), ctx, userID, id)
+}
+
+// GetTrash mocks base method.
+func (m *MockService) GetTrash(ctx context.Context, userID primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", ctx, userID, q)
+	ret0, _ := ret[0].(*dto.TasksPageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockServiceMockRecorder) GetTrash(ctx, userID, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockService)(nil).GetTrash), ctx, userID, q)
+}
+
+// GetWorkspaceByID mocks base method.
+func (m *MockService) GetWorkspaceByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceByID", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceByID indicates an expected call of GetWorkspaceByID.
+func (mr *MockServiceMockRecorder) GetWorkspaceByID(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceByID", reflect.TypeOf((*MockService)(nil).GetWorkspaceByID), ctx, userID, id)
+}
+
+// GetWorkspaces mocks base method.
+func (m *MockService) GetWorkspaces(ctx context.Context, userID primitive.ObjectID) ([]entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaces", ctx, userID)
+	ret0, _ := ret[0].([]entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaces indicates an expected call of GetWorkspaces.
+func (mr *MockServiceMockRecorder) GetWorkspaces(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaces", reflect.TypeOf((*MockService)(nil).GetWorkspaces), ctx, userID)
+}
+
+// MoveTask mocks base method.
+func (m *MockService) MoveTask(ctx context.Context, userID, id primitive.ObjectID, r *dto.MoveTaskDTO, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", ctx, userID, id, r, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockServiceMockRecorder) MoveTask(ctx, userID, id, r, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockService)(nil).MoveTask), ctx, userID, id, r, version)
+}
+
+// ParseToken mocks base method.
+func (m *MockService) ParseToken(ctx context.Context, token string) (primitive.ObjectID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", ctx, token)
+	ret0, _ := ret[0].(primitive.ObjectID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockServiceMockRecorder) ParseToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockService)(nil).ParseToken), ctx, token)
+}
+
+// PatchTask mocks base method.
+func (m *MockService) PatchTask(ctx context.Context, userID primitive.ObjectID, p *dto.TasksPatchDTO, id primitive.ObjectID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTask", ctx, userID, p, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchTask indicates an expected call of PatchTask.
+func (mr *MockServiceMockRecorder) PatchTask(ctx, userID, p, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTask", reflect.TypeOf((*MockService)(nil).PatchTask), ctx, userID, p, id, version)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockService) RemoveDependency(ctx context.Context, userID, id, blockerID primitive.ObjectID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", ctx, userID, id, blockerID, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockServiceMockRecorder) RemoveDependency(ctx, userID, id, blockerID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockService)(nil).RemoveDependency), ctx, userID, id, blockerID, version)
+}
+
+// RemoveMember mocks base method.
+func (m *MockService) RemoveMember(ctx context.Context, userID, id, memberID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, userID, id, memberID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockServiceMockRecorder) RemoveMember(ctx, userID, id, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockService)(nil).RemoveMember), ctx, userID, id, memberID)
+}
+
+// RenameTag mocks base method.
+func (m *MockService) RenameTag(ctx context.Context, userID primitive.ObjectID, tag string, r *dto.RenameTagDTO) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, userID, tag, r)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockServiceMockRecorder) RenameTag(ctx, userID, tag, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockService)(nil).RenameTag), ctx, userID, tag, r)
+}
+
+// ReorderChecklist mocks base method.
+func (m *MockService) ReorderChecklist(ctx context.Context, userID, id primitive.ObjectID, r *dto.ChecklistOrderDTO, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderChecklist", ctx, userID, id, r, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderChecklist indicates an expected call of ReorderChecklist.
+func (mr *MockServiceMockRecorder) ReorderChecklist(ctx, userID, id, r, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderChecklist", reflect.TypeOf((*MockService)(nil).ReorderChecklist), ctx, userID, id, r, version)
+}
+
+// RestoreTask mocks base method.
+func (m *MockService) RestoreTask(ctx context.Context, userID, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockServiceMockRecorder) RestoreTask(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockService)(nil).RestoreTask), ctx, userID, id)
+}
+
+// RevertTask mocks base method.
+func (m *MockService) RevertTask(ctx context.Context, userID, id primitive.ObjectID, target, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertTask", ctx, userID, id, target, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertTask indicates an expected call of RevertTask.
+func (mr *MockServiceMockRecorder) RevertTask(ctx, userID, id, target, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertTask", reflect.TypeOf((*MockService)(nil).RevertTask), ctx, userID, id, target, version)
+}
+
+// SearchTasks mocks base method.
+func (m *MockService) SearchTasks(ctx context.Context, userID primitive.ObjectID, q *dto.TasksSearchDTO) (*dto.TasksPageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTasks", ctx, userID, q)
+	ret0, _ := ret[0].(*dto.TasksPageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTasks indicates an expected call of SearchTasks.
+func (mr *MockServiceMockRecorder) SearchTasks(ctx, userID, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTasks", reflect.TypeOf((*MockService)(nil).SearchTasks), ctx, userID, q)
+}
+
+// SetRecurrence mocks base method.
+func (m *MockService) SetRecurrence(ctx context.Context, userID, id primitive.ObjectID, r *dto.RecurrenceDTO, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecurrence", ctx, userID, id, r, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecurrence indicates an expected call of SetRecurrence.
+func (mr *MockServiceMockRecorder) SetRecurrence(ctx, userID, id, r, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecurrence", reflect.TypeOf((*MockService)(nil).SetRecurrence), ctx, userID, id, r, version)
+}
+
+// SignIn mocks base method.
+func (m *MockService) SignIn(ctx context.Context, u *dto.UserDTO) (*dto.TokenDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", ctx, u)
+	ret0, _ := ret[0].(*dto.TokenDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockServiceMockRecorder) SignIn(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockService)(nil).SignIn), ctx, u)
+}
+
+// SignUp mocks base method.
+func (m *MockService) SignUp(ctx context.Context, u *dto.UserDTO) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignUp", ctx, u)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignUp indicates an expected call of SignUp.
+func (mr *MockServiceMockRecorder) SignUp(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockService)(nil).SignUp), ctx, u)
+}
+
+// StopRecurrence mocks base method.
+func (m *MockService) StopRecurrence(ctx context.Context, userID, id primitive.ObjectID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopRecurrence", ctx, userID, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopRecurrence indicates an expected call of StopRecurrence.
+func (mr *MockServiceMockRecorder) StopRecurrence(ctx, userID, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopRecurrence", reflect.TypeOf((*MockService)(nil).StopRecurrence), ctx, userID, id, version)
+}
+
+// UpdateChecklistItem mocks base method.
+func (m *MockService) UpdateChecklistItem(ctx context.Context, userID, id, itemID primitive.ObjectID, p *dto.ChecklistItemPatchDTO, version int64) (*entity.Tasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklistItem", ctx, userID, id, itemID, p, version)
+	ret0, _ := ret[0].(*entity.Tasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChecklistItem indicates an expected call of UpdateChecklistItem.
+func (mr *MockServiceMockRecorder) UpdateChecklistItem(ctx, userID, id, itemID, p, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklistItem", reflect.TypeOf((*MockService)(nil).UpdateChecklistItem), ctx, userID, id, itemID, p, version)
+}
+
+// UpdateComment mocks base method.
+func (m *MockService) UpdateComment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID, r *dto.CommentDTO) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, access, taskID, id, r)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockServiceMockRecorder) UpdateComment(ctx, access, taskID, id, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockService)(nil).UpdateComment), ctx, access, taskID, id, r)
+}
+
+// UpdateList mocks base method.
+func (m *MockService) UpdateList(ctx context.Context, userID, id primitive.ObjectID, l *dto.ListDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateList", ctx, userID, id, l)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateList indicates an expected call of UpdateList.
+func (mr *MockServiceMockRecorder) UpdateList(ctx, userID, id, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateList", reflect.TypeOf((*MockService)(nil).UpdateList), ctx, userID, id, l)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockService) UpdateMemberRole(ctx context.Context, userID, id, memberID primitive.ObjectID, r *dto.MemberRoleDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", ctx, userID, id, memberID, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockServiceMockRecorder) UpdateMemberRole(ctx, userID, id, memberID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockService)(nil).UpdateMemberRole), ctx, userID, id, memberID, r)
+}
+
+// UpdateTask mocks base method.
+func (m *MockService) UpdateTask(ctx context.Context, userID primitive.ObjectID, t *dto.TasksDTO, id primitive.ObjectID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, userID, t, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockServiceMockRecorder) UpdateTask(ctx, userID, t, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockService)(nil).UpdateTask), ctx, userID, t, id, version)
+}
+
+// UpdateTaskStatus mocks base method.
+func (m *MockService) UpdateTaskStatus(ctx context.Context, userID, id primitive.ObjectID, status string, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, userID, id, status, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaskStatus indicates an expected call of UpdateTaskStatus.
+func (mr *MockServiceMockRecorder) UpdateTaskStatus(ctx, userID, id, status, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockService)(nil).UpdateTaskStatus), ctx, userID, id, status, version)
+}
+
+// UpdateWorkspace mocks base method.
+func (m *MockService) UpdateWorkspace(ctx context.Context, userID, id primitive.ObjectID, w *dto.WorkspaceDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspace", ctx, userID, id, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspace indicates an expected call of UpdateWorkspace.
+func (mr *MockServiceMockRecorder) UpdateWorkspace(ctx, userID, id, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockService)(nil).UpdateWorkspace), ctx, userID, id, w)
+}
+
+// UploadAttachment mocks base method.
+func (m *MockService) UploadAttachment(ctx context.Context, access *entity.Access, taskID primitive.ObjectID, name string, size int64, r io.Reader) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", ctx, access, taskID, name, size, r)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment.
+func (mr *MockServiceMockRecorder) UploadAttachment(ctx, access, taskID, name, size, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockService)(nil).UpdateTaskStatus), ctx, id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockService)(nil).UploadAttachment), ctx, access, taskID, name, size, r)
 }
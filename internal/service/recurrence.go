@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"log"
+)
+
+// SetRecurrence задает или меняет правило повторения задачи. Правило действует
+// с ее activeAt и переходит к следующим повторениям серии
+func (m *Manager) SetRecurrence(ctx context.Context, userID, id primitive.ObjectID, r *dto.RecurrenceDTO, version int64) error {
+	rule, err := parseRecurrence(r.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	task, err := m.Repository.GetTaskByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if version > 0 && task.Version != version {
+		return custom_error.ErrVersionMismatch
+	}
+
+	updated, err := m.Repository.SetRecurrence(ctx, userID, id, rule.Anchor(task.ActiveAt).String(), task.Version)
+	if err != nil {
+		return err
+	}
+	m.track(ctx, entity.HistoryUpdate, task, updated)
+
+	return nil
+}
+
+// StopRecurrence останавливает серию: после выполнения задачи новое повторение не создается
+func (m *Manager) StopRecurrence(ctx context.Context, userID, id primitive.ObjectID, version int64) error {
+	task, err := m.Repository.GetTaskByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if version > 0 && task.Version != version {
+		return custom_error.ErrVersionMismatch
+	}
+
+	updated, err := m.Repository.SetRecurrence(ctx, userID, id, "", task.Version)
+	if err != nil {
+		return err
+	}
+	m.track(ctx, entity.HistoryUpdate, task, updated)
+
+	return nil
+}
+
+// completeOccurrence завершает повторение серии task и создает следующее
+// с тем же содержимым и невыполненным чек-листом. Повторение записывается только поверх
+// прочитанной версии task. Создание следующего повторения попадает в его историю.
+// Возвращает завершенное повторение в том виде, в котором оно записано
+func (m *Manager) completeOccurrence(ctx context.Context, task *entity.Tasks) (*entity.Tasks, error) {
+	rule, err := entity.ParseRRule(task.Recurrence)
+	if err != nil {
+		// Сохраненное правило проверено при записи, сюда попадать не должно
+		log.Printf("task %s has invalid recurrence %q: %s", task.ID.Hex(), task.Recurrence, err)
+		return m.Repository.UpdateTaskStatus(ctx, task.Owner, task.ID, entity.StatusDone, task.Version)
+	}
+
+	activeAt, ok := m.nextOccurrence(rule, task.ActiveAt)
+	if !ok {
+		return m.Repository.CompleteOccurrence(ctx, task, task.Version, nil)
+	}
+
+	series := task.Series()
+	next := &entity.Tasks{
+		ListID:       task.ListID,
+		Title:        task.Title,
+		Description:  task.Description,
+		ActiveAt:     activeAt,
+		Status:       entity.StatusTodo,
+		Priority:     task.Priority,
+		Tags:         append([]string(nil), task.Tags...),
+		AutoComplete: task.AutoComplete,
+		Recurrence:   task.Recurrence,
+		SeriesID:     &series,
+		Owner:        task.Owner,
+		Workspace:    task.Workspace,
+		Version:      1,
+	}
+	if task.Due != nil {
+		due := *task.Due
+		next.Due = &due
+	}
+	for _, item := range task.Checklist {
+		next.Checklist = append(next.Checklist, entity.ChecklistItem{ID: primitive.NewObjectID(), Title: item.Title})
+	}
+
+	m.Decorator.Prepare(next)
+
+	completed, err := m.Repository.CompleteOccurrence(ctx, task, task.Version, next)
+	if err != nil {
+		return nil, err
+	}
+	m.record(ctx, m.historyEntry(ctx, entity.HistoryCreate, nil, next))
+
+	return completed, nil
+}
+
+// nextOccurrence возвращает дату следующего повторения после after. Пропущенные
+// повторения не создаются: следующее повторение не раньше сегодняшнего дня
+func (m *Manager) nextOccurrence(rule entity.RRule, after entity.Date) (entity.Date, bool) {
+	today := entity.DateOf(m.now())
+
+	next, ok := rule.Next(after)
+	for ok && next.Before(today.Time) {
+		next, ok = rule.Next(next)
+	}
+
+	return next, ok
+}
+
+func parseRecurrence(s string) (entity.RRule, error) {
+	rule, err := entity.ParseRRule(s)
+	if err != nil {
+		log.Println("recurrence format err: ", err)
+		return rule, custom_error.ErrInvalidRecurrence
+	}
+	return rule, nil
+}
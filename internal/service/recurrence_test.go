@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_repository "github.com/khussa1n/todo-list/internal/repository/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+func Test_CompleteOccurrence(t *testing.T) {
+	id := primitive.NewObjectID()
+	seriesID := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		taskRepo        entity.Tasks
+		version         int64
+		expectedNext    *entity.Tasks
+		expectedSrvcErr error
+	}{
+		{
+			name:     "ok weekly on weekend",
+			taskRepo: entity.Tasks{ID: id, Title: "Уборка", ActiveAt: mustDate("2023-08-09"), Status: "todo", Priority: entity.PriorityHigh, Tags: []string{"дом"}, Recurrence: "FREQ=WEEKLY;BYDAY=WE,SA", Owner: userID, Version: 2},
+			expectedNext: &entity.Tasks{Title: "Уборка", ActiveAt: mustDate("2023-08-12"), Status: "todo", Priority: entity.PriorityHigh, Tags: []string{"дом"},
+				Recurrence: "FREQ=WEEKLY;BYDAY=WE,SA", SeriesID: &id, Weekend: true, Owner: userID, Version: 1},
+		},
+		{
+			name:     "ok missed occurrences skipped",
+			taskRepo: entity.Tasks{ID: id, Title: "Зарядка", ActiveAt: mustDate("2023-08-01"), Status: "in_progress", Recurrence: "FREQ=DAILY", SeriesID: &seriesID, Owner: userID, Version: 5},
+			version:  5,
+			expectedNext: &entity.Tasks{Title: "Зарядка", ActiveAt: mustDate("2023-08-09"), Status: "todo",
+				Recurrence: "FREQ=DAILY", SeriesID: &seriesID, Owner: userID, Version: 1},
+		},
+		{
+			name:     "ok monthly on last day",
+			taskRepo: entity.Tasks{ID: id, Title: "Счета", ActiveAt: mustDate("2023-08-31"), Status: "todo", Recurrence: "FREQ=MONTHLY;BYMONTHDAY=31", Owner: userID, Version: 1},
+			expectedNext: &entity.Tasks{Title: "Счета", ActiveAt: mustDate("2023-09-30"), Status: "todo",
+				Recurrence: "FREQ=MONTHLY;BYMONTHDAY=31", SeriesID: &id, Weekend: true, Owner: userID, Version: 1},
+		},
+		{
+			name:     "ok series ended",
+			taskRepo: entity.Tasks{ID: id, Title: "Курс", ActiveAt: mustDate("2023-08-09"), Status: "todo", Recurrence: "FREQ=DAILY;UNTIL=20230809", Owner: userID, Version: 1},
+		},
+		{
+			name:            "stale version",
+			taskRepo:        entity.Tasks{ID: id, Title: "Зарядка", ActiveAt: mustDate("2023-08-09"), Status: "todo", Recurrence: "FREQ=DAILY", Owner: userID, Version: 3},
+			version:         2,
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			service := New(mockRepo, cfg)
+			service.now = func() time.Time {
+				return time.Date(2023, time.August, 9, 15, 0, 0, 0, time.UTC)
+			}
+
+			mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&testCase.taskRepo, nil).Times(1)
+
+			// Выполненное повторение остается в серии без правила
+			after := testCase.taskRepo
+			after.Status = "done"
+			after.Recurrence = ""
+			after.Version++
+
+			switch testCase.name {
+			case "ok weekly on weekend", "ok missed occurrences skipped", "ok monthly on last day":
+				mockRepo.EXPECT().CompleteOccurrence(ctx, &testCase.taskRepo, testCase.taskRepo.Version, testCase.expectedNext).Return(&after, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, testCase.expectedNext.ID, 1, entity.HistoryCreate)
+				expectHistory(t, mockRepo, ctx, after.ID, after.Version, entity.HistoryStatus)
+				break
+			case "ok series ended":
+				mockRepo.EXPECT().CompleteOccurrence(ctx, &testCase.taskRepo, testCase.taskRepo.Version, nil).Return(&after, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, after.ID, after.Version, entity.HistoryStatus)
+				break
+			case "stale version":
+				mockRepo.EXPECT().CompleteOccurrence(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			err = service.UpdateTaskStatus(ctx, userID, id, "done", testCase.version)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+		})
+	}
+}
+
+func Test_CompleteOccurrenceChecklist(t *testing.T) {
+	id := primitive.NewObjectID()
+	item := primitive.NewObjectID()
+
+	cfg, err := config.InitConfig("../../config.yaml")
+	require.NoError(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRepo := mock_repository.NewMockRepository(controller)
+
+	ctx := context.Background()
+
+	task := &entity.Tasks{ID: id, Title: "Поездка", ActiveAt: mustDate("2023-08-09"), Status: "todo", Recurrence: "FREQ=DAILY",
+		Checklist: []entity.ChecklistItem{{ID: item, Title: "Паспорт", Done: true}}, Owner: userID, Version: 1}
+
+	var next *entity.Tasks
+	mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(task, nil).Times(1)
+	mockRepo.EXPECT().CompleteOccurrence(ctx, task, int64(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ *entity.Tasks, _ int64, n *entity.Tasks) (*entity.Tasks, error) {
+		next = n
+		return &entity.Tasks{ID: id, Title: "Поездка", ActiveAt: mustDate("2023-08-09"), Status: "done",
+			Checklist: []entity.ChecklistItem{{ID: item, Title: "Паспорт", Done: true}}, Owner: userID, Version: 2}, nil
+	}).Times(1)
+	mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).Return(nil).Times(1)
+	expectHistory(t, mockRepo, ctx, id, 2, entity.HistoryStatus)
+
+	service := New(mockRepo, cfg)
+	service.now = func() time.Time {
+		return time.Date(2023, time.August, 9, 15, 0, 0, 0, time.UTC)
+	}
+
+	err = service.UpdateTaskStatus(ctx, userID, id, "done", 0)
+	require.NoError(t, err)
+
+	// Чек-лист следующего повторения начинается заново, у пунктов новые ID
+	require.Len(t, next.Checklist, 1)
+	require.Equal(t, "Паспорт", next.Checklist[0].Title)
+	require.False(t, next.Checklist[0].Done)
+	require.NotEqual(t, item, next.Checklist[0].ID)
+	require.True(t, task.Checklist[0].Done)
+}
+
+func Test_CompleteOccurrenceAutoComplete(t *testing.T) {
+	id := primitive.NewObjectID()
+	item := primitive.NewObjectID()
+	done := true
+
+	cfg, err := config.InitConfig("../../config.yaml")
+	require.NoError(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRepo := mock_repository.NewMockRepository(controller)
+
+	ctx := context.Background()
+
+	task := &entity.Tasks{ID: id, Title: "Поездка", ActiveAt: mustDate("2023-08-09"), Status: "todo", Recurrence: "FREQ=DAILY", AutoComplete: true,
+		Checklist: []entity.ChecklistItem{{ID: item, Title: "Паспорт"}}, Owner: userID, Version: 1}
+	checked := []entity.ChecklistItem{{ID: item, Title: "Паспорт", Done: true}}
+	saved := &entity.Tasks{ID: id, Title: "Поездка", ActiveAt: mustDate("2023-08-09"), Status: "todo", Recurrence: "FREQ=DAILY", AutoComplete: true,
+		Checklist: checked, Owner: userID, Version: 2}
+	completed := &entity.Tasks{ID: id, Title: "Поездка", ActiveAt: mustDate("2023-08-09"), Status: "done", AutoComplete: true,
+		Checklist: checked, SeriesID: &id, Occurrence: &task.ActiveAt, Owner: userID, Version: 3}
+
+	// Чек-лист сохраняется без смены статуса, затем повторение завершается
+	// поверх новой версии и создается следующее
+	mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(task, nil).Times(1)
+	mockRepo.EXPECT().UpdateChecklist(ctx, userID, id, checked, "todo", int64(1)).Return(saved, nil).Times(1)
+	expectHistory(t, mockRepo, ctx, saved.ID, saved.Version, entity.HistoryUpdate)
+	mockRepo.EXPECT().CompleteOccurrence(ctx, saved, int64(2), gomock.Any()).DoAndReturn(func(_ context.Context, _ *entity.Tasks, _ int64, n *entity.Tasks) (*entity.Tasks, error) {
+		require.Equal(t, mustDate("2023-08-10"), n.ActiveAt)
+		require.False(t, n.Checklist[0].Done)
+		return completed, nil
+	}).Times(1)
+	mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).Return(nil).Times(1)
+	expectHistory(t, mockRepo, ctx, completed.ID, completed.Version, entity.HistoryStatus)
+
+	service := New(mockRepo, cfg)
+	service.now = func() time.Time {
+		return time.Date(2023, time.August, 9, 15, 0, 0, 0, time.UTC)
+	}
+
+	result, err := service.UpdateChecklistItem(ctx, userID, id, item, &dto.ChecklistItemPatchDTO{Done: &done}, 0)
+	require.NoError(t, err)
+	require.Equal(t, "done", result.Status)
+	require.Equal(t, int64(3), result.Version)
+}
+
+func Test_SetRecurrence(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		dto             dto.RecurrenceDTO
+		version         int64
+		taskRepo        entity.Tasks
+		ruleRepo        string
+		expectedSrvcErr error
+	}{
+		{
+			name:     "ok anchored to activeAt",
+			dto:      dto.RecurrenceDTO{Recurrence: "FREQ=MONTHLY;UNTIL=20231231T235959Z"},
+			taskRepo: entity.Tasks{ID: id, ActiveAt: mustDate("2023-08-15"), Owner: userID, Version: 2},
+			ruleRepo: "FREQ=MONTHLY;BYMONTHDAY=15;UNTIL=20231231",
+		},
+		{
+			name:     "ok weekly days",
+			dto:      dto.RecurrenceDTO{Recurrence: "RRULE:FREQ=WEEKLY;BYDAY=FR,MO,FR"},
+			version:  2,
+			taskRepo: entity.Tasks{ID: id, ActiveAt: mustDate("2023-08-15"), Owner: userID, Version: 2},
+			ruleRepo: "FREQ=WEEKLY;BYDAY=MO,FR",
+		},
+		{
+			name:            "invalid byday with daily",
+			dto:             dto.RecurrenceDTO{Recurrence: "FREQ=DAILY;BYDAY=MO"},
+			expectedSrvcErr: custom_error.ErrInvalidRecurrence,
+		},
+		{
+			name:            "invalid interval",
+			dto:             dto.RecurrenceDTO{Recurrence: "FREQ=DAILY;INTERVAL=0"},
+			expectedSrvcErr: custom_error.ErrInvalidRecurrence,
+		},
+		{
+			name:            "invalid count",
+			dto:             dto.RecurrenceDTO{Recurrence: "FREQ=DAILY;COUNT=3"},
+			expectedSrvcErr: custom_error.ErrInvalidRecurrence,
+		},
+		{
+			name:            "stale version",
+			dto:             dto.RecurrenceDTO{Recurrence: "FREQ=DAILY"},
+			version:         1,
+			taskRepo:        entity.Tasks{ID: id, ActiveAt: mustDate("2023-08-15"), Owner: userID, Version: 2},
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			switch testCase.name {
+			case "ok anchored to activeAt", "ok weekly days":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&testCase.taskRepo, nil).Times(1)
+				mockRepo.EXPECT().SetRecurrence(ctx, userID, id, testCase.ruleRepo, testCase.taskRepo.Version).Return(&entity.Tasks{ID: id, ActiveAt: mustDate("2023-08-15"), Recurrence: testCase.ruleRepo, Owner: userID, Version: 3}, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, id, 3, entity.HistoryUpdate)
+				break
+			case "invalid byday with daily", "invalid interval", "invalid count":
+				mockRepo.EXPECT().GetTaskByID(ctx, gomock.Any(), gomock.Any()).Times(0)
+				mockRepo.EXPECT().SetRecurrence(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "stale version":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&testCase.taskRepo, nil).Times(1)
+				mockRepo.EXPECT().SetRecurrence(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			err = service.SetRecurrence(ctx, userID, id, &testCase.dto, testCase.version)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+		})
+	}
+}
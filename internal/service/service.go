@@ -5,16 +5,104 @@ import (
 	"github.com/khussa1n/todo-list/internal/entity"
 	"github.com/khussa1n/todo-list/internal/entity/dto"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
 )
 
+// TodoList - задачи владельца userID: пользователя или рабочего пространства,
+// права на которое уже проверены. CreateTask получает права целиком, чтобы отметить задачу пространством.
+// version - ожидаемая версия задачи из If-Match, 0 - любая версия
 type TodoList interface {
-	CreateTask(ctx context.Context, t *dto.TasksDTO) (*entity.Tasks, error)
-	UpdateTask(ctx context.Context, t *dto.TasksDTO, id primitive.ObjectID) error
-	UpdateTaskStatus(ctx context.Context, id primitive.ObjectID, status string) error
-	GetAllTasks(ctx context.Context, status string) ([]entity.Tasks, error)
-	DeleteTask(ctx context.Context, id primitive.ObjectID) error
+	CreateTask(ctx context.Context, access *entity.Access, t *dto.TasksDTO) (*entity.Tasks, error)
+	UpdateTask(ctx context.Context, userID primitive.ObjectID, t *dto.TasksDTO, id primitive.ObjectID, version int64) error
+	PatchTask(ctx context.Context, userID primitive.ObjectID, p *dto.TasksPatchDTO, id primitive.ObjectID, version int64) error
+	UpdateTaskStatus(ctx context.Context, userID, id primitive.ObjectID, status string, version int64) error
+	GetAllTasks(ctx context.Context, userID primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error)
+	SearchTasks(ctx context.Context, userID primitive.ObjectID, q *dto.TasksSearchDTO) (*dto.TasksPageDTO, error)
+	GetTaskByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.Tasks, error)
+	DeleteTask(ctx context.Context, userID, id primitive.ObjectID, version int64) error
+	GetTrash(ctx context.Context, userID primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error)
+	RestoreTask(ctx context.Context, userID, id primitive.ObjectID) error
+	AddChecklistItem(ctx context.Context, userID, id primitive.ObjectID, r *dto.ChecklistItemDTO, version int64) (*entity.Tasks, error)
+	UpdateChecklistItem(ctx context.Context, userID, id, itemID primitive.ObjectID, p *dto.ChecklistItemPatchDTO, version int64) (*entity.Tasks, error)
+	DeleteChecklistItem(ctx context.Context, userID, id, itemID primitive.ObjectID, version int64) (*entity.Tasks, error)
+	ReorderChecklist(ctx context.Context, userID, id primitive.ObjectID, r *dto.ChecklistOrderDTO, version int64) (*entity.Tasks, error)
+	SetRecurrence(ctx context.Context, userID, id primitive.ObjectID, r *dto.RecurrenceDTO, version int64) error
+	StopRecurrence(ctx context.Context, userID, id primitive.ObjectID, version int64) error
+	AddDependency(ctx context.Context, userID, id, blockerID primitive.ObjectID, version int64) error
+	RemoveDependency(ctx context.Context, userID, id, blockerID primitive.ObjectID, version int64) error
+	GetDependencyGraph(ctx context.Context, userID, id primitive.ObjectID) (*entity.DependencyGraph, error)
+	MoveTask(ctx context.Context, userID, id primitive.ObjectID, r *dto.MoveTaskDTO, version int64) error
+	GetTags(ctx context.Context, userID primitive.ObjectID) ([]entity.TagStat, error)
+	RenameTag(ctx context.Context, userID primitive.ObjectID, tag string, r *dto.RenameTagDTO) (int64, error)
+}
+
+// List - списки задач владельца userID: пользователя или рабочего пространства
+type List interface {
+	CreateList(ctx context.Context, userID primitive.ObjectID, l *dto.ListDTO) (*entity.List, error)
+	GetLists(ctx context.Context, userID primitive.ObjectID) ([]entity.List, error)
+	GetListByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.List, error)
+	UpdateList(ctx context.Context, userID, id primitive.ObjectID, l *dto.ListDTO) error
+	DeleteList(ctx context.Context, userID, id primitive.ObjectID) error
+	GetListTasks(ctx context.Context, userID, id primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error)
+}
+
+// Workspace - рабочие пространства пользователя userID. Пространство, где пользователь
+// не участвует, для него не существует: custom_error.ErrWorkspaceNotFound.
+// Действие, которое не разрешает роль пользователя, возвращает custom_error.ErrForbidden.
+// GetAccess возвращает права пользователя на задачи и списки пространства
+type Workspace interface {
+	CreateWorkspace(ctx context.Context, userID primitive.ObjectID, w *dto.WorkspaceDTO) (*entity.Workspace, error)
+	GetWorkspaces(ctx context.Context, userID primitive.ObjectID) ([]entity.Workspace, error)
+	GetWorkspaceByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.Workspace, error)
+	UpdateWorkspace(ctx context.Context, userID, id primitive.ObjectID, w *dto.WorkspaceDTO) error
+	DeleteWorkspace(ctx context.Context, userID, id primitive.ObjectID) error
+	GetMembers(ctx context.Context, userID, id primitive.ObjectID) ([]entity.Member, error)
+	UpdateMemberRole(ctx context.Context, userID, id, memberID primitive.ObjectID, r *dto.MemberRoleDTO) error
+	RemoveMember(ctx context.Context, userID, id, memberID primitive.ObjectID) error
+	CreateInvitation(ctx context.Context, userID, id primitive.ObjectID, r *dto.InvitationDTO) (*entity.Invitation, error)
+	AcceptInvitation(ctx context.Context, userID primitive.ObjectID, token string) (*entity.Member, error)
+	GetAccess(ctx context.Context, userID, workspace primitive.ObjectID) (*entity.Access, error)
+}
+
+// Comment - комментарии к задачам владельца access.Owner. Править комментарий может
+// только автор, удалить - автор или владелец пространства: иначе custom_error.ErrNotCommentAuthor
+type Comment interface {
+	GetComments(ctx context.Context, access *entity.Access, taskID primitive.ObjectID) ([]entity.Comment, error)
+	CreateComment(ctx context.Context, access *entity.Access, taskID primitive.ObjectID, r *dto.CommentDTO) (*entity.Comment, error)
+	UpdateComment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID, r *dto.CommentDTO) (*entity.Comment, error)
+	DeleteComment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) error
+}
+
+// Attachment - вложения задач владельца access.Owner. Файл больше config.Attachment.MaxSize
+// возвращает custom_error.ErrAttachmentTooLarge, файл не из AllowedTypes - custom_error.ErrAttachmentType.
+// GetAttachmentContent возвращает custom_error.ErrAttachmentCorrupted, если файл не совпадает с контрольной суммой
+type Attachment interface {
+	UploadAttachment(ctx context.Context, access *entity.Access, taskID primitive.ObjectID, name string, size int64, r io.Reader) (*entity.Attachment, error)
+	GetAttachments(ctx context.Context, access *entity.Access, taskID primitive.ObjectID) ([]entity.Attachment, error)
+	GetAttachmentContent(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) (*entity.Attachment, []byte, error)
+	DeleteAttachment(ctx context.Context, access *entity.Access, taskID, id primitive.ObjectID) error
+}
+
+// History - история изменений задач владельца userID: каждое изменение задачи записывается
+// с пользователем из WithActor. RevertTask возвращает задаче содержимое версии target как новую версию,
+// custom_error.ErrHistoryNotFound - такой версии в истории нет
+type History interface {
+	GetHistory(ctx context.Context, userID, id primitive.ObjectID) ([]entity.HistoryEntry, error)
+	RevertTask(ctx context.Context, userID, id primitive.ObjectID, target, version int64) (*entity.Tasks, error)
+}
+
+type Auth interface {
+	SignUp(ctx context.Context, u *dto.UserDTO) (*entity.User, error)
+	SignIn(ctx context.Context, u *dto.UserDTO) (*dto.TokenDTO, error)
+	ParseToken(ctx context.Context, token string) (primitive.ObjectID, error)
 }
 
 type Service interface {
 	TodoList
+	List
+	Comment
+	Attachment
+	History
+	Workspace
+	Auth
 }
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetTags возвращает метки задач пользователя, самые частые первыми
+func (m *Manager) GetTags(ctx context.Context, userID primitive.ObjectID) ([]entity.TagStat, error) {
+	tags, err := m.Repository.GetTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tags == nil {
+		tags = make([]entity.TagStat, 0)
+	}
+
+	return tags, nil
+}
+
+// RenameTag переименовывает метку tag у всех задач пользователя, включая корзину.
+// Если у задачи уже есть метка с новым названием, метки сливаются в одну.
+// Возвращает число измененных задач
+func (m *Manager) RenameTag(ctx context.Context, userID primitive.ObjectID, tag string, r *dto.RenameTagDTO) (int64, error) {
+	from, ok := entity.NormalizeTag(tag)
+	if !ok {
+		return 0, custom_error.ErrTagNotFound
+	}
+
+	to, ok := entity.NormalizeTag(r.Name)
+	if !ok {
+		return 0, custom_error.ErrInvalidTag
+	}
+
+	// Метка ищется среди тех же задач, которые переименование меняет, включая корзину
+	renamed, err := m.Repository.RenameTag(ctx, userID, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	// Переименование создает новые версии задач, поэтому каждая попадает в историю
+	for i := range renamed {
+		m.record(ctx, m.historyEntry(ctx, entity.HistoryUpdate, &renamed[i].Before, &renamed[i].After))
+	}
+
+	return int64(len(renamed)), nil
+}
+
+func parseTags(tags []string) ([]string, error) {
+	normalized, ok := entity.NormalizeTags(tags)
+	if !ok {
+		return nil, custom_error.ErrInvalidTag
+	}
+	return normalized, nil
+}
+
+// applyTagFilter добавляет в filter метки запроса. По умолчанию задача должна иметь все метки
+func applyTagFilter(filter *entity.TaskFilter, tags []string, match string) error {
+	if match != "" && match != "all" && match != "any" {
+		return custom_error.ErrInvalidTagMatch
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	normalized, err := parseTags(tags)
+	if err != nil {
+		return err
+	}
+
+	filter.Tags = normalized
+	filter.TagsAll = match != "any"
+
+	return nil
+}
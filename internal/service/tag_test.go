@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_repository "github.com/khussa1n/todo-list/internal/repository/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+)
+
+func Test_GetTags(t *testing.T) {
+	table := []struct {
+		name         string
+		expectedRepo []entity.TagStat
+		expectedSrvc []entity.TagStat
+	}{
+		{
+			name:         "ok",
+			expectedRepo: []entity.TagStat{{Tag: "backend", Count: 2}, {Tag: "ops", Count: 1}},
+			expectedSrvc: []entity.TagStat{{Tag: "backend", Count: 2}, {Tag: "ops", Count: 1}},
+		},
+		{
+			name:         "ok empty array",
+			expectedSrvc: make([]entity.TagStat, 0),
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			mockRepo.EXPECT().GetTags(ctx, userID).Return(testCase.expectedRepo, nil).Times(1)
+
+			service := New(mockRepo, cfg)
+
+			result, err := service.GetTags(ctx, userID)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectedSrvc, result)
+		})
+	}
+}
+
+func Test_RenameTag(t *testing.T) {
+	id1, id2 := primitive.NewObjectID(), primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		tag             string
+		dto             dto.RenameTagDTO
+		from, to        string
+		expectedRepo    []entity.TaskRevision
+		expectedRepoErr error
+		expectedSrvc    int64
+		expectedSrvcErr error
+	}{
+		{
+			name: "ok",
+			tag:  "Backend",
+			dto:  dto.RenameTagDTO{Name: " API "},
+			from: "backend",
+			to:   "api",
+			expectedRepo: []entity.TaskRevision{
+				{
+					Before: entity.Tasks{ID: id1, Tags: []string{"backend"}, Owner: userID, Version: 1},
+					After:  entity.Tasks{ID: id1, Tags: []string{"api"}, Owner: userID, Version: 2},
+				},
+				{
+					Before: entity.Tasks{ID: id2, Tags: []string{"api", "backend"}, Owner: userID, Version: 4},
+					After:  entity.Tasks{ID: id2, Tags: []string{"api"}, Owner: userID, Version: 5},
+				},
+			},
+			expectedSrvc: 2,
+		},
+		{
+			name:            "tag not found",
+			tag:             "backend",
+			dto:             dto.RenameTagDTO{Name: "api"},
+			from:            "backend",
+			to:              "api",
+			expectedRepoErr: custom_error.ErrTagNotFound,
+			expectedSrvcErr: custom_error.ErrTagNotFound,
+		},
+		{
+			name: "same name",
+			tag:  "Backend",
+			dto:  dto.RenameTagDTO{Name: "backend"},
+			from: "backend",
+			to:   "backend",
+		},
+		{
+			name:            "same name not found",
+			tag:             "backend",
+			dto:             dto.RenameTagDTO{Name: "backend"},
+			from:            "backend",
+			to:              "backend",
+			expectedRepoErr: custom_error.ErrTagNotFound,
+			expectedSrvcErr: custom_error.ErrTagNotFound,
+		},
+		{
+			name:            "invalid name",
+			tag:             "backend",
+			dto:             dto.RenameTagDTO{Name: "back end"},
+			expectedSrvcErr: custom_error.ErrInvalidTag,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			switch testCase.name {
+			case "ok":
+				// Каждая переименованная задача получает запись в истории
+				mockRepo.EXPECT().RenameTag(ctx, userID, testCase.from, testCase.to).Return(testCase.expectedRepo, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, id1, 2, entity.HistoryUpdate)
+				expectHistory(t, mockRepo, ctx, id2, 5, entity.HistoryUpdate)
+				break
+			case "tag not found", "same name", "same name not found":
+				// Одноименная метка проверяется в хранилище среди тех же задач, что и переименование
+				mockRepo.EXPECT().RenameTag(ctx, userID, testCase.from, testCase.to).Return(nil, testCase.expectedRepoErr).Times(1)
+				mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).Times(0)
+				break
+			case "invalid name":
+				mockRepo.EXPECT().RenameTag(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			updated, err := service.RenameTag(ctx, userID, testCase.tag, &testCase.dto)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+			require.Equal(t, testCase.expectedSrvc, updated)
+		})
+	}
+}
@@ -2,119 +2,619 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"github.com/khussa1n/todo-list/internal/custom_error"
 	"github.com/khussa1n/todo-list/internal/entity"
 	"github.com/khussa1n/todo-list/internal/entity/dto"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"log"
-	"time"
+	"strings"
+	"unicode/utf8"
 )
 
-func (m *Manager) CreateTask(ctx context.Context, t *dto.TasksDTO) (*entity.Tasks, error) {
-	if len(t.Title) > 200 {
-		return nil, custom_error.ErrMessageTooLong
+const (
+	defaultLimit int64 = 20
+	maxLimit     int64 = 100
+	// maxSearchTerms - сколько слов можно искать одним запросом
+	maxSearchTerms = 10
+	// maxDescriptionLength - длина описания в символах
+	maxDescriptionLength = 10000
+)
+
+var sortFields = map[string]struct{}{
+	"activeAt":  {},
+	"title":     {},
+	"priority":  {},
+	"createdAt": {},
+}
+
+// CreateTask создает задачу от имени access.Owner. Читатель пространства задачи не создает
+func (m *Manager) CreateTask(ctx context.Context, access *entity.Access, t *dto.TasksDTO) (*entity.Tasks, error) {
+	if !access.Can(entity.RoleEditor) {
+		return nil, custom_error.ErrForbidden
+	}
+
+	task, err := m.taskFromDTO(t)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Recurrence != "" {
+		rule, err := parseRecurrence(t.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		task.Recurrence = rule.Anchor(task.ActiveAt).String()
 	}
 
-	layout := "2006-01-02"
-	parsedDate, err := time.Parse(layout, t.ActiveAt)
+	task.ListID, err = m.resolveList(ctx, access.Owner, t.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Status = entity.StatusTodo
+	task.Owner = access.Owner
+	task.Workspace = access.Workspace
+	task.Version = 1
+
+	newTask, err := m.Repository.CreateTask(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	m.record(ctx, m.historyEntry(ctx, entity.HistoryCreate, nil, newTask))
+
+	return m.decorate(newTask), nil
+}
+
+func (m *Manager) UpdateTask(ctx context.Context, userID primitive.ObjectID, t *dto.TasksDTO, id primitive.ObjectID, version int64) error {
+	newTask, err := m.taskFromDTO(t)
+	if err != nil {
+		return err
+	}
+
+	task, err := m.Repository.GetTaskByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if version > 0 && task.Version != version {
+		return custom_error.ErrVersionMismatch
+	}
+
+	newTask.Status = task.Status
+	newTask.Owner = userID
+
+	// Без If-Match задача все равно записывается поверх прочитанной версии,
+	// иначе параллельное изменение между чтением и записью потерялось бы
+	updated, err := m.Repository.UpdateTask(ctx, newTask, id, task.Version)
+	if err != nil {
+		return err
+	}
+	m.track(ctx, entity.HistoryUpdate, task, updated)
+
+	return nil
+}
+
+// PatchTask меняет только переданные поля задачи. Признак выходного дня
+// пересчитывается при смене activeAt
+func (m *Manager) PatchTask(ctx context.Context, userID primitive.ObjectID, p *dto.TasksPatchDTO, id primitive.ObjectID, version int64) error {
+	patch := &entity.TaskPatch{}
+
+	// Декоратор применяется к неполной задаче: берутся только поля, затронутые изменением
+	var task entity.Tasks
+
+	if p.Title != nil {
+		task.Title = *p.Title
+	}
+
+	if p.ActiveAt != nil {
+		activeAt, err := entity.ParseDate(*p.ActiveAt)
+		if err != nil {
+			log.Println("activeAt format err: ", err)
+			return custom_error.ErrInvalidActiveAtFormat
+		}
+		task.ActiveAt = activeAt
+	}
+
+	if p.Description != nil {
+		description := strings.TrimSpace(*p.Description)
+		err := validateDescription(description)
+		if err != nil {
+			return err
+		}
+		patch.Description = &description
+	}
+
+	if p.Priority != nil {
+		priority, err := parsePriority(*p.Priority)
+		if err != nil {
+			return err
+		}
+		patch.Priority = &priority
+	}
+
+	if p.Due != nil && !p.ClearDue {
+		due, err := parseDue(p.Due)
+		if err != nil {
+			return err
+		}
+		patch.Due = &due
+	}
+	patch.ClearDue = p.ClearDue
+
+	if p.Tags != nil {
+		tags, err := parseTags(*p.Tags)
+		if err != nil {
+			return err
+		}
+		patch.Tags = &tags
+	}
+	patch.AutoComplete = p.AutoComplete
+
+	m.Decorator.Prepare(&task)
+
+	if p.Title != nil {
+		// Как и при создании, название проверяется после очистки
+		err := validateTitle(task.Title)
+		if err != nil {
+			return err
+		}
+		patch.Title = &task.Title
+	}
+	if p.ActiveAt != nil {
+		patch.ActiveAt = &task.ActiveAt
+		patch.Weekend = &task.Weekend
+	}
+
+	before, err := m.Repository.GetTaskByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if version > 0 && before.Version != version {
+		return custom_error.ErrVersionMismatch
+	}
+
+	after, err := m.Repository.PatchTask(ctx, userID, id, patch, before.Version)
+	if err != nil {
+		return err
+	}
+	m.track(ctx, entity.HistoryUpdate, before, after)
+
+	return nil
+}
+
+// taskFromDTO проверяет название и дату задачи и готовит задачу к сохранению
+func (m *Manager) taskFromDTO(t *dto.TasksDTO) (*entity.Tasks, error) {
+	activeAt, err := entity.ParseDate(t.ActiveAt)
 	if err != nil {
 		log.Println("activeAt format err: ", err)
 		return nil, custom_error.ErrInvalidActiveAtFormat
 	}
 
-	weekday := parsedDate.Weekday()
+	task := &entity.Tasks{
+		Title:        t.Title,
+		Description:  strings.TrimSpace(t.Description),
+		ActiveAt:     activeAt,
+		AutoComplete: t.AutoComplete,
+	}
 
-	var title string
-	if weekday == time.Saturday || weekday == time.Sunday {
-		title = "ВЫХОДНОЙ - "
-		title += t.Title
-	} else {
-		title += t.Title
+	err = validateDescription(task.Description)
+	if err != nil {
+		return nil, err
 	}
 
-	task := &entity.Tasks{
-		Title:    title,
-		ActiveAt: t.ActiveAt,
-		Status:   "active",
+	if t.Priority != "" {
+		task.Priority, err = parsePriority(t.Priority)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	newTask, err := m.Repository.CreateTask(ctx, task)
+	if t.Due != nil {
+		due, err := parseDue(t.Due)
+		if err != nil {
+			return nil, err
+		}
+		task.Due = &due
+	}
+
+	if len(t.Tags) > 0 {
+		task.Tags, err = parseTags(t.Tags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.Decorator.Prepare(task)
+
+	// Название проверяется после очистки: из пробелов или одного префикса остается пустая строка
+	err = validateTitle(task.Title)
 	if err != nil {
 		return nil, err
 	}
 
-	return newTask, nil
+	return task, nil
 }
 
-func (m *Manager) UpdateTask(ctx context.Context, t *dto.TasksDTO, id primitive.ObjectID) error {
-	if len(t.Title) > 200 {
+func validateTitle(title string) error {
+	if title == "" {
+		return custom_error.ErrEmptyTitle
+	}
+	if len(title) > 200 {
 		return custom_error.ErrMessageTooLong
 	}
+	return nil
+}
+
+func validateDescription(description string) error {
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return custom_error.ErrDescriptionTooLong
+	}
+	return nil
+}
+
+func parsePriority(s string) (entity.Priority, error) {
+	priority, ok := entity.ParsePriority(s)
+	if !ok {
+		return priority, custom_error.ErrInvalidPriority
+	}
+	return priority, nil
+}
+
+// parsePriorities разбирает список приоритетов через запятую, пустая строка - без фильтра
+func parsePriorities(s string) ([]entity.Priority, error) {
+	if s == "" {
+		return nil, nil
+	}
 
-	layout := "2006-01-02"
-	parsedDate, err := time.Parse(layout, t.ActiveAt)
+	var priorities []entity.Priority
+	for _, name := range strings.Split(s, ",") {
+		priority, err := parsePriority(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		priorities = append(priorities, priority)
+	}
+	return priorities, nil
+}
+
+func parseDue(d *dto.DueDTO) (entity.DueTime, error) {
+	due, err := entity.ParseDueTime(d.Time, d.TimeZone)
 	if err != nil {
-		log.Println("activeAt format err: ", err)
-		return custom_error.ErrInvalidActiveAtFormat
+		log.Println("due format err: ", err)
+		return due, custom_error.ErrInvalidDueTime
 	}
+	return due, nil
+}
 
-	weekday := parsedDate.Weekday()
+// decorate возвращает оформленную для ответа копию задачи
+func (m *Manager) decorate(t *entity.Tasks) *entity.Tasks {
+	task := *t
+	m.present(&task)
+	return &task
+}
 
-	var title string
-	if weekday == time.Saturday || weekday == time.Sunday {
-		title = "ВЫХОДНОЙ - "
-		title += t.Title
-	} else {
-		title += t.Title
+// present оформляет задачу для ответа и вычисляет прогресс чек-листа
+func (m *Manager) present(t *entity.Tasks) {
+	m.Decorator.Decorate(t)
+	t.Progress = t.ChecklistProgress()
+}
+
+// UpdateTaskStatus переводит задачу в статус status, если переход разрешен.
+// Перевод в текущий статус ничего не меняет. Задачу нельзя выполнить, пока ее блокируют
+// открытые задачи. Выполненная повторяющаяся задача создает следующее повторение серии
+func (m *Manager) UpdateTaskStatus(ctx context.Context, userID, id primitive.ObjectID, status string, version int64) error {
+	if !entity.IsValidStatus(status) {
+		return custom_error.ErrInvalidStatus
 	}
 
-	task, err := m.Repository.GetTaskByID(ctx, id)
+	task, err := m.Repository.GetTaskByID(ctx, userID, id)
 	if err != nil {
 		return err
 	}
 
-	newTask := &entity.Tasks{
-		Title:    title,
-		ActiveAt: t.ActiveAt,
-		Status:   task.Status,
+	if version > 0 && task.Version != version {
+		return custom_error.ErrVersionMismatch
+	}
+
+	if task.Status == status {
+		return nil
+	}
+
+	if !entity.CanTransition(task.Status, status) {
+		return fmt.Errorf("%w: from %s to %s", custom_error.ErrInvalidTransition, task.Status, status)
 	}
 
-	err = m.Repository.UpdateTask(ctx, newTask, id)
+	if status == entity.StatusDone {
+		err = m.checkBlockers(ctx, task)
+		if err != nil {
+			return err
+		}
+	}
+
+	var updated *entity.Tasks
+	if status == entity.StatusDone && task.Recurrence != "" {
+		updated, err = m.completeOccurrence(ctx, task)
+	} else {
+		// Переход проверен для прочитанной версии, поэтому и записывается только поверх нее
+		updated, err = m.Repository.UpdateTaskStatus(ctx, userID, id, status, task.Version)
+	}
 	if err != nil {
 		return err
 	}
+	m.track(ctx, entity.HistoryStatus, task, updated)
 
 	return nil
 }
 
-func (m *Manager) UpdateTaskStatus(ctx context.Context, id primitive.ObjectID, status string) error {
-	err := m.Repository.UpdateTaskStatus(ctx, id, status)
+func (m *Manager) GetAllTasks(ctx context.Context, userID primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error) {
+	filter := &entity.TaskFilter{
+		Owner:     userID,
+		Statuses:  entity.OpenStatuses,
+		SortField: "createdAt",
+		Limit:     defaultLimit,
+	}
+
+	return m.listTasks(ctx, filter, q)
+}
+
+// GetTrash возвращает задачи из корзины, по умолчанию во всех статусах
+func (m *Manager) GetTrash(ctx context.Context, userID primitive.ObjectID, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error) {
+	filter := &entity.TaskFilter{
+		Owner:     userID,
+		Deleted:   true,
+		SortField: "createdAt",
+		Limit:     defaultLimit,
+	}
+
+	return m.listTasks(ctx, filter, q)
+}
+
+// listTasks дополняет filter параметрами запроса и возвращает страницу задач
+func (m *Manager) listTasks(ctx context.Context, filter *entity.TaskFilter, q *dto.TasksQueryDTO) (*dto.TasksPageDTO, error) {
+	if q.Status != "" {
+		if !entity.IsValidStatus(q.Status) {
+			return nil, custom_error.ErrInvalidStatus
+		}
+		filter.Statuses = []string{q.Status}
+	}
+
+	priorities, err := parsePriorities(q.Priority)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	filter.Priorities = priorities
+
+	err = applyTagFilter(filter, q.Tags, q.TagMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.applyDateFilter(filter, q.Period, q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Limit != 0 {
+		if q.Limit < 0 || q.Limit > maxLimit {
+			return nil, custom_error.ErrInvalidLimit
+		}
+		filter.Limit = q.Limit
+	}
+
+	if q.Sort != "" {
+		field := strings.TrimPrefix(q.Sort, "-")
+		if _, ok := sortFields[field]; !ok {
+			return nil, custom_error.ErrInvalidSort
+		}
+		filter.SortField = field
+		filter.SortDesc = strings.HasPrefix(q.Sort, "-")
+	}
+
+	if q.Cursor != "" {
+		after, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return nil, custom_error.ErrInvalidCursor
+		}
+		filter.After = after
+	}
+
+	// Запрашиваем на одну задачу больше, чтобы понять, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit++
+
+	tasks, total, err := m.Repository.GetAllTasks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.TasksPageDTO{
+		Tasks: make([]entity.Tasks, 0),
+		Total: total,
+	}
+
+	if int64(len(tasks)) > limit {
+		tasks = tasks[:limit]
+		page.NextCursor = encodeCursor(tasks[limit-1].ID)
+	}
+
+	for i := range tasks {
+		m.present(&tasks[i])
+	}
+
+	if tasks != nil {
+		page.Tasks = tasks
+	}
+
+	return page, nil
+}
+
+// applyDateFilter переводит from/to или period запроса в границы activeAt
+func (m *Manager) applyDateFilter(filter *entity.TaskFilter, period, from, to string) error {
+	if period != "" {
+		if from != "" || to != "" {
+			return custom_error.ErrInvalidPeriod
+		}
+
+		today := entity.DateOf(m.now())
+
+		switch period {
+		case "overdue":
+			filter.ActiveTo = today.AddDays(-1)
+		case "today":
+			filter.ActiveFrom = today
+			filter.ActiveTo = today
+		case "week":
+			// Неделя начинается с понедельника
+			offset := (int(today.Weekday()) + 6) % 7
+			filter.ActiveFrom = today.AddDays(-offset)
+			filter.ActiveTo = filter.ActiveFrom.AddDays(6)
+		default:
+			return custom_error.ErrInvalidPeriod
+		}
+
+		return nil
 	}
+
+	var err error
+	if from != "" {
+		filter.ActiveFrom, err = entity.ParseDate(from)
+		if err != nil {
+			return custom_error.ErrInvalidDateRange
+		}
+	}
+
+	if to != "" {
+		filter.ActiveTo, err = entity.ParseDate(to)
+		if err != nil {
+			return custom_error.ErrInvalidDateRange
+		}
+	}
+
+	if !filter.ActiveFrom.IsZero() && !filter.ActiveTo.IsZero() && filter.ActiveFrom.After(filter.ActiveTo.Time) {
+		return custom_error.ErrInvalidDateRange
+	}
+
 	return nil
 }
 
-func (m *Manager) GetAllTasks(ctx context.Context, status string) ([]entity.Tasks, error) {
-	if status == "" {
-		status = "active"
+// SearchTasks ищет задачи по словам q, начало слова тоже совпадает.
+// По умолчанию ищет во всех статусах, самые релевантные задачи идут первыми
+func (m *Manager) SearchTasks(ctx context.Context, userID primitive.ObjectID, q *dto.TasksSearchDTO) (*dto.TasksPageDTO, error) {
+	filter := &entity.TaskFilter{
+		Owner:  userID,
+		Search: entity.SearchTerms(q.Q),
+		Limit:  defaultLimit,
+	}
+
+	if len(filter.Search) == 0 || len(filter.Search) > maxSearchTerms {
+		return nil, custom_error.ErrInvalidSearchQuery
+	}
+
+	if q.Status != "" {
+		if !entity.IsValidStatus(q.Status) {
+			return nil, custom_error.ErrInvalidStatus
+		}
+		filter.Statuses = []string{q.Status}
+	}
+
+	priorities, err := parsePriorities(q.Priority)
+	if err != nil {
+		return nil, err
+	}
+	filter.Priorities = priorities
+
+	err = m.applyDateFilter(filter, q.Period, q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Limit != 0 {
+		if q.Limit < 0 || q.Limit > maxLimit {
+			return nil, custom_error.ErrInvalidLimit
+		}
+		filter.Limit = q.Limit
+	}
+
+	if q.Cursor != "" {
+		filter.Offset, err = decodeOffsetCursor(q.Cursor)
+		if err != nil {
+			return nil, custom_error.ErrInvalidCursor
+		}
+	}
+
+	// Запрашиваем на одну задачу больше, чтобы понять, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit++
+
+	tasks, total, err := m.Repository.GetAllTasks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.TasksPageDTO{
+		Tasks: make([]entity.Tasks, 0),
+		Total: total,
 	}
-	tasks, err := m.Repository.GetAllTasks(ctx, status)
+
+	if int64(len(tasks)) > limit {
+		tasks = tasks[:limit]
+		page.NextCursor = encodeOffsetCursor(filter.Offset + limit)
+	}
+
+	for i := range tasks {
+		m.present(&tasks[i])
+	}
+
+	if tasks != nil {
+		page.Tasks = tasks
+	}
+
+	return page, nil
+}
+
+func (m *Manager) GetTaskByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.Tasks, error) {
+	task, err := m.Repository.GetTaskByID(ctx, userID, id)
 	if err != nil {
 		return nil, err
 	}
+	return m.decorate(task), nil
+}
 
-	if tasks == nil {
-		return make([]entity.Tasks, 0), nil
+// DeleteTask переносит задачу в корзину. Комментарии, вложения и история задачи остаются
+func (m *Manager) DeleteTask(ctx context.Context, userID, id primitive.ObjectID, version int64) error {
+	task, err := m.Repository.GetTaskByID(ctx, userID, id)
+	if err != nil {
+		return err
 	}
 
-	return tasks, nil
+	if version > 0 && task.Version != version {
+		return custom_error.ErrVersionMismatch
+	}
+
+	// История считается от прочитанной версии, поэтому задача удаляется только поверх нее
+	deleted, err := m.Repository.DeleteTask(ctx, userID, id, task.Version)
+	if err != nil {
+		return err
+	}
+	m.record(ctx, m.historyEntry(ctx, entity.HistoryDelete, task, deleted))
+
+	return nil
 }
 
-func (m *Manager) DeleteTask(ctx context.Context, id primitive.ObjectID) error {
-	err := m.Repository.DeleteTask(ctx, id)
+func (m *Manager) RestoreTask(ctx context.Context, userID, id primitive.ObjectID) error {
+	before := m.lastSnapshot(ctx, userID, id)
+
+	task, err := m.Repository.RestoreTask(ctx, userID, id)
 	if err != nil {
 		return err
 	}
+	if before == nil {
+		// Задача удалена до появления истории: время удаления неизвестно, изменений в записи нет
+		before = task
+	}
+	m.record(ctx, m.historyEntry(ctx, entity.HistoryRestore, before, task))
+
 	return nil
 }
@@ -2,22 +2,31 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/golang/mock/gomock"
 	"github.com/khussa1n/todo-list/internal/config"
 	"github.com/khussa1n/todo-list/internal/custom_error"
 	"github.com/khussa1n/todo-list/internal/entity"
 	"github.com/khussa1n/todo-list/internal/entity/dto"
 	mock_repository "github.com/khussa1n/todo-list/internal/repository/mock"
+	"github.com/khussa1n/todo-list/pkg/blobstorage"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
 	"testing"
+	"time"
 )
 
+var userID = primitive.NewObjectID()
+
 func Test_CreateTask(t *testing.T) {
 	id := primitive.NewObjectID()
+	listID := primitive.NewObjectID()
+	workspaceID := primitive.NewObjectID()
 
 	table := []struct {
 		name            string
+		access          *entity.Access
 		dto             dto.TasksDTO
 		taskRepo        entity.Tasks
 		expectedRepo    entity.Tasks
@@ -25,24 +34,118 @@ func Test_CreateTask(t *testing.T) {
 		expectedSrvcErr error
 	}{
 		{
-			name:         "ok_ВЫХОДНОЙ",
+			name:         "ok ВЫХОДНОЙ",
 			dto:          dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-05"},
-			taskRepo:     entity.Tasks{Title: "ВЫХОДНОЙ - Купить", ActiveAt: "2023-08-05", Status: "active"},
-			expectedRepo: entity.Tasks{ID: id, Title: "ВЫХОДНОЙ - Купить", ActiveAt: "2023-08-05", Status: "active"},
-			expectedSrvc: entity.Tasks{ID: id, Title: "ВЫХОДНОЙ - Купить", ActiveAt: "2023-08-05", Status: "active"},
+			taskRepo:     entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "todo", Weekend: true, Owner: userID, Version: 1},
+			expectedRepo: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "todo", Weekend: true, Owner: userID, Version: 1},
+			expectedSrvc: entity.Tasks{ID: id, Title: "ВЫХОДНОЙ - Купить", ActiveAt: mustDate("2023-08-05"), Status: "todo", Weekend: true, Owner: userID, Version: 1},
 		},
 		{
 			name:         "ok",
 			dto:          dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04"},
-			taskRepo:     entity.Tasks{Title: "Купить", ActiveAt: "2023-08-04", Status: "active"},
-			expectedRepo: entity.Tasks{ID: id, Title: "Купить", ActiveAt: "2023-08-04", Status: "active"},
-			expectedSrvc: entity.Tasks{ID: id, Title: "Купить", ActiveAt: "2023-08-04", Status: "active"},
+			taskRepo:     entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: userID, Version: 1},
+			expectedRepo: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: userID, Version: 1},
+			expectedSrvc: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: userID, Version: 1},
+		},
+		{
+			name:         "ok details",
+			dto:          dto.TasksDTO{Title: "Купить", Description: "**Молоко**", ActiveAt: "2023-08-04", Priority: "urgent", Due: &dto.DueDTO{Time: "18:30", TimeZone: "Asia/Almaty"}},
+			taskRepo:     entity.Tasks{Title: "Купить", Description: "**Молоко**", ActiveAt: mustDate("2023-08-04"), Status: "todo", Priority: entity.PriorityUrgent, Due: &entity.DueTime{Time: "18:30", TimeZone: "Asia/Almaty"}, Owner: userID, Version: 1},
+			expectedRepo: entity.Tasks{ID: id, Title: "Купить", Description: "**Молоко**", ActiveAt: mustDate("2023-08-04"), Status: "todo", Priority: entity.PriorityUrgent, Due: &entity.DueTime{Time: "18:30", TimeZone: "Asia/Almaty"}, Owner: userID, Version: 1},
+			expectedSrvc: entity.Tasks{ID: id, Title: "Купить", Description: "**Молоко**", ActiveAt: mustDate("2023-08-04"), Status: "todo", Priority: entity.PriorityUrgent, Due: &entity.DueTime{Time: "18:30", TimeZone: "Asia/Almaty"}, Owner: userID, Version: 1},
+		},
+		{
+			name:         "ok due in UTC",
+			dto:          dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", Priority: "low", Due: &dto.DueDTO{Time: "09:00"}},
+			taskRepo:     entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Priority: entity.PriorityLow, Due: &entity.DueTime{Time: "09:00", TimeZone: "UTC"}, Owner: userID, Version: 1},
+			expectedRepo: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Priority: entity.PriorityLow, Due: &entity.DueTime{Time: "09:00", TimeZone: "UTC"}, Owner: userID, Version: 1},
+			expectedSrvc: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Priority: entity.PriorityLow, Due: &entity.DueTime{Time: "09:00", TimeZone: "UTC"}, Owner: userID, Version: 1},
+		},
+		{
+			name:         "ok tags",
+			dto:          dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", Tags: []string{"Дом", " shopping ", "дом"}},
+			taskRepo:     entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Tags: []string{"дом", "shopping"}, Owner: userID, Version: 1},
+			expectedRepo: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Tags: []string{"дом", "shopping"}, Owner: userID, Version: 1},
+			expectedSrvc: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Tags: []string{"дом", "shopping"}, Owner: userID, Version: 1},
+		},
+		{
+			name:         "ok recurrence",
+			dto:          dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", Recurrence: "rrule:freq=weekly;interval=2"},
+			taskRepo:     entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Recurrence: "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR", Owner: userID, Version: 1},
+			expectedRepo: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Recurrence: "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR", Owner: userID, Version: 1},
+			expectedSrvc: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Recurrence: "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR", Owner: userID, Version: 1},
+		},
+		{
+			name:         "ok list",
+			dto:          dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", ListID: listID.Hex()},
+			taskRepo:     entity.Tasks{ListID: &listID, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: userID, Version: 1},
+			expectedRepo: entity.Tasks{ID: id, ListID: &listID, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: userID, Version: 1},
+			expectedSrvc: entity.Tasks{ID: id, ListID: &listID, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: userID, Version: 1},
+		},
+		{
+			name:            "list not found",
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", ListID: listID.Hex()},
+			expectedSrvcErr: custom_error.ErrListNotFound,
 		},
 		{
 			name:            "activeAt invalid format",
 			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-32"},
 			expectedSrvcErr: custom_error.ErrInvalidActiveAtFormat,
 		},
+		{
+			name:            "invalid priority",
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", Priority: "critical"},
+			expectedSrvcErr: custom_error.ErrInvalidPriority,
+		},
+		{
+			name:            "invalid due time",
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", Due: &dto.DueDTO{Time: "25:00"}},
+			expectedSrvcErr: custom_error.ErrInvalidDueTime,
+		},
+		{
+			name:            "invalid due time zone",
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", Due: &dto.DueDTO{Time: "18:30", TimeZone: "Mars/Olympus"}},
+			expectedSrvcErr: custom_error.ErrInvalidDueTime,
+		},
+		{
+			name:            "description too long",
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", Description: strings.Repeat("я", 10001)},
+			expectedSrvcErr: custom_error.ErrDescriptionTooLong,
+		},
+		{
+			name:            "invalid tag",
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", Tags: []string{"#дом"}},
+			expectedSrvcErr: custom_error.ErrInvalidTag,
+		},
+		{
+			name:            "invalid recurrence",
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04", Recurrence: "FREQ=YEARLY"},
+			expectedSrvcErr: custom_error.ErrInvalidRecurrence,
+		},
+		{
+			name:         "ok workspace",
+			access:       &entity.Access{UserID: userID, Owner: workspaceID, Workspace: &workspaceID, Role: entity.RoleEditor},
+			dto:          dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04"},
+			taskRepo:     entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: workspaceID, Workspace: &workspaceID, Version: 1},
+			expectedRepo: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: workspaceID, Workspace: &workspaceID, Version: 1},
+			expectedSrvc: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: workspaceID, Workspace: &workspaceID, Version: 1},
+		},
+		{
+			name:            "viewer",
+			access:          &entity.Access{UserID: userID, Owner: workspaceID, Workspace: &workspaceID, Role: entity.RoleViewer},
+			dto:             dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04"},
+			expectedSrvcErr: custom_error.ErrForbidden,
+		},
+		{
+			name:            "blank title",
+			dto:             dto.TasksDTO{Title: "   ", ActiveAt: "2023-08-04"},
+			expectedSrvcErr: custom_error.ErrEmptyTitle,
+		},
+		{
+			name:            "only weekend prefix",
+			dto:             dto.TasksDTO{Title: "ВЫХОДНОЙ - ", ActiveAt: "2023-08-05"},
+			expectedSrvcErr: custom_error.ErrEmptyTitle,
+		},
 		{
 			name: "more than 200 char",
 			dto: dto.TasksDTO{Title: "Купитьasdfsasddddddddddddddddddddddddddddddddddddddddddddddddddddd" +
@@ -67,20 +170,32 @@ func Test_CreateTask(t *testing.T) {
 
 			service := New(mockRepo, cfg)
 
+			access := testCase.access
+			if access == nil {
+				access = entity.PersonalAccess(userID)
+			}
+
 			switch testCase.name {
-			case "ok", "ok ВЫХОДНОЙ":
+			case "ok", "ok ВЫХОДНОЙ", "ok details", "ok due in UTC", "ok tags", "ok recurrence", "ok list", "ok workspace":
+				if testCase.dto.ListID != "" {
+					mockRepo.EXPECT().GetListByID(ctx, userID, listID).Return(&entity.List{ID: listID, Title: "Ops", Owner: userID}, nil).Times(1)
+				}
 				mockRepo.EXPECT().CreateTask(ctx, &testCase.taskRepo).Return(&testCase.expectedRepo, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, id, 1, entity.HistoryCreate)
 
-				result, err := service.CreateTask(ctx, &testCase.dto)
+				result, err := service.CreateTask(ctx, access, &testCase.dto)
 				require.NoError(t, err)
 				require.NotEmpty(t, result)
 
 				require.Equal(t, *result, testCase.expectedSrvc)
 				break
-			case "activeAt invalid format", "more than 200 char":
+			case "activeAt invalid format", "more than 200 char", "blank title", "only weekend prefix", "invalid priority", "invalid due time", "invalid due time zone", "description too long", "invalid tag", "invalid recurrence", "list not found", "viewer":
+				if testCase.dto.ListID != "" {
+					mockRepo.EXPECT().GetListByID(ctx, userID, listID).Return(nil, custom_error.ErrListNotFound).Times(1)
+				}
 				mockRepo.EXPECT().CreateTask(ctx, &testCase.taskRepo).Return(&testCase.expectedRepo, nil).Times(0)
 
-				_, err = service.CreateTask(ctx, &testCase.dto)
+				_, err = service.CreateTask(ctx, access, &testCase.dto)
 				require.NotEmpty(t, err)
 
 				require.Equal(t, err, testCase.expectedSrvcErr)
@@ -102,18 +217,25 @@ func Test_UpdateTask(t *testing.T) {
 		expectedSrvcErr error
 	}{
 		{
-			name:          "ok_ВЫХОДНОЙ",
+			name:          "ok ВЫХОДНОЙ",
 			dto:           dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-05"},
 			taskRepo:      id,
-			expectedRepo:  entity.Tasks{ID: id, Title: "Купить", ActiveAt: "2023-08-05", Status: "active"},
-			expectedRepo2: entity.Tasks{Title: "ВЫХОДНОЙ - Купить", ActiveAt: "2023-08-05", Status: "active"},
+			expectedRepo:  entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "todo", Owner: userID, Version: 1},
+			expectedRepo2: entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "todo", Weekend: true, Owner: userID},
+		},
+		{
+			name:          "ok decorated title",
+			dto:           dto.TasksDTO{Title: "ВЫХОДНОЙ - Купить", ActiveAt: "2023-08-05"},
+			taskRepo:      id,
+			expectedRepo:  entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "todo", Weekend: true, Owner: userID, Version: 1},
+			expectedRepo2: entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "todo", Weekend: true, Owner: userID},
 		},
 		{
 			name:          "ok",
 			dto:           dto.TasksDTO{Title: "Купить", ActiveAt: "2023-08-04"},
 			taskRepo:      id,
-			expectedRepo:  entity.Tasks{ID: id, Title: "Купить", ActiveAt: "2023-08-04", Status: "active"},
-			expectedRepo2: entity.Tasks{Title: "Купить", ActiveAt: "2023-08-04", Status: "active"},
+			expectedRepo:  entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: userID, Version: 1},
+			expectedRepo2: entity.Tasks{Title: "Купить", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: userID},
 		},
 		{
 			name: "more than 200 char",
@@ -146,18 +268,146 @@ func Test_UpdateTask(t *testing.T) {
 			service := New(mockRepo, cfg)
 
 			switch testCase.name {
-			case "ok", "ok ВЫХОДНОЙ":
-				mockRepo.EXPECT().GetTaskByID(ctx, testCase.taskRepo).Return(&testCase.expectedRepo, nil).Times(1)
-				mockRepo.EXPECT().UpdateTask(ctx, &testCase.expectedRepo2, testCase.taskRepo).Return(nil).Times(1)
+			case "ok", "ok ВЫХОДНОЙ", "ok decorated title":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, testCase.taskRepo).Return(&testCase.expectedRepo, nil).Times(1)
+				updated := testCase.expectedRepo2
+				updated.ID, updated.Version = testCase.taskRepo, 2
+				mockRepo.EXPECT().UpdateTask(ctx, &testCase.expectedRepo2, testCase.taskRepo, int64(1)).Return(&updated, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, updated.ID, updated.Version, entity.HistoryUpdate)
 
-				err = service.UpdateTask(ctx, &testCase.dto, testCase.expectedRepo.ID)
+				err = service.UpdateTask(ctx, userID, &testCase.dto, testCase.expectedRepo.ID, 0)
 				require.NoError(t, err)
 				break
 			case "activeAt invalid format", "more than 200 char":
-				mockRepo.EXPECT().GetTaskByID(ctx, testCase.taskRepo).Return(&testCase.expectedRepo, nil).Times(0)
-				mockRepo.EXPECT().UpdateTask(ctx, &testCase.expectedRepo2, testCase.taskRepo).Return(nil).Times(0)
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, testCase.taskRepo).Return(&testCase.expectedRepo, nil).Times(0)
+				mockRepo.EXPECT().UpdateTask(ctx, &testCase.expectedRepo2, testCase.taskRepo, int64(0)).Times(0)
 
-				err = service.UpdateTask(ctx, &testCase.dto, testCase.expectedRepo.ID)
+				err = service.UpdateTask(ctx, userID, &testCase.dto, testCase.expectedRepo.ID, 0)
+				require.Equal(t, testCase.expectedSrvcErr, err)
+				break
+			}
+		})
+	}
+}
+
+func Test_PatchTask(t *testing.T) {
+	title, decorated, activeAt, invalidDate, empty := "Купить", "ВЫХОДНОЙ - Купить", "2023-08-05", "2023-08-70", ""
+	weekendDate, weekend := mustDate("2023-08-05"), true
+	description, high, invalidPriority, highPriority := "**Молоко**", "high", "critical", entity.PriorityHigh
+	spacedTitle, spacedDescription, blank := "  Купить ", " **Молоко**\n", "   "
+
+	table := []struct {
+		name            string
+		dto             dto.TasksPatchDTO
+		expectedRepo    entity.TaskPatch
+		expectedSrvcErr error
+	}{
+		{
+			name:         "ok title",
+			dto:          dto.TasksPatchDTO{Title: &decorated},
+			expectedRepo: entity.TaskPatch{Title: &title},
+		},
+		{
+			name:         "ok spaces",
+			dto:          dto.TasksPatchDTO{Title: &spacedTitle, Description: &spacedDescription},
+			expectedRepo: entity.TaskPatch{Title: &title, Description: &description},
+		},
+		{
+			name:         "ok activeAt",
+			dto:          dto.TasksPatchDTO{ActiveAt: &activeAt},
+			expectedRepo: entity.TaskPatch{ActiveAt: &weekendDate, Weekend: &weekend},
+		},
+		{
+			name:         "ok empty",
+			dto:          dto.TasksPatchDTO{},
+			expectedRepo: entity.TaskPatch{},
+		},
+		{
+			name:         "ok details",
+			dto:          dto.TasksPatchDTO{Description: &description, Priority: &high, Due: &dto.DueDTO{Time: "07:15", TimeZone: "Europe/Moscow"}},
+			expectedRepo: entity.TaskPatch{Description: &description, Priority: &highPriority, Due: &entity.DueTime{Time: "07:15", TimeZone: "Europe/Moscow"}},
+		},
+		{
+			name:         "ok clear due",
+			dto:          dto.TasksPatchDTO{ClearDue: true},
+			expectedRepo: entity.TaskPatch{ClearDue: true},
+		},
+		{
+			name:            "invalid priority",
+			dto:             dto.TasksPatchDTO{Priority: &invalidPriority},
+			expectedSrvcErr: custom_error.ErrInvalidPriority,
+		},
+		{
+			name:            "invalid due time",
+			dto:             dto.TasksPatchDTO{Due: &dto.DueDTO{Time: "7:15"}},
+			expectedSrvcErr: custom_error.ErrInvalidDueTime,
+		},
+		{
+			name:            "empty title",
+			dto:             dto.TasksPatchDTO{Title: &empty},
+			expectedSrvcErr: custom_error.ErrEmptyTitle,
+		},
+		{
+			name:            "stale version",
+			dto:             dto.TasksPatchDTO{Title: &title},
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+		},
+		{
+			name:            "blank title",
+			dto:             dto.TasksPatchDTO{Title: &blank},
+			expectedSrvcErr: custom_error.ErrEmptyTitle,
+		},
+		{
+			name:            "activeAt invalid format",
+			dto:             dto.TasksPatchDTO{ActiveAt: &invalidDate},
+			expectedSrvcErr: custom_error.ErrInvalidActiveAtFormat,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+			id := primitive.NewObjectID()
+
+			service := New(mockRepo, cfg)
+
+			switch testCase.name {
+			case "ok title", "ok spaces", "ok activeAt", "ok details", "ok clear due":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&entity.Tasks{ID: id, Owner: userID, Version: 1}, nil).Times(1)
+				mockRepo.EXPECT().PatchTask(ctx, userID, id, &testCase.expectedRepo, int64(1)).Return(&entity.Tasks{ID: id, Owner: userID, Version: 2}, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, id, 2, entity.HistoryUpdate)
+
+				err = service.PatchTask(ctx, userID, &testCase.dto, id, 0)
+				require.NoError(t, err)
+				break
+			case "ok empty":
+				// Пустое изменение не создает версию и не попадает в историю
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&entity.Tasks{ID: id, Owner: userID, Version: 1}, nil).Times(1)
+				mockRepo.EXPECT().PatchTask(ctx, userID, id, &testCase.expectedRepo, int64(1)).Return(&entity.Tasks{ID: id, Owner: userID, Version: 1}, nil).Times(1)
+				mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).Times(0)
+
+				err = service.PatchTask(ctx, userID, &testCase.dto, id, 0)
+				require.NoError(t, err)
+				break
+			case "stale version":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, id).Return(&entity.Tasks{ID: id, Owner: userID, Version: 3}, nil).Times(1)
+				mockRepo.EXPECT().PatchTask(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				err = service.PatchTask(ctx, userID, &testCase.dto, id, 2)
+				require.Equal(t, testCase.expectedSrvcErr, err)
+				break
+			case "empty title", "blank title", "activeAt invalid format", "invalid priority", "invalid due time":
+				mockRepo.EXPECT().PatchTask(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				err = service.PatchTask(ctx, userID, &testCase.dto, id, 0)
 				require.Equal(t, testCase.expectedSrvcErr, err)
 				break
 			}
@@ -167,14 +417,58 @@ func Test_UpdateTask(t *testing.T) {
 
 func Test_UpdateTaskStatus(t *testing.T) {
 	table := []struct {
-		name   string
-		id     primitive.ObjectID
-		status string
+		name            string
+		id              primitive.ObjectID
+		current         string
+		status          string
+		version         int64
+		expectedSrvcErr error
 	}{
 		{
-			name:   "ok",
-			id:     primitive.NewObjectID(),
-			status: "done",
+			name:    "ok",
+			id:      primitive.NewObjectID(),
+			current: "todo",
+			status:  "in_progress",
+			version: 1,
+		},
+		{
+			name:    "ok reopen",
+			id:      primitive.NewObjectID(),
+			current: "done",
+			status:  "todo",
+		},
+		{
+			name:    "same status",
+			id:      primitive.NewObjectID(),
+			current: "done",
+			status:  "done",
+		},
+		{
+			name:            "invalid transition",
+			id:              primitive.NewObjectID(),
+			current:         "archived",
+			status:          "done",
+			expectedSrvcErr: custom_error.ErrInvalidTransition,
+		},
+		{
+			name:            "stale version",
+			id:              primitive.NewObjectID(),
+			current:         "todo",
+			status:          "done",
+			version:         2,
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+		},
+		{
+			name:            "invalid status",
+			id:              primitive.NewObjectID(),
+			status:          "active",
+			expectedSrvcErr: custom_error.ErrInvalidStatus,
+		},
+		{
+			name:            "task not found",
+			id:              primitive.NewObjectID(),
+			status:          "done",
+			expectedSrvcErr: custom_error.ErrTaskNotFound,
 		},
 	}
 
@@ -190,31 +484,149 @@ func Test_UpdateTaskStatus(t *testing.T) {
 
 			ctx := context.Background()
 
-			mockRepo.EXPECT().UpdateTaskStatus(ctx, testCase.id, testCase.status).Return(nil).Times(1)
+			task := &entity.Tasks{ID: testCase.id, Title: "Купить", Status: testCase.current, Owner: userID, Version: 1}
+
+			switch testCase.name {
+			case "ok", "ok reopen":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, testCase.id).Return(task, nil).Times(1)
+				// Без If-Match статус все равно записывается поверх прочитанной версии
+				mockRepo.EXPECT().UpdateTaskStatus(ctx, userID, testCase.id, testCase.status, int64(1)).Return(&entity.Tasks{ID: testCase.id, Title: "Купить", Status: testCase.status, Owner: userID, Version: 2}, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, testCase.id, 2, entity.HistoryStatus)
+				break
+			case "same status", "invalid transition", "stale version":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, testCase.id).Return(task, nil).Times(1)
+				mockRepo.EXPECT().UpdateTaskStatus(ctx, userID, testCase.id, testCase.status, testCase.version).Times(0)
+				break
+			case "invalid status":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, testCase.id).Times(0)
+				break
+			case "task not found":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, testCase.id).Return(nil, custom_error.ErrTaskNotFound).Times(1)
+				break
+			}
 
 			service := New(mockRepo, cfg)
 
-			err = service.UpdateTaskStatus(ctx, testCase.id, testCase.status)
+			err = service.UpdateTaskStatus(ctx, userID, testCase.id, testCase.status, testCase.version)
+			if testCase.expectedSrvcErr != nil {
+				require.ErrorIs(t, err, testCase.expectedSrvcErr)
+				return
+			}
 			require.NoError(t, err)
 		})
 	}
 }
 
 func Test_GetAllTasks(t *testing.T) {
+	id1, id2 := primitive.NewObjectID(), primitive.NewObjectID()
+	task1 := entity.Tasks{ID: id1, Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "todo"}
+	task2 := entity.Tasks{ID: id2, Title: "Продать", ActiveAt: mustDate("2023-08-06"), Status: "todo"}
+
 	table := []struct {
-		name         string
-		status       string
-		expectedRepo []entity.Tasks
+		name            string
+		query           dto.TasksQueryDTO
+		filterRepo      entity.TaskFilter
+		expectedRepo    []entity.Tasks
+		totalRepo       int64
+		expectedSrvc    dto.TasksPageDTO
+		expectedSrvcErr error
 	}{
 		{
 			name:         "ok",
-			status:       "active",
-			expectedRepo: []entity.Tasks{{ID: primitive.NewObjectID(), Title: "Купить", ActiveAt: "2023-08-05", Status: "active"}},
+			query:        dto.TasksQueryDTO{Status: "todo"},
+			filterRepo:   entity.TaskFilter{Owner: userID, Statuses: []string{"todo"}, SortField: "createdAt", Limit: 21},
+			expectedRepo: []entity.Tasks{task1},
+			totalRepo:    1,
+			expectedSrvc: dto.TasksPageDTO{Tasks: []entity.Tasks{task1}, Total: 1},
 		},
 		{
 			name:         "ok empty array",
-			status:       "active",
-			expectedRepo: make([]entity.Tasks, 0),
+			query:        dto.TasksQueryDTO{},
+			filterRepo:   entity.TaskFilter{Owner: userID, Statuses: []string{"todo", "in_progress"}, SortField: "createdAt", Limit: 21},
+			expectedSrvc: dto.TasksPageDTO{Tasks: make([]entity.Tasks, 0)},
+		},
+		{
+			name:         "ok next page",
+			query:        dto.TasksQueryDTO{Status: "done", Limit: 1, Sort: "-activeAt", Cursor: encodeCursor(id2)},
+			filterRepo:   entity.TaskFilter{Owner: userID, Statuses: []string{"done"}, SortField: "activeAt", SortDesc: true, After: id2, Limit: 2},
+			expectedRepo: []entity.Tasks{task1, task2},
+			totalRepo:    3,
+			expectedSrvc: dto.TasksPageDTO{Tasks: []entity.Tasks{task1}, NextCursor: encodeCursor(id1), Total: 3},
+		},
+		{
+			name:         "ok period week",
+			query:        dto.TasksQueryDTO{Period: "week"},
+			filterRepo:   entity.TaskFilter{Owner: userID, Statuses: []string{"todo", "in_progress"}, ActiveFrom: mustDate("2023-08-07"), ActiveTo: mustDate("2023-08-13"), SortField: "createdAt", Limit: 21},
+			expectedSrvc: dto.TasksPageDTO{Tasks: make([]entity.Tasks, 0)},
+		},
+		{
+			name:         "ok period overdue",
+			query:        dto.TasksQueryDTO{Period: "overdue"},
+			filterRepo:   entity.TaskFilter{Owner: userID, Statuses: []string{"todo", "in_progress"}, ActiveTo: mustDate("2023-08-08"), SortField: "createdAt", Limit: 21},
+			expectedSrvc: dto.TasksPageDTO{Tasks: make([]entity.Tasks, 0)},
+		},
+		{
+			name:         "ok from to",
+			query:        dto.TasksQueryDTO{From: "2023-08-01", To: "2023-08-31"},
+			filterRepo:   entity.TaskFilter{Owner: userID, Statuses: []string{"todo", "in_progress"}, ActiveFrom: mustDate("2023-08-01"), ActiveTo: mustDate("2023-08-31"), SortField: "createdAt", Limit: 21},
+			expectedSrvc: dto.TasksPageDTO{Tasks: make([]entity.Tasks, 0)},
+		},
+		{
+			name:         "ok priority",
+			query:        dto.TasksQueryDTO{Priority: "high, urgent", Sort: "-priority"},
+			filterRepo:   entity.TaskFilter{Owner: userID, Statuses: []string{"todo", "in_progress"}, Priorities: []entity.Priority{entity.PriorityHigh, entity.PriorityUrgent}, SortField: "priority", SortDesc: true, Limit: 21},
+			expectedSrvc: dto.TasksPageDTO{Tasks: make([]entity.Tasks, 0)},
+		},
+		{
+			name:         "ok tags",
+			query:        dto.TasksQueryDTO{Tags: []string{" Backend", "ops", "backend"}, TagMatch: "any"},
+			filterRepo:   entity.TaskFilter{Owner: userID, Statuses: []string{"todo", "in_progress"}, Tags: []string{"backend", "ops"}, SortField: "createdAt", Limit: 21},
+			expectedSrvc: dto.TasksPageDTO{Tasks: make([]entity.Tasks, 0)},
+		},
+		{
+			name:            "invalid priority",
+			query:           dto.TasksQueryDTO{Priority: "high,critical"},
+			expectedSrvcErr: custom_error.ErrInvalidPriority,
+		},
+		{
+			name:            "invalid tag",
+			query:           dto.TasksQueryDTO{Tags: []string{"two words"}},
+			expectedSrvcErr: custom_error.ErrInvalidTag,
+		},
+		{
+			name:            "invalid tag match",
+			query:           dto.TasksQueryDTO{Tags: []string{"ops"}, TagMatch: "none"},
+			expectedSrvcErr: custom_error.ErrInvalidTagMatch,
+		},
+		{
+			name:            "invalid date range",
+			query:           dto.TasksQueryDTO{From: "2023-08-31", To: "2023-08-01"},
+			expectedSrvcErr: custom_error.ErrInvalidDateRange,
+		},
+		{
+			name:            "invalid period",
+			query:           dto.TasksQueryDTO{Period: "today", From: "2023-08-01"},
+			expectedSrvcErr: custom_error.ErrInvalidPeriod,
+		},
+		{
+			name:            "invalid status",
+			query:           dto.TasksQueryDTO{Status: "active"},
+			expectedSrvcErr: custom_error.ErrInvalidStatus,
+		},
+		{
+			name:            "invalid limit",
+			query:           dto.TasksQueryDTO{Limit: 101},
+			expectedSrvcErr: custom_error.ErrInvalidLimit,
+		},
+		{
+			name:            "invalid sort",
+			query:           dto.TasksQueryDTO{Sort: "status"},
+			expectedSrvcErr: custom_error.ErrInvalidSort,
+		},
+		{
+			name:            "invalid cursor",
+			query:           dto.TasksQueryDTO{Cursor: "abc"},
+			expectedSrvcErr: custom_error.ErrInvalidCursor,
 		},
 	}
 
@@ -231,32 +643,112 @@ func Test_GetAllTasks(t *testing.T) {
 			ctx := context.Background()
 
 			service := New(mockRepo, cfg)
+			service.now = func() time.Time {
+				return time.Date(2023, time.August, 9, 15, 0, 0, 0, time.UTC)
+			}
 
 			switch testCase.name {
-			case "ok":
-				mockRepo.EXPECT().GetAllTasks(ctx, testCase.status).Return(testCase.expectedRepo, nil).Times(1)
+			case "ok", "ok empty array", "ok next page", "ok period week", "ok period overdue", "ok from to", "ok priority", "ok tags":
+				mockRepo.EXPECT().GetAllTasks(ctx, &testCase.filterRepo).Return(testCase.expectedRepo, testCase.totalRepo, nil).Times(1)
+
+				result, err := service.GetAllTasks(ctx, userID, &testCase.query)
+				require.NoError(t, err)
+				require.Equal(t, testCase.expectedSrvc, *result)
 				break
-			case "ok empty array":
-				mockRepo.EXPECT().GetAllTasks(ctx, testCase.status).Return(nil, nil).Times(1)
+			case "invalid status", "invalid limit", "invalid sort", "invalid cursor", "invalid date range", "invalid period", "invalid priority", "invalid tag", "invalid tag match":
+				mockRepo.EXPECT().GetAllTasks(ctx, gomock.Any()).Times(0)
+
+				_, err = service.GetAllTasks(ctx, userID, &testCase.query)
+				require.Equal(t, testCase.expectedSrvcErr, err)
 				break
 			}
+		})
+	}
+}
+
+func Test_GetTaskByID(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		id              primitive.ObjectID
+		expectedRepo    entity.Tasks
+		expectedSrvcErr error
+	}{
+		{
+			name:         "ok",
+			id:           id,
+			expectedRepo: entity.Tasks{ID: id, Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "todo"},
+		},
+		{
+			name:            "task not found",
+			id:              id,
+			expectedSrvcErr: custom_error.ErrTaskNotFound,
+		},
+	}
 
-			result, err := service.GetAllTasks(ctx, testCase.status)
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
 			require.NoError(t, err)
-			require.Equal(t, testCase.expectedRepo, result)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			service := New(mockRepo, cfg)
+
+			switch testCase.name {
+			case "ok":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, testCase.id).Return(&testCase.expectedRepo, nil).Times(1)
+
+				result, err := service.GetTaskByID(ctx, userID, testCase.id)
+				require.NoError(t, err)
+				require.Equal(t, testCase.expectedRepo, *result)
+				break
+			case "task not found":
+				mockRepo.EXPECT().GetTaskByID(ctx, userID, testCase.id).Return(nil, testCase.expectedSrvcErr).Times(1)
+
+				_, err = service.GetTaskByID(ctx, userID, testCase.id)
+				require.Equal(t, testCase.expectedSrvcErr, err)
+				break
+			}
 		})
 	}
 }
 
 func Test_DeleteTask(t *testing.T) {
 	table := []struct {
-		name string
-		id   primitive.ObjectID
+		name            string
+		id              primitive.ObjectID
+		version         int64
+		expectedRepoErr error
+		expectedSrvcErr error
 	}{
 		{
 			name: "ok",
 			id:   primitive.NewObjectID(),
 		},
+		{
+			name:    "ok with If-Match",
+			id:      primitive.NewObjectID(),
+			version: 1,
+		},
+		{
+			name:            "stale version",
+			id:              primitive.NewObjectID(),
+			version:         2,
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+		},
+		{
+			name:            "changed after read",
+			id:              primitive.NewObjectID(),
+			expectedRepoErr: custom_error.ErrVersionMismatch,
+			expectedSrvcErr: custom_error.ErrVersionMismatch,
+		},
 	}
 
 	for _, testCase := range table {
@@ -271,12 +763,297 @@ func Test_DeleteTask(t *testing.T) {
 
 			ctx := context.Background()
 
-			mockRepo.EXPECT().DeleteTask(ctx, testCase.id).Return(nil).Times(1)
+			blobs, err := blobstorage.NewLocal(t.TempDir())
+			require.NoError(t, err)
+
+			attachment := entity.Attachment{ID: primitive.NewObjectID(), TaskID: testCase.id, Owner: userID}
+			attachment.Key = attachmentKey(&attachment)
+			err = blobs.Put(ctx, attachment.Key, strings.NewReader("file"), 4, "text/plain")
+			require.NoError(t, err)
+
+			mockRepo.EXPECT().GetTaskByID(ctx, userID, testCase.id).Return(&entity.Tasks{ID: testCase.id, Owner: userID, Version: 1}, nil).Times(1)
+			// Задача удаляется поверх прочитанной версии и с If-Match, и без него
+			switch testCase.name {
+			case "ok", "ok with If-Match":
+				deletedAt := time.Date(2023, 8, 4, 12, 0, 0, 0, time.UTC)
+				mockRepo.EXPECT().DeleteTask(ctx, userID, testCase.id, int64(1)).Return(&entity.Tasks{ID: testCase.id, Owner: userID, Version: 2, DeletedAt: &deletedAt}, nil).Times(1)
+				expectHistory(t, mockRepo, ctx, testCase.id, 2, entity.HistoryDelete)
+				break
+			case "stale version":
+				mockRepo.EXPECT().DeleteTask(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "changed after read":
+				mockRepo.EXPECT().DeleteTask(ctx, userID, testCase.id, int64(1)).Return(nil, testCase.expectedRepoErr).Times(1)
+				mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).Times(0)
+				break
+			}
 
 			service := New(mockRepo, cfg)
+			service.Blobs = blobs
 
-			err = service.DeleteTask(ctx, testCase.id)
+			err = service.DeleteTask(ctx, userID, testCase.id, testCase.version)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+
+			// Файл вложения остается, пока задача не удалена из корзины насовсем
+			_, err = blobs.Get(ctx, attachment.Key)
 			require.NoError(t, err)
 		})
 	}
 }
+
+func Test_SearchTasks(t *testing.T) {
+	id1, id2 := primitive.NewObjectID(), primitive.NewObjectID()
+	task1 := entity.Tasks{ID: id1, Title: "Купить молоко", ActiveAt: mustDate("2023-08-05"), Status: "done"}
+	task2 := entity.Tasks{ID: id2, Title: "Молоко", ActiveAt: mustDate("2023-08-06"), Status: "todo"}
+
+	table := []struct {
+		name            string
+		query           dto.TasksSearchDTO
+		filterRepo      entity.TaskFilter
+		expectedRepo    []entity.Tasks
+		totalRepo       int64
+		expectedSrvc    dto.TasksPageDTO
+		expectedSrvcErr error
+	}{
+		{
+			name:         "ok",
+			query:        dto.TasksSearchDTO{Q: "Купить, МОЛ купить"},
+			filterRepo:   entity.TaskFilter{Owner: userID, Search: []string{"купить", "мол"}, Limit: 21},
+			expectedRepo: []entity.Tasks{task1},
+			totalRepo:    1,
+			expectedSrvc: dto.TasksPageDTO{Tasks: []entity.Tasks{task1}, Total: 1},
+		},
+		{
+			name:         "ok next page",
+			query:        dto.TasksSearchDTO{Q: "мол", Status: "todo", From: "2023-08-01", Limit: 1, Cursor: encodeOffsetCursor(1)},
+			filterRepo:   entity.TaskFilter{Owner: userID, Search: []string{"мол"}, Statuses: []string{"todo"}, ActiveFrom: mustDate("2023-08-01"), Offset: 1, Limit: 2},
+			expectedRepo: []entity.Tasks{task2, task1},
+			totalRepo:    3,
+			expectedSrvc: dto.TasksPageDTO{Tasks: []entity.Tasks{task2}, NextCursor: encodeOffsetCursor(2), Total: 3},
+		},
+		{
+			name:            "no words",
+			query:           dto.TasksSearchDTO{Q: " - "},
+			expectedSrvcErr: custom_error.ErrInvalidSearchQuery,
+		},
+		{
+			name:            "too many words",
+			query:           dto.TasksSearchDTO{Q: "a b c d e f g h i j k"},
+			expectedSrvcErr: custom_error.ErrInvalidSearchQuery,
+		},
+		{
+			name:            "invalid status",
+			query:           dto.TasksSearchDTO{Q: "мол", Status: "deleted"},
+			expectedSrvcErr: custom_error.ErrInvalidStatus,
+		},
+		{
+			name:            "invalid cursor",
+			query:           dto.TasksSearchDTO{Q: "мол", Cursor: encodeCursor(id1)},
+			expectedSrvcErr: custom_error.ErrInvalidCursor,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			service := New(mockRepo, cfg)
+
+			switch testCase.name {
+			case "ok", "ok next page":
+				mockRepo.EXPECT().GetAllTasks(ctx, &testCase.filterRepo).Return(testCase.expectedRepo, testCase.totalRepo, nil).Times(1)
+
+				result, err := service.SearchTasks(ctx, userID, &testCase.query)
+				require.NoError(t, err)
+				require.Equal(t, testCase.expectedSrvc, *result)
+				break
+			case "no words", "too many words", "invalid status", "invalid cursor":
+				mockRepo.EXPECT().GetAllTasks(ctx, gomock.Any()).Times(0)
+
+				_, err = service.SearchTasks(ctx, userID, &testCase.query)
+				require.Equal(t, testCase.expectedSrvcErr, err)
+				break
+			}
+		})
+	}
+}
+
+func Test_GetTrash(t *testing.T) {
+	task1 := entity.Tasks{ID: primitive.NewObjectID(), Title: "Купить", ActiveAt: mustDate("2023-08-05"), Status: "done"}
+
+	table := []struct {
+		name            string
+		query           dto.TasksQueryDTO
+		filterRepo      entity.TaskFilter
+		expectedRepo    []entity.Tasks
+		totalRepo       int64
+		expectedSrvc    dto.TasksPageDTO
+		expectedSrvcErr error
+	}{
+		{
+			name:         "ok",
+			query:        dto.TasksQueryDTO{},
+			filterRepo:   entity.TaskFilter{Owner: userID, Deleted: true, SortField: "createdAt", Limit: 21},
+			expectedRepo: []entity.Tasks{task1},
+			totalRepo:    1,
+			expectedSrvc: dto.TasksPageDTO{Tasks: []entity.Tasks{task1}, Total: 1},
+		},
+		{
+			name:         "ok with status",
+			query:        dto.TasksQueryDTO{Status: "archived", Sort: "title"},
+			filterRepo:   entity.TaskFilter{Owner: userID, Deleted: true, Statuses: []string{"archived"}, SortField: "title", Limit: 21},
+			expectedSrvc: dto.TasksPageDTO{Tasks: make([]entity.Tasks, 0)},
+		},
+		{
+			name:            "invalid status",
+			query:           dto.TasksQueryDTO{Status: "deleted"},
+			expectedSrvcErr: custom_error.ErrInvalidStatus,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			service := New(mockRepo, cfg)
+
+			switch testCase.name {
+			case "ok", "ok with status":
+				mockRepo.EXPECT().GetAllTasks(ctx, &testCase.filterRepo).Return(testCase.expectedRepo, testCase.totalRepo, nil).Times(1)
+
+				result, err := service.GetTrash(ctx, userID, &testCase.query)
+				require.NoError(t, err)
+				require.Equal(t, testCase.expectedSrvc, *result)
+				break
+			case "invalid status":
+				mockRepo.EXPECT().GetAllTasks(ctx, gomock.Any()).Times(0)
+
+				_, err = service.GetTrash(ctx, userID, &testCase.query)
+				require.Equal(t, testCase.expectedSrvcErr, err)
+				break
+			}
+		})
+	}
+}
+
+func Test_RestoreTask(t *testing.T) {
+	table := []struct {
+		name            string
+		id              primitive.ObjectID
+		expectedSrvcErr error
+	}{
+		{
+			name: "ok",
+			id:   primitive.NewObjectID(),
+		},
+		{
+			name:            "title taken",
+			id:              primitive.NewObjectID(),
+			expectedSrvcErr: custom_error.ErrDuplicateTask,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			deleted := time.Date(2023, 8, 4, 12, 0, 0, 0, time.UTC)
+			history := []entity.HistoryEntry{
+				{TaskID: testCase.id, Owner: userID, Version: 2, Action: entity.HistoryDelete, Snapshot: entity.Tasks{ID: testCase.id, Owner: userID, Version: 2, DeletedAt: &deleted}},
+			}
+
+			mockRepo.EXPECT().GetHistory(ctx, userID, testCase.id).Return(history, nil).Times(1)
+			switch testCase.name {
+			case "ok":
+				mockRepo.EXPECT().RestoreTask(ctx, userID, testCase.id).Return(&entity.Tasks{ID: testCase.id, Owner: userID, Version: 3}, nil).Times(1)
+				mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *entity.HistoryEntry) error {
+					require.Equal(t, entity.HistoryRestore, e.Action)
+					require.Equal(t, int64(3), e.Version)
+					require.Equal(t, []entity.FieldChange{
+						{Field: "deletedAt", Before: json.RawMessage(`"2023-08-04T12:00:00Z"`), After: json.RawMessage(`null`)},
+					}, e.Changes)
+					return nil
+				}).Times(1)
+				break
+			case "title taken":
+				mockRepo.EXPECT().RestoreTask(ctx, userID, testCase.id).Return(nil, testCase.expectedSrvcErr).Times(1)
+				mockRepo.EXPECT().CreateHistoryEntry(ctx, gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			err = service.RestoreTask(ctx, userID, testCase.id)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+		})
+	}
+}
+
+func Test_PurgeTrash(t *testing.T) {
+	cfg, err := config.InitConfig("../../config.yaml")
+	require.NoError(t, err)
+	cfg.Trash.Retention = 24 * time.Hour
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRepo := mock_repository.NewMockRepository(controller)
+
+	ctx := context.Background()
+
+	blobs, err := blobstorage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	attachment := entity.Attachment{ID: primitive.NewObjectID(), TaskID: primitive.NewObjectID(), Owner: userID}
+	attachment.Key = attachmentKey(&attachment)
+	err = blobs.Put(ctx, attachment.Key, strings.NewReader("file"), 4, "text/plain")
+	require.NoError(t, err)
+
+	service := New(mockRepo, cfg)
+	service.Blobs = blobs
+	service.now = func() time.Time {
+		return time.Date(2023, time.August, 9, 15, 0, 0, 0, time.UTC)
+	}
+
+	mockRepo.EXPECT().PurgeDeletedTasks(ctx, time.Date(2023, time.August, 8, 15, 0, 0, 0, time.UTC)).Return(int64(2), []entity.Attachment{attachment}, nil).Times(1)
+
+	purged, err := service.PurgeTrash(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged)
+
+	// Файлы вложений удаляются вместе с задачами из корзины
+	_, err = blobs.Get(ctx, attachment.Key)
+	require.Equal(t, blobstorage.ErrNotFound, err)
+}
+
+func mustDate(s string) entity.Date {
+	d, err := entity.ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
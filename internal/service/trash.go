@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PurgeTrash окончательно удаляет задачи, пролежавшие в корзине дольше config.Trash.Retention,
+// вместе с их комментариями и файлами вложений
+func (m *Manager) PurgeTrash(ctx context.Context) (int64, error) {
+	before := m.now().Add(-m.Config.Trash.Retention)
+
+	purged, attachments, err := m.Repository.PurgeDeletedTasks(ctx, before)
+	if err != nil {
+		return purged, err
+	}
+	m.deleteBlobs(ctx, attachments)
+
+	return purged, nil
+}
+
+// RunTrashPurge очищает корзину каждые config.Trash.PurgeInterval до отмены ctx
+func (m *Manager) RunTrashPurge(ctx context.Context) {
+	interval := m.Config.Trash.PurgeInterval
+	if interval <= 0 {
+		log.Println("trash purge disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := m.PurgeTrash(ctx)
+			if err != nil {
+				log.Printf("trash purge err: %s", err.Error())
+				continue
+			}
+			if purged > 0 {
+				log.Printf("trash purge: %d tasks removed", purged)
+			}
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// invitationTokenBytes - длина случайной части токена приглашения
+const invitationTokenBytes = 32
+
+// CreateWorkspace создает рабочее пространство, пользователь становится его владельцем
+func (m *Manager) CreateWorkspace(ctx context.Context, userID primitive.ObjectID, w *dto.WorkspaceDTO) (*entity.Workspace, error) {
+	title, err := listTitle(w.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := m.Repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace := &entity.Workspace{Title: title, Owner: userID}
+	owner := &entity.Member{UserID: userID, Username: user.Username, Role: entity.RoleOwner}
+
+	newWorkspace, err := m.Repository.CreateWorkspace(ctx, workspace, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	newWorkspace.Role = entity.RoleOwner
+
+	return newWorkspace, nil
+}
+
+func (m *Manager) GetWorkspaces(ctx context.Context, userID primitive.ObjectID) ([]entity.Workspace, error) {
+	return m.Repository.GetWorkspaces(ctx, userID)
+}
+
+func (m *Manager) GetWorkspaceByID(ctx context.Context, userID, id primitive.ObjectID) (*entity.Workspace, error) {
+	access, err := m.GetAccess(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := m.Repository.GetWorkspaceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace.Role = access.Role
+
+	return workspace, nil
+}
+
+// UpdateWorkspace переименовывает пространство, доступно только владельцу
+func (m *Manager) UpdateWorkspace(ctx context.Context, userID, id primitive.ObjectID, w *dto.WorkspaceDTO) error {
+	title, err := listTitle(w.Title)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.requireRole(ctx, userID, id, entity.RoleOwner)
+	if err != nil {
+		return err
+	}
+
+	return m.Repository.UpdateWorkspace(ctx, &entity.Workspace{ID: id, Title: title})
+}
+
+// DeleteWorkspace удаляет пространство вместе с его задачами, списками и файлами вложений,
+// доступно только владельцу
+func (m *Manager) DeleteWorkspace(ctx context.Context, userID, id primitive.ObjectID) error {
+	_, err := m.requireRole(ctx, userID, id, entity.RoleOwner)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := m.Repository.DeleteOwnerAttachments(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = m.Repository.DeleteWorkspace(ctx, id)
+	if err != nil {
+		return err
+	}
+	m.deleteBlobs(ctx, attachments)
+
+	return nil
+}
+
+func (m *Manager) GetMembers(ctx context.Context, userID, id primitive.ObjectID) ([]entity.Member, error) {
+	_, err := m.GetAccess(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Repository.GetMembers(ctx, id)
+}
+
+// UpdateMemberRole меняет роль участника, доступно только владельцу.
+// Роль самого владельца не меняется
+func (m *Manager) UpdateMemberRole(ctx context.Context, userID, id, memberID primitive.ObjectID, r *dto.MemberRoleDTO) error {
+	_, err := m.requireRole(ctx, userID, id, entity.RoleOwner)
+	if err != nil {
+		return err
+	}
+
+	member, err := m.Repository.GetMember(ctx, id, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == entity.RoleOwner {
+		return custom_error.ErrWorkspaceOwner
+	}
+
+	return m.Repository.UpdateMemberRole(ctx, id, memberID, r.Role)
+}
+
+// RemoveMember исключает участника из пространства. Исключать может владелец,
+// выйти сам - любой участник, кроме владельца
+func (m *Manager) RemoveMember(ctx context.Context, userID, id, memberID primitive.ObjectID) error {
+	access, err := m.GetAccess(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if memberID != userID && !access.Can(entity.RoleOwner) {
+		return custom_error.ErrForbidden
+	}
+
+	member, err := m.Repository.GetMember(ctx, id, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == entity.RoleOwner {
+		return custom_error.ErrWorkspaceOwner
+	}
+
+	return m.Repository.RemoveMember(ctx, id, memberID)
+}
+
+// CreateInvitation создает одноразовое приглашение с ролью r.Role, доступно только владельцу.
+// Токен возвращается только здесь, хранится его хэш
+func (m *Manager) CreateInvitation(ctx context.Context, userID, id primitive.ObjectID, r *dto.InvitationDTO) (*entity.Invitation, error) {
+	_, err := m.requireRole(ctx, userID, id, entity.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &entity.Invitation{
+		Token:     token,
+		TokenHash: hashInvitationToken(token),
+		Workspace: id,
+		Role:      r.Role,
+		CreatedBy: userID,
+		ExpiresAt: m.now().UTC().Add(m.Config.Workspace.InvitationTTL),
+	}
+
+	err = m.Repository.CreateInvitation(ctx, invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// AcceptInvitation добавляет пользователя в пространство приглашения. Приглашение
+// удаляется, поэтому второй раз его принять нельзя
+func (m *Manager) AcceptInvitation(ctx context.Context, userID primitive.ObjectID, token string) (*entity.Member, error) {
+	hash := hashInvitationToken(token)
+
+	invitation, err := m.Repository.GetInvitation(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.now().Before(invitation.ExpiresAt) {
+		return nil, custom_error.ErrInvitationNotFound
+	}
+
+	_, err = m.Repository.GetMember(ctx, invitation.Workspace, userID)
+	if err == nil {
+		return nil, custom_error.ErrAlreadyMember
+	}
+	if !errors.Is(err, custom_error.ErrMemberNotFound) {
+		return nil, err
+	}
+
+	user, err := m.Repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.Repository.DeleteInvitation(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	member := &entity.Member{
+		Workspace: invitation.Workspace,
+		UserID:    userID,
+		Username:  user.Username,
+		Role:      invitation.Role,
+	}
+
+	err = m.Repository.AddMember(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// GetAccess возвращает права пользователя на задачи пространства workspace.
+// Пространство, где пользователь не участвует, для него не существует
+func (m *Manager) GetAccess(ctx context.Context, userID, workspace primitive.ObjectID) (*entity.Access, error) {
+	member, err := m.Repository.GetMember(ctx, workspace, userID)
+	if err != nil {
+		if errors.Is(err, custom_error.ErrMemberNotFound) {
+			return nil, custom_error.ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+
+	return &entity.Access{
+		UserID:    userID,
+		Owner:     workspace,
+		Workspace: &workspace,
+		Role:      member.Role,
+	}, nil
+}
+
+// requireRole возвращает права пользователя на пространство, если его роль не ниже role
+func (m *Manager) requireRole(ctx context.Context, userID, workspace primitive.ObjectID, role entity.Role) (*entity.Access, error) {
+	access, err := m.GetAccess(ctx, userID, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if !access.Can(role) {
+		return nil, custom_error.ErrForbidden
+	}
+
+	return access, nil
+}
+
+func newInvitationToken() (string, error) {
+	b := make([]byte, invitationTokenBytes)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/custom_error"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	mock_repository "github.com/khussa1n/todo-list/internal/repository/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+func Test_GetAccess(t *testing.T) {
+	workspaceID := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		member          *entity.Member
+		expectedRepoErr error
+		expectedSrvc    *entity.Access
+		expectedSrvcErr error
+	}{
+		{
+			name:         "ok",
+			member:       &entity.Member{Workspace: workspaceID, UserID: userID, Role: entity.RoleViewer},
+			expectedSrvc: &entity.Access{UserID: userID, Owner: workspaceID, Workspace: &workspaceID, Role: entity.RoleViewer},
+		},
+		{
+			name:            "not a member",
+			expectedRepoErr: custom_error.ErrMemberNotFound,
+			expectedSrvcErr: custom_error.ErrWorkspaceNotFound,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			mockRepo.EXPECT().GetMember(ctx, workspaceID, userID).Return(testCase.member, testCase.expectedRepoErr).Times(1)
+
+			service := New(mockRepo, cfg)
+
+			access, err := service.GetAccess(ctx, userID, workspaceID)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+			require.Equal(t, testCase.expectedSrvc, access)
+		})
+	}
+}
+
+func Test_RemoveMember(t *testing.T) {
+	workspaceID := primitive.NewObjectID()
+	memberID := primitive.NewObjectID()
+
+	table := []struct {
+		name            string
+		role            entity.Role
+		memberID        primitive.ObjectID
+		memberRole      entity.Role
+		expectedSrvcErr error
+	}{
+		{
+			name:       "ok owner removes editor",
+			role:       entity.RoleOwner,
+			memberID:   memberID,
+			memberRole: entity.RoleEditor,
+		},
+		{
+			name:       "ok viewer leaves",
+			role:       entity.RoleViewer,
+			memberID:   userID,
+			memberRole: entity.RoleViewer,
+		},
+		{
+			name:            "editor removes viewer",
+			role:            entity.RoleEditor,
+			memberID:        memberID,
+			expectedSrvcErr: custom_error.ErrForbidden,
+		},
+		{
+			name:            "owner leaves",
+			role:            entity.RoleOwner,
+			memberID:        userID,
+			memberRole:      entity.RoleOwner,
+			expectedSrvcErr: custom_error.ErrWorkspaceOwner,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+
+			caller := &entity.Member{Workspace: workspaceID, UserID: userID, Role: testCase.role}
+			target := &entity.Member{Workspace: workspaceID, UserID: testCase.memberID, Role: testCase.memberRole}
+
+			switch testCase.name {
+			case "ok owner removes editor":
+				mockRepo.EXPECT().GetMember(ctx, workspaceID, userID).Return(caller, nil).Times(1)
+				mockRepo.EXPECT().GetMember(ctx, workspaceID, memberID).Return(target, nil).Times(1)
+				mockRepo.EXPECT().RemoveMember(ctx, workspaceID, memberID).Return(nil).Times(1)
+				break
+			case "ok viewer leaves":
+				mockRepo.EXPECT().GetMember(ctx, workspaceID, userID).Return(caller, nil).Times(2)
+				mockRepo.EXPECT().RemoveMember(ctx, workspaceID, userID).Return(nil).Times(1)
+				break
+			case "editor removes viewer":
+				mockRepo.EXPECT().GetMember(ctx, workspaceID, userID).Return(caller, nil).Times(1)
+				mockRepo.EXPECT().RemoveMember(ctx, gomock.Any(), gomock.Any()).Times(0)
+				break
+			case "owner leaves":
+				mockRepo.EXPECT().GetMember(ctx, workspaceID, userID).Return(caller, nil).Times(2)
+				mockRepo.EXPECT().RemoveMember(ctx, gomock.Any(), gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+
+			err = service.RemoveMember(ctx, userID, workspaceID, testCase.memberID)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+		})
+	}
+}
+
+func Test_CreateInvitation(t *testing.T) {
+	cfg, err := config.InitConfig("../../config.yaml")
+	require.NoError(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRepo := mock_repository.NewMockRepository(controller)
+
+	ctx := context.Background()
+	workspaceID := primitive.NewObjectID()
+	now := time.Date(2023, 8, 4, 12, 0, 0, 0, time.UTC)
+
+	var stored *entity.Invitation
+	mockRepo.EXPECT().GetMember(ctx, workspaceID, userID).Return(&entity.Member{Workspace: workspaceID, UserID: userID, Role: entity.RoleOwner}, nil).Times(1)
+	mockRepo.EXPECT().CreateInvitation(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, i *entity.Invitation) error {
+		stored = i
+		return nil
+	}).Times(1)
+
+	service := New(mockRepo, cfg)
+	service.now = func() time.Time {
+		return now
+	}
+
+	invitation, err := service.CreateInvitation(ctx, userID, workspaceID, &dto.InvitationDTO{Role: entity.RoleEditor})
+	require.NoError(t, err)
+	require.NotEmpty(t, invitation.Token)
+	require.Equal(t, hashInvitationToken(invitation.Token), stored.TokenHash)
+	require.NotEqual(t, invitation.Token, stored.TokenHash)
+	require.Equal(t, entity.RoleEditor, stored.Role)
+	require.Equal(t, now.Add(cfg.Workspace.InvitationTTL), stored.ExpiresAt)
+}
+
+func Test_AcceptInvitation(t *testing.T) {
+	workspaceID := primitive.NewObjectID()
+	token := "invitation-token"
+	now := time.Date(2023, 8, 4, 12, 0, 0, 0, time.UTC)
+	invitation := &entity.Invitation{
+		TokenHash: hashInvitationToken(token),
+		Workspace: workspaceID,
+		Role:      entity.RoleViewer,
+		ExpiresAt: now.Add(time.Hour),
+	}
+
+	table := []struct {
+		name            string
+		invitation      *entity.Invitation
+		expectedSrvcErr error
+	}{
+		{
+			name:       "ok",
+			invitation: invitation,
+		},
+		{
+			name:            "expired",
+			invitation:      &entity.Invitation{TokenHash: invitation.TokenHash, Workspace: workspaceID, Role: entity.RoleViewer, ExpiresAt: now},
+			expectedSrvcErr: custom_error.ErrInvitationNotFound,
+		},
+		{
+			name:            "already member",
+			invitation:      invitation,
+			expectedSrvcErr: custom_error.ErrAlreadyMember,
+		},
+		{
+			name:            "already accepted",
+			invitation:      invitation,
+			expectedSrvcErr: custom_error.ErrInvitationNotFound,
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := config.InitConfig("../../config.yaml")
+			require.NoError(t, err)
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockRepo := mock_repository.NewMockRepository(controller)
+
+			ctx := context.Background()
+			user := &entity.User{ID: userID, Username: "dana"}
+
+			mockRepo.EXPECT().GetInvitation(ctx, invitation.TokenHash).Return(testCase.invitation, nil).Times(1)
+
+			switch testCase.name {
+			case "ok":
+				mockRepo.EXPECT().GetMember(ctx, workspaceID, userID).Return(nil, custom_error.ErrMemberNotFound).Times(1)
+				mockRepo.EXPECT().GetUserByID(ctx, userID).Return(user, nil).Times(1)
+				mockRepo.EXPECT().DeleteInvitation(ctx, invitation.TokenHash).Return(nil).Times(1)
+				mockRepo.EXPECT().AddMember(ctx, &entity.Member{Workspace: workspaceID, UserID: userID, Username: "dana", Role: entity.RoleViewer}).Return(nil).Times(1)
+				break
+			case "expired":
+				mockRepo.EXPECT().DeleteInvitation(ctx, gomock.Any()).Times(0)
+				break
+			case "already member":
+				mockRepo.EXPECT().GetMember(ctx, workspaceID, userID).Return(&entity.Member{Role: entity.RoleEditor}, nil).Times(1)
+				mockRepo.EXPECT().DeleteInvitation(ctx, gomock.Any()).Times(0)
+				break
+			case "already accepted":
+				mockRepo.EXPECT().GetMember(ctx, workspaceID, userID).Return(nil, custom_error.ErrMemberNotFound).Times(1)
+				mockRepo.EXPECT().GetUserByID(ctx, userID).Return(user, nil).Times(1)
+				mockRepo.EXPECT().DeleteInvitation(ctx, invitation.TokenHash).Return(custom_error.ErrInvitationNotFound).Times(1)
+				mockRepo.EXPECT().AddMember(ctx, gomock.Any()).Times(0)
+				break
+			}
+
+			service := New(mockRepo, cfg)
+			service.now = func() time.Time {
+				return now
+			}
+
+			member, err := service.AcceptInvitation(ctx, userID, token)
+			require.Equal(t, testCase.expectedSrvcErr, err)
+			if testCase.expectedSrvcErr == nil {
+				require.Equal(t, entity.RoleViewer, member.Role)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package blobstorage
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	storage, err := NewLocal(dir)
+	require.NoError(t, err)
+
+	content := []byte("screenshot")
+	err = storage.Put(ctx, "owner/task/file", bytes.NewReader(content), int64(len(content)), "image/png")
+	require.NoError(t, err)
+
+	file, err := storage.Get(ctx, "owner/task/file")
+	require.NoError(t, err)
+	got, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.Equal(t, content, got)
+
+	// Временные файлы не остаются в каталоге
+	entries, err := os.ReadDir(filepath.Join(dir, "owner", "task"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	err = storage.Put(ctx, "owner/task/short", bytes.NewReader(content), int64(len(content))+1, "")
+	require.Error(t, err)
+	_, err = storage.Get(ctx, "owner/task/short")
+	require.Equal(t, ErrNotFound, err)
+
+	require.NoError(t, storage.Delete(ctx, "owner/task/file"))
+	require.NoError(t, storage.Delete(ctx, "owner/task/file"))
+	_, err = storage.Get(ctx, "owner/task/file")
+	require.Equal(t, ErrNotFound, err)
+
+	for _, key := range []string{"", "../file", "owner/../../file", "/file", "owner//file", `owner\file`} {
+		_, err = storage.Get(ctx, key)
+		require.Equal(t, ErrInvalidKey, err, key)
+	}
+}
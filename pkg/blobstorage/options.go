@@ -0,0 +1,37 @@
+package blobstorage
+
+import "net/http"
+
+type Option func(*S3)
+
+// WithEndpoint задает адрес S3-совместимого сервиса, например http://localhost:9000
+func WithEndpoint(endpoint string) Option {
+	return func(s *S3) {
+		s.endpoint = endpoint
+	}
+}
+
+func WithRegion(region string) Option {
+	return func(s *S3) {
+		s.region = region
+	}
+}
+
+func WithBucket(bucket string) Option {
+	return func(s *S3) {
+		s.bucket = bucket
+	}
+}
+
+func WithCredentials(accessKey, secretKey string) Option {
+	return func(s *S3) {
+		s.accessKey = accessKey
+		s.secretKey = secretKey
+	}
+}
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *S3) {
+		s.client = client
+	}
+}
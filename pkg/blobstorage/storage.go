@@ -0,0 +1,37 @@
+package blobstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Storage - хранилище файлов по ключу. Ключ - путь из сегментов через /, без . и ..
+type Storage interface {
+	// Put сохраняет size байт из r под ключом key, прежний файл с этим ключом заменяется
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get открывает файл key, закрывает его вызывающий код
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет файл key, отсутствие файла ошибкой не считается
+	Delete(ctx context.Context, key string) error
+}
+
+func validateKey(key string) error {
+	if key == "" || strings.Contains(key, `\`) {
+		return ErrInvalidKey
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
@@ -40,7 +40,7 @@ func New(cfgOpts ...Option) (*mongo.Database, error) {
 
 	// ping-запрос для подтверждения успешного подключения
 	var result bson.M
-	if err = client.Database(m.dbName).RunCommand(context.TODO(), bson.D{{"ping", 1}}).Decode(&result); err != nil {
+	if err = client.Database(m.dbName).RunCommand(context.TODO(), bson.D{{Key: "ping", Value: 1}}).Decode(&result); err != nil {
 		return nil, fmt.Errorf("mongoDB Send a ping err: %e", err)
 	}
 
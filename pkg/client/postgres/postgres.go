@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	_ "github.com/lib/pq"
+	"net/url"
+)
+
+type Postgres struct {
+	host     string
+	username string
+	password string
+	port     string
+	dbName   string
+	sslMode  string
+}
+
+func New(cfgOpts ...Option) (*sql.DB, error) {
+	p := &Postgres{
+		sslMode: "disable",
+	}
+
+	for _, opt := range cfgOpts {
+		opt(p)
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(p.username, p.password),
+		Host:     p.host + ":" + p.port,
+		Path:     p.dbName,
+		RawQuery: url.Values{"sslmode": {p.sslMode}}.Encode(),
+	}
+
+	db, err := sql.Open("postgres", dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("postgres open err: %w", err)
+	}
+
+	// ping-запрос для подтверждения успешного подключения
+	if err = db.PingContext(context.TODO()); err != nil {
+		return nil, fmt.Errorf("postgres ping err: %w", err)
+	}
+
+	return db, nil
+}
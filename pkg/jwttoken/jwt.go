@@ -0,0 +1,58 @@
+package jwttoken
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type JWTToken struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func New(secret string, ttl time.Duration) *JWTToken {
+	return &JWTToken{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
+
+// CreateToken выпускает токен HS256, subject которого - ID пользователя
+func (j *JWTToken) CreateToken(subject string) (string, error) {
+	now := time.Now()
+
+	claims := jwt.RegisteredClaims{
+		Subject:   subject,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(j.ttl)),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(j.secret)
+	if err != nil {
+		return "", fmt.Errorf("sign token err: %w", err)
+	}
+
+	return token, nil
+}
+
+// ValidateToken проверяет подпись и срок действия токена и возвращает его subject
+func (j *JWTToken) ValidateToken(tokenString string) (string, error) {
+	var claims jwt.RegisteredClaims
+
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		return j.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if claims.Subject == "" {
+		return "", ErrInvalidToken
+	}
+
+	return claims.Subject, nil
+}
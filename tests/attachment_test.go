@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func (s *APITestSuite) TestAttachments() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	send := func(method, url string, body io.Reader, contentType string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, body)
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+		if contentType != "" {
+			request.Header.Set("Content-Type", contentType)
+		}
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	upload := func(url, fileName string, content []byte) *httptest.ResponseRecorder {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", fileName)
+		s.NoError(err)
+		_, err = part.Write(content)
+		s.NoError(err)
+		s.NoError(writer.Close())
+
+		return send(http.MethodPost, url, body, writer.FormDataContentType())
+	}
+
+	recorder := send(http.MethodPost, "/api/todo-list/tasks/", bytes.NewBufferString(`{"title":"test_attachments","activeAt":"2020-04-06"}`), "")
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	var task entity.Tasks
+	err := json.NewDecoder(recorder.Body).Decode(&task)
+	s.NoError(err)
+	url := "/api/todo-list/tasks/" + task.ID.Hex() + "/attachments"
+
+	content := append([]byte("%PDF-1.4\n"), bytes.Repeat([]byte("report "), 200)...)
+	recorder = upload(url, "report.pdf", content)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	var attachment entity.Attachment
+	err = json.NewDecoder(recorder.Body).Decode(&attachment)
+	s.NoError(err)
+	r.Equal("application/pdf", attachment.ContentType)
+	r.Equal(int64(len(content)), attachment.Size)
+
+	// Тип определяется по содержимому, а не по расширению
+	recorder = upload(url, "photo.png", []byte("PK\x03\x04 archive"))
+	r.Equal(http.StatusUnsupportedMediaType, recorder.Code)
+	r.Contains(recorder.Body.String(), "unsupported_attachment_type")
+
+	recorder = send(http.MethodGet, url, nil, "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var attachments dto.AttachmentsDTO
+	err = json.NewDecoder(recorder.Body).Decode(&attachments)
+	s.NoError(err)
+	r.Len(attachments.Attachments, 1)
+
+	recorder = send(http.MethodGet, url+"/"+attachment.ID.Hex(), nil, "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	r.Equal(content, recorder.Body.Bytes())
+	r.Equal("application/pdf", recorder.Header().Get("Content-Type"))
+	r.Equal(`attachment; filename=report.pdf`, recorder.Header().Get("Content-Disposition"))
+
+	// Поврежденный файл не отдается
+	path := filepath.Join(s.blobDir, s.userID.Hex(), task.ID.Hex(), attachment.ID.Hex())
+	damaged := append([]byte{}, content...)
+	damaged[len(damaged)-1] = '!'
+	err = os.WriteFile(path, damaged, 0o644)
+	s.NoError(err)
+
+	recorder = send(http.MethodGet, url+"/"+attachment.ID.Hex(), nil, "")
+	r.Equal(http.StatusInternalServerError, recorder.Code)
+	r.Contains(recorder.Body.String(), "attachment_corrupted")
+
+	recorder = send(http.MethodDelete, url+"/"+attachment.ID.Hex(), nil, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+	_, err = os.Stat(path)
+	r.True(os.IsNotExist(err))
+
+	recorder = send(http.MethodGet, url+"/"+attachment.ID.Hex(), nil, "")
+	r.Equal(http.StatusNotFound, recorder.Code)
+	r.Contains(recorder.Body.String(), "attachment_not_found")
+
+	// Вложения задачи из корзины остаются и возвращаются вместе с ней
+	recorder = upload(url, "notes.txt", []byte("notes"))
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+	err = json.NewDecoder(recorder.Body).Decode(&attachment)
+	s.NoError(err)
+	path = filepath.Join(s.blobDir, s.userID.Hex(), task.ID.Hex(), attachment.ID.Hex())
+
+	recorder = send(http.MethodDelete, "/api/todo-list/tasks/"+task.ID.Hex(), nil, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+	_, err = os.Stat(path)
+	s.NoError(err)
+
+	recorder = send(http.MethodPost, "/api/todo-list/tasks/"+task.ID.Hex()+"/restore", nil, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodGet, url+"/"+attachment.ID.Hex(), nil, "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	r.Equal("notes", recorder.Body.String())
+
+	// Файлы вложений удаляются, когда задача удаляется из корзины насовсем
+	recorder = send(http.MethodDelete, "/api/todo-list/tasks/"+task.ID.Hex(), nil, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	retention := s.service.Config.Trash.Retention
+	s.service.Config.Trash.Retention = -time.Minute
+	defer func() {
+		s.service.Config.Trash.Retention = retention
+	}()
+
+	_, err = s.service.PurgeTrash(context.Background())
+	s.NoError(err)
+	_, err = os.Stat(path)
+	r.True(os.IsNotExist(err))
+
+	stored, err := s.repos.GetAttachments(context.Background(), s.userID, task.ID)
+	s.NoError(err)
+	r.Empty(stored)
+}
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (s *APITestSuite) TestSignUpAndSignIn() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	body := fmt.Sprintf(`{"username":"user_%s","password":"password123"}`, primitive.NewObjectID().Hex())
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/api/todo-list/auth/sign-up", bytes.NewBufferString(body))
+	s.NoError(err)
+	router.ServeHTTP(recorder, request)
+	r.Equal(http.StatusCreated, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodPost, "/api/todo-list/auth/sign-up", bytes.NewBufferString(body))
+	s.NoError(err)
+	router.ServeHTTP(recorder, request)
+	r.Equal(http.StatusConflict, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodPost, "/api/todo-list/auth/sign-in", bytes.NewBufferString(body))
+	s.NoError(err)
+	router.ServeHTTP(recorder, request)
+	r.Equal(http.StatusOK, recorder.Code)
+
+	var token dto.TokenDTO
+	err = json.NewDecoder(recorder.Body).Decode(&token)
+	s.NoError(err)
+	r.NotEmpty(token.Token)
+
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, "/api/todo-list/tasks/", nil)
+	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+token.Token)
+	router.ServeHTTP(recorder, request)
+	r.Equal(http.StatusOK, recorder.Code)
+}
+
+func (s *APITestSuite) TestTasksOfAnotherUserAreHidden() {
+	taskTest := entity.Tasks{ID: primitive.NewObjectID(), Title: "test_foreign", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: primitive.NewObjectID()}
+	err = s.insertTask(taskTest)
+	s.NoError(err)
+
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/todo-list/tasks/"+taskTest.ID.Hex(), nil)
+	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
+	router.ServeHTTP(recorder, request)
+	r.Equal(http.StatusNotFound, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, "/api/todo-list/tasks/"+taskTest.ID.Hex(), nil)
+	s.NoError(err)
+	router.ServeHTTP(recorder, request)
+	r.Equal(http.StatusUnauthorized, recorder.Code)
+}
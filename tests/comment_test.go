@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (s *APITestSuite) TestComments() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := send(http.MethodPost, "/api/todo-list/tasks/", `{"title":"test_comments","activeAt":"2020-04-06"}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	var task entity.Tasks
+	err := json.NewDecoder(recorder.Body).Decode(&task)
+	s.NoError(err)
+	url := "/api/todo-list/tasks/" + task.ID.Hex()
+
+	recorder = send(http.MethodGet, url+"/comments", "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	r.Equal(`{"comments":[]}`, recorder.Body.String())
+
+	recorder = send(http.MethodPost, url+"/comments", `{"body":"Нужен **паспорт**"}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	var comment entity.Comment
+	err = json.NewDecoder(recorder.Body).Decode(&comment)
+	s.NoError(err)
+	r.Equal(s.userID, comment.Author)
+	r.Nil(comment.UpdatedAt)
+
+	recorder = send(http.MethodPost, url+"/comments", `{"body":"   "}`)
+	r.Equal(http.StatusUnprocessableEntity, recorder.Code)
+	r.Contains(recorder.Body.String(), "empty_comment")
+
+	recorder = send(http.MethodPut, url+"/comments/"+comment.ID.Hex(), `{"body":"Нужен паспорт и виза"}`)
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodGet, url+"/comments", "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var comments dto.CommentsDTO
+	err = json.NewDecoder(recorder.Body).Decode(&comments)
+	s.NoError(err)
+	r.Len(comments.Comments, 1)
+	r.Equal("Нужен паспорт и виза", comments.Comments[0].Body)
+	r.NotNil(comments.Comments[0].UpdatedAt)
+	r.Len(comments.Comments[0].Edits, 1)
+	r.Equal("Нужен **паспорт**", comments.Comments[0].Edits[0].Body)
+
+	// Комментарии задачи из корзины не читаются, но возвращаются вместе с ней
+	recorder = send(http.MethodDelete, url, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodGet, url+"/comments", "")
+	r.Equal(http.StatusNotFound, recorder.Code)
+
+	recorder = send(http.MethodPost, url+"/restore", "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodGet, url+"/comments", "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	err = json.NewDecoder(recorder.Body).Decode(&comments)
+	s.NoError(err)
+	r.Len(comments.Comments, 1)
+	r.Equal(comment.ID, comments.Comments[0].ID)
+
+	// Комментарии удаляются, только когда задача удаляется из корзины насовсем
+	recorder = send(http.MethodDelete, url, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	_, _, err = s.repos.PurgeDeletedTasks(context.Background(), time.Now().Add(time.Minute))
+	s.NoError(err)
+
+	stored, err := s.repos.GetComments(context.Background(), s.userID, task.ID)
+	s.NoError(err)
+	r.Empty(stored)
+}
@@ -9,7 +9,15 @@ var (
 	task = entity.Tasks{
 		ID:       primitive.NewObjectID(),
 		Title:    "Titled",
-		ActiveAt: "2023-08-04",
-		Status:   "active",
+		ActiveAt: mustDate("2023-08-04"),
+		Status:   "todo",
 	}
 )
+
+func mustDate(s string) entity.Date {
+	d, err := entity.ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (s *APITestSuite) TestHistory() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	send := func(method, url, body, ifMatch string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+		if ifMatch != "" {
+			request.Header.Set("If-Match", ifMatch)
+		}
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	history := func(url string) []entity.HistoryEntry {
+		recorder := send(http.MethodGet, url+"/history", "", "")
+		r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+		var result dto.HistoryDTO
+		err := json.NewDecoder(recorder.Body).Decode(&result)
+		s.NoError(err)
+		return result.History
+	}
+
+	recorder := send(http.MethodPost, "/api/todo-list/tasks/", `{"title":"test_history","activeAt":"2023-08-04","tags":["дом"]}`, "")
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	var task entity.Tasks
+	err := json.NewDecoder(recorder.Body).Decode(&task)
+	s.NoError(err)
+	url := "/api/todo-list/tasks/" + task.ID.Hex()
+
+	recorder = send(http.MethodPut, url, `{"title":"test_history_renamed","activeAt":"2023-08-04"}`, `"1"`)
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodPatch, url+"/status", `{"status":"in_progress"}`, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodDelete, url, "", "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodPost, url+"/restore", "", "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	// Каждое изменение записано отдельной версией от имени пользователя
+	entries := history(url)
+	r.Len(entries, 5)
+	for i, action := range []string{entity.HistoryCreate, entity.HistoryUpdate, entity.HistoryStatus, entity.HistoryDelete, entity.HistoryRestore} {
+		r.Equal(action, entries[i].Action)
+		r.Equal(int64(i+1), entries[i].Version)
+		r.Equal(s.userID, entries[i].Actor)
+	}
+	r.Equal([]entity.FieldChange{
+		{Field: "title", Before: json.RawMessage(`"test_history"`), After: json.RawMessage(`"test_history_renamed"`)},
+		{Field: "tags", Before: json.RawMessage(`["дом"]`), After: json.RawMessage(`null`)},
+	}, entries[1].Changes)
+	r.Equal("status", entries[2].Changes[0].Field)
+	r.Equal("deletedAt", entries[3].Changes[0].Field)
+	r.Equal(json.RawMessage(`null`), entries[4].Changes[0].After)
+
+	// Откат возвращает содержимое версии 1 новой версией, статус остается текущим
+	recorder = send(http.MethodPost, url+"/revert/1", "", `"4"`)
+	r.Equal(http.StatusPreconditionFailed, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodPost, url+"/revert/1", "", `"5"`)
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	r.Equal(`"6"`, recorder.Header().Get("ETag"))
+
+	var reverted entity.Tasks
+	err = json.NewDecoder(recorder.Body).Decode(&reverted)
+	s.NoError(err)
+	r.Equal("test_history", reverted.Title)
+	r.Equal([]string{"дом"}, reverted.Tags)
+	r.Equal("in_progress", reverted.Status)
+
+	entries = history(url)
+	r.Len(entries, 6)
+	r.Equal(entity.HistoryRevert, entries[5].Action)
+	r.Equal(int64(1), *entries[5].RevertedTo)
+
+	recorder = send(http.MethodPost, url+"/revert/42", "", "")
+	r.Equal(http.StatusNotFound, recorder.Code)
+	r.Contains(recorder.Body.String(), "history_not_found")
+
+	recorder = send(http.MethodPost, url+"/revert/first", "", "")
+	r.Equal(http.StatusBadRequest, recorder.Code)
+	r.Contains(recorder.Body.String(), "invalid_version")
+
+	recorder = send(http.MethodDelete, url, "", "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+}
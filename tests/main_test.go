@@ -2,12 +2,21 @@ package tests
 
 import (
 	"context"
+	"database/sql"
 	"github.com/khussa1n/todo-list/internal/config"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
 	"github.com/khussa1n/todo-list/internal/handler"
+	"github.com/khussa1n/todo-list/internal/repository"
+	"github.com/khussa1n/todo-list/internal/repository/memrepo"
 	"github.com/khussa1n/todo-list/internal/repository/mongorepo"
+	"github.com/khussa1n/todo-list/internal/repository/postgresrepo"
 	"github.com/khussa1n/todo-list/internal/service"
+	"github.com/khussa1n/todo-list/pkg/blobstorage"
 	"github.com/khussa1n/todo-list/pkg/client/mongodb"
+	"github.com/khussa1n/todo-list/pkg/client/postgres"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"os"
 	"testing"
@@ -28,33 +37,93 @@ func init() {
 type APITestSuite struct {
 	suite.Suite
 
-	db      *mongo.Database
+	// driver - хранилище, на котором запускается набор: test.db.driver или memory
+	driver string
+
+	// db - подключение к MongoDB, nil для хранилища в памяти
+	db *mongo.Database
+	// sqlDB - подключение к PostgreSQL, nil для остальных хранилищ
+	sqlDB   *sql.DB
 	handler *handler.Handler
 	service *service.Manager
-	repos   *mongorepo.MongoDB
+	repos   repository.Repository
+	// blobDir - каталог файлов вложений, удаляется после тестов
+	blobDir string
+
+	userID primitive.ObjectID
+	token  string
 }
 
 func TestAPISuite(t *testing.T) {
-	if testing.Short() {
+	if testing.Short() && cfg.Test.DB.Driver != config.DriverMemory {
+		t.Skip()
+	}
+
+	suite.Run(t, &APITestSuite{driver: cfg.Test.DB.Driver})
+}
+
+// TestMemoryAPISuite дополнительно прогоняет набор на хранилище в памяти. Ему не нужны
+// внешние сервисы, поэтому он запускается и с --short
+func TestMemoryAPISuite(t *testing.T) {
+	if cfg.Test.DB.Driver == config.DriverMemory {
 		t.Skip()
 	}
 
-	suite.Run(t, new(APITestSuite))
+	suite.Run(t, &APITestSuite{driver: config.DriverMemory})
 }
 
 func (s *APITestSuite) SetupSuite() {
-	if client, err := mongodb.New(
-		mongodb.WithHost(cfg.Test.DB.Host),
-		mongodb.WithPort(cfg.Test.DB.Port),
-		mongodb.WithDBName(cfg.Test.DB.DBName),
-	); err != nil {
-		s.FailNow("Failed to connect to mongo", err)
-	} else {
-		s.db = client
+	switch s.driver {
+	case config.DriverMemory:
+		s.repos = memrepo.New()
+	case config.DriverPostgres:
+		if conn, err := postgres.New(
+			postgres.WithHost(cfg.Test.DB.Host),
+			postgres.WithPort(cfg.Test.DB.Port),
+			postgres.WithDBName(cfg.Test.DB.DBName),
+			postgres.WithUsername(cfg.Test.DB.Username),
+			postgres.WithPassword(cfg.Test.DB.Password),
+			postgres.WithSSLMode(cfg.Test.DB.SSLMode),
+		); err != nil {
+			s.FailNow("Failed to connect to postgres", err)
+		} else {
+			s.sqlDB = conn
+		}
+
+		pdb := postgresrepo.New(s.sqlDB)
+		if err = pdb.Migrate(context.Background()); err != nil {
+			s.FailNow("Failed to migrate", err)
+		}
+
+		s.repos = pdb
+	default:
+		if client, err := mongodb.New(
+			mongodb.WithHost(cfg.Test.DB.Host),
+			mongodb.WithPort(cfg.Test.DB.Port),
+			mongodb.WithDBName(cfg.Test.DB.DBName),
+		); err != nil {
+			s.FailNow("Failed to connect to mongo", err)
+		} else {
+			s.db = client
+		}
+
+		mdb := mongorepo.New(s.db, cfg.Test.DB.Collections)
+		if err = mdb.Migrate(context.Background()); err != nil {
+			s.FailNow("Failed to migrate", err)
+		}
+		if err = mdb.EnsureIndexes(context.Background()); err != nil {
+			s.FailNow("Failed to create indexes", err)
+		}
+
+		s.repos = mdb
 	}
 
 	s.initDeps()
 
+	if err = s.signIn(); err != nil {
+		s.FailNow("Failed to sign in", err)
+	}
+
 	if err = s.populateDB(); err != nil {
 		s.FailNow("Failed to populate DB", err)
 	}
@@ -62,15 +131,26 @@ func (s *APITestSuite) SetupSuite() {
 }
 
 func (s *APITestSuite) TearDownSuite() {
-	s.db.Client().Disconnect(context.Background())
+	if s.db != nil {
+		s.db.Client().Disconnect(context.Background())
+	}
+	if s.sqlDB != nil {
+		s.sqlDB.Close()
+	}
 }
 
 func (s *APITestSuite) initDeps() {
-	mdb := mongorepo.New(s.db, cfg.DB.Collections)
-	srvs := service.New(mdb, cfg)
+	s.blobDir = s.T().TempDir()
+	blobs, err := blobstorage.NewLocal(s.blobDir)
+	if err != nil {
+		s.FailNow("Failed to create blob storage", err)
+	}
+
+	srvs := service.New(s.repos, cfg)
+	srvs.Blobs = blobs
 	hndlr := handler.New(srvs)
+	hndlr.MaxUploadSize = cfg.Attachment.MaxSize
 
-	s.repos = mdb
 	s.service = srvs
 	s.handler = hndlr
 }
@@ -80,10 +160,38 @@ func TestMain(m *testing.M) {
 	os.Exit(rc)
 }
 
-func (s *APITestSuite) populateDB() error {
-	_, err = s.db.Collection(cfg.DB.Collections.Task).InsertOne(context.Background(), task)
+// signIn регистрирует тестового пользователя и получает для него токен
+func (s *APITestSuite) signIn() error {
+	req := &dto.UserDTO{Username: "test_" + primitive.NewObjectID().Hex(), Password: "password123"}
+
+	user, err := s.service.SignUp(context.Background(), req)
 	if err != nil {
 		return err
 	}
+
+	token, err := s.service.SignIn(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	s.userID = user.ID
+	s.token = token.Token
+	task.Owner = user.ID
+
 	return nil
 }
+
+func (s *APITestSuite) populateDB() error {
+	return s.insertTask(task)
+}
+
+// insertTask сохраняет задачу напрямую через репозиторий, минуя сервис
+func (s *APITestSuite) insertTask(t entity.Tasks) error {
+	_, err := s.repos.CreateTask(context.Background(), &t)
+	return err
+}
+
+// getTask читает задачу тестового пользователя напрямую из репозитория
+func (s *APITestSuite) getTask(id primitive.ObjectID) (*entity.Tasks, error) {
+	return s.repos.GetTaskByID(context.Background(), s.userID, id)
+}
@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"github.com/khussa1n/todo-list/internal/repository/mongorepo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (s *APITestSuite) TestMigrateLegacyTasks() {
+	if s.db == nil {
+		s.T().Skip("documents of previous versions are kept only in MongoDB")
+	}
+
+	router := s.handler.InitRouter()
+	r := s.Require()
+	ctx := context.Background()
+
+	get := func(url string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Документы в том виде, в каком их сохраняли версии до статусов, версий, корзины и поиска
+	legacy, nulled := primitive.NewObjectID(), primitive.NewObjectID()
+	_, err := s.db.Collection(cfg.Test.DB.Collections.Task).InsertMany(ctx, []interface{}{
+		bson.M{"_id": legacy, "title": "test_legacy квазиплан", "activeAt": "2001-02-03", "status": "active", "owner": s.userID},
+		bson.M{"_id": nulled, "title": "test_legacy_null", "activeAt": "2023-08-04", "status": "done", "owner": s.userID, "deletedAt": nil},
+	})
+	r.NoError(err)
+
+	r.NoError(s.repos.(*mongorepo.MongoDB).Migrate(ctx))
+
+	task, err := s.getTask(legacy)
+	r.NoError(err)
+	r.Equal("todo", task.Status)
+	r.Equal(int64(1), task.Version)
+	r.Equal(entity.PriorityMedium, task.Priority)
+	r.Equal("2001-02-03", task.ActiveAt.String())
+
+	// Задача с deletedAt: null не в корзине
+	recorder := get("/api/todo-list/tasks/" + nulled.Hex())
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	recorder = get("/api/todo-list/tasks/?status=todo&from=2001-02-03&to=2001-02-03")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var page dto.TasksPageDTO
+	err = json.NewDecoder(recorder.Body).Decode(&page)
+	s.NoError(err)
+	r.Equal([]primitive.ObjectID{legacy}, taskIDs(page.Tasks))
+
+	recorder = get("/api/todo-list/tasks/search?q=квази")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	err = json.NewDecoder(recorder.Body).Decode(&page)
+	s.NoError(err)
+	r.Equal([]primitive.ObjectID{legacy}, taskIDs(page.Tasks))
+
+	// Повторный запуск ничего не меняет
+	r.NoError(s.repos.(*mongorepo.MongoDB).Migrate(ctx))
+
+	again, err := s.getTask(legacy)
+	r.NoError(err)
+	r.Equal(task, again)
+}
+
+func (s *APITestSuite) TestEnsureIndexesDuplicates() {
+	if s.db == nil {
+		s.T().Skip("documents of previous versions are kept only in MongoDB")
+	}
+
+	r := s.Require()
+	ctx := context.Background()
+	mdb := s.repos.(*mongorepo.MongoDB)
+	tasks := s.db.Collection(cfg.Test.DB.Collections.Task)
+	lists := s.db.Collection(cfg.Test.DB.Collections.List)
+	users := s.db.Collection(cfg.Test.DB.Collections.User)
+
+	// Данные, сохраненные до появления индексов уникальности
+	for collection, name := range map[*mongo.Collection]string{
+		tasks: "owner_list_title_deleted_occurrence_unique",
+		lists: "owner_title_unique",
+		users: "username_unique",
+	} {
+		_, err := collection.Indexes().DropOne(ctx, name)
+		r.NoError(err)
+	}
+
+	first, second, third, trashed := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	_, err := tasks.InsertMany(ctx, []interface{}{
+		bson.M{"_id": first, "title": "test_dup дубль", "activeAt": primitive.NewDateTimeFromTime(time.Now()), "status": "todo", "owner": s.userID, "version": 1},
+		bson.M{"_id": second, "title": "test_dup дубль", "activeAt": primitive.NewDateTimeFromTime(time.Now()), "status": "todo", "owner": s.userID, "version": 1, "deletedAt": nil},
+		bson.M{"_id": third, "title": "test_dup дубль", "activeAt": primitive.NewDateTimeFromTime(time.Now()), "status": "todo", "owner": s.userID, "version": 1},
+		bson.M{"_id": trashed, "title": "test_dup дубль", "activeAt": primitive.NewDateTimeFromTime(time.Now()), "status": "todo", "owner": s.userID, "version": 1,
+			"deletedAt": primitive.NewDateTimeFromTime(time.Now())},
+	})
+	r.NoError(err)
+
+	oldList, newList := primitive.NewObjectID(), primitive.NewObjectID()
+	_, err = lists.InsertMany(ctx, []interface{}{
+		bson.M{"_id": oldList, "title": "test_dup_list", "owner": s.userID},
+		bson.M{"_id": newList, "title": "test_dup_list", "owner": s.userID},
+	})
+	r.NoError(err)
+
+	extraUser := primitive.NewObjectID()
+	_, err = users.InsertMany(ctx, []interface{}{
+		bson.M{"username": "test_dup_user", "password": "x"},
+		bson.M{"_id": extraUser, "username": "test_dup_user", "password": "x"},
+	})
+	r.NoError(err)
+
+	// Одинаковые имена пользователей не переименовываются, ошибка называет их
+	err = mdb.EnsureIndexes(ctx)
+	r.ErrorContains(err, `"test_dup_user"`)
+
+	_, err = users.DeleteOne(ctx, bson.M{"_id": extraUser})
+	r.NoError(err)
+
+	r.NoError(mdb.EnsureIndexes(ctx))
+
+	// Самая старая задача сохраняет название, задача в корзине с остальными не конфликтует
+	for id, title := range map[primitive.ObjectID]string{
+		first: "test_dup дубль", second: "test_dup дубль (2)", third: "test_dup дубль (3)", trashed: "test_dup дубль",
+	} {
+		var task entity.Tasks
+		err = tasks.FindOne(ctx, bson.M{"_id": id}).Decode(&task)
+		r.NoError(err)
+		r.Equal(title, task.Title)
+	}
+
+	var list entity.List
+	err = lists.FindOne(ctx, bson.M{"_id": newList}).Decode(&list)
+	r.NoError(err)
+	r.Equal("test_dup_list (2)", list.Title)
+
+	// Переименованная задача находится по новому названию
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/todo-list/tasks/search?q=дубл", nil)
+	r.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
+	s.handler.InitRouter().ServeHTTP(recorder, request)
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var page dto.TasksPageDTO
+	err = json.NewDecoder(recorder.Body).Decode(&page)
+	s.NoError(err)
+	r.ElementsMatch([]primitive.ObjectID{first, second, third}, taskIDs(page.Tasks))
+
+	// Индексы снова на месте, повторный запуск ничего не меняет
+	_, err = tasks.InsertOne(ctx, bson.M{"title": "test_dup дубль", "owner": s.userID})
+	r.True(mongo.IsDuplicateKeyError(err))
+	r.NoError(mdb.EnsureIndexes(ctx))
+
+	_, err = tasks.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": bson.A{first, second, third, trashed}}})
+	r.NoError(err)
+	_, err = lists.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": bson.A{oldList, newList}}})
+	r.NoError(err)
+	_, err = users.DeleteMany(ctx, bson.M{"username": "test_dup_user"})
+	r.NoError(err)
+}
+
+func taskIDs(tasks []entity.Tasks) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
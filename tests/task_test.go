@@ -5,11 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/khussa1n/todo-list/internal/custom_error"
 	"github.com/khussa1n/todo-list/internal/entity"
 	"github.com/khussa1n/todo-list/internal/entity/dto"
-	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
 )
 
 func (s *APITestSuite) TestCreateTask() {
@@ -26,27 +30,172 @@ func (s *APITestSuite) TestCreateTask() {
 	url := fmt.Sprintf("/api/todo-list/tasks/")
 	request, err := http.NewRequest(http.MethodPost, url, &buf)
 	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
 
 	router.ServeHTTP(recorder, request)
 
 	r := s.Require()
 
-	if recorder.Code == 400 {
-		r.Equal("\"a task with the same title already exists\"", recorder.Body.String())
+	if recorder.Code == http.StatusConflict {
+		r.Equal(`{"code":"duplicate_task","message":"a task with the same title already exists","details":[{"field":"title","message":"a task with the same title already exists"}]}`, recorder.Body.String())
 	} else {
 		r.Equal(http.StatusCreated, recorder.Code)
-		var task2 entity.Tasks
-		err = s.db.Collection(cfg.Test.DB.Collections.Task).FindOne(context.Background(), bson.M{"title": dtoTest.Title}).Decode(&task2)
+		var created entity.Tasks
+		err = json.NewDecoder(recorder.Body).Decode(&created)
+		s.NoError(err)
+
+		task2, err := s.getTask(created.ID)
 		s.NoError(err)
 
 		r.Equal(dtoTest.Title, task2.Title)
-		r.Equal(dtoTest.ActiveAt, task2.ActiveAt)
-		r.Equal("active", task2.Status)
+		r.Equal(dtoTest.ActiveAt, task2.ActiveAt.String())
+		r.Equal("todo", task2.Status)
+	}
+}
+
+func (s *APITestSuite) TestWeekendTaskRoundTrip() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	// Задача на субботу отдается с префиксом, но сохраняется без него
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/api/todo-list/tasks/", bytes.NewBufferString(`{"title":"test_weekend","activeAt":"2023-08-05"}`))
+	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
+
+	router.ServeHTTP(recorder, request)
+
+	r.Equal(http.StatusCreated, recorder.Code)
+	var created entity.Tasks
+	err = json.NewDecoder(recorder.Body).Decode(&created)
+	s.NoError(err)
+	r.Equal("ВЫХОДНОЙ - test_weekend", created.Title)
+	r.True(created.Weekend)
+
+	// Повторное сохранение полученного названия не добавляет второй префикс
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodPut, "/api/todo-list/tasks/"+created.ID.Hex(), bytes.NewBufferString(`{"title":"`+created.Title+`","activeAt":"2023-08-06"}`))
+	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
+
+	router.ServeHTTP(recorder, request)
+
+	r.Equal(http.StatusNoContent, recorder.Code)
+	stored, err := s.getTask(created.ID)
+	s.NoError(err)
+	r.Equal("test_weekend", stored.Title)
+	r.True(stored.Weekend)
+
+	// Перенос на будний день снимает флаг
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodPut, "/api/todo-list/tasks/"+created.ID.Hex(), bytes.NewBufferString(`{"title":"test_weekend","activeAt":"2023-08-07"}`))
+	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
+
+	router.ServeHTTP(recorder, request)
+
+	r.Equal(http.StatusNoContent, recorder.Code)
+	stored, err = s.getTask(created.ID)
+	s.NoError(err)
+	r.Equal("test_weekend", stored.Title)
+	r.False(stored.Weekend)
+}
+
+func (s *APITestSuite) TestPatchTask() {
+	taskTest := entity.Tasks{ID: primitive.NewObjectID(), Title: "test_patch", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: s.userID}
+	err = s.insertTask(taskTest)
+	s.NoError(err)
+
+	other := entity.Tasks{ID: primitive.NewObjectID(), Title: "test_patch_other", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: s.userID}
+	err = s.insertTask(other)
+	s.NoError(err)
+
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	for _, step := range []struct {
+		id   primitive.ObjectID
+		body string
+		code int
+	}{
+		{id: taskTest.ID, body: `{"activeAt":"2023-08-05"}`, code: http.StatusNoContent},
+		{id: taskTest.ID, body: `{"title":"test_patch_other"}`, code: http.StatusConflict},
+		{id: primitive.NewObjectID(), body: `{"title":"test_patch_missing"}`, code: http.StatusNotFound},
+	} {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodPatch, "/api/todo-list/tasks/"+step.id.Hex(), bytes.NewBufferString(step.body))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+		request.Header.Set("Content-Type", "application/merge-patch+json")
+
+		router.ServeHTTP(recorder, request)
+
+		r.Equal(step.code, recorder.Code, step.body)
 	}
+
+	// Изменилась только дата, название осталось прежним
+	task2, err := s.getTask(taskTest.ID)
+	s.NoError(err)
+
+	r.Equal("test_patch", task2.Title)
+	r.Equal("2023-08-05", task2.ActiveAt.String())
+	r.True(task2.Weekend)
+	r.Equal("todo", task2.Status)
+}
+
+func (s *APITestSuite) TestOptimisticConcurrency() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	send := func(method, url, body, ifMatch string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+		if ifMatch != "" {
+			request.Header.Set("If-Match", ifMatch)
+		}
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := send(http.MethodPost, "/api/todo-list/tasks/", `{"title":"test_etag","activeAt":"2023-08-04"}`, "")
+	r.Equal(http.StatusCreated, recorder.Code)
+	r.Equal(`"1"`, recorder.Header().Get("ETag"))
+
+	var created entity.Tasks
+	err = json.NewDecoder(recorder.Body).Decode(&created)
+	s.NoError(err)
+	url := "/api/todo-list/tasks/" + created.ID.Hex()
+
+	// Первый из двух редакторов версии 1 сохраняет изменения, второй получает 412
+	recorder = send(http.MethodPut, url, `{"title":"test_etag_first","activeAt":"2023-08-04"}`, `"1"`)
+	r.Equal(http.StatusNoContent, recorder.Code)
+
+	recorder = send(http.MethodPatch, url, `{"title":"test_etag_second"}`, `"1"`)
+	r.Equal(http.StatusPreconditionFailed, recorder.Code)
+
+	recorder = send(http.MethodGet, url, "", "")
+	r.Equal(http.StatusOK, recorder.Code)
+	r.Equal(`"2"`, recorder.Header().Get("ETag"))
+
+	var current entity.Tasks
+	err = json.NewDecoder(recorder.Body).Decode(&current)
+	s.NoError(err)
+	r.Equal("test_etag_first", current.Title)
+
+	recorder = send(http.MethodDelete, url, "", `"1"`)
+	r.Equal(http.StatusPreconditionFailed, recorder.Code)
+
+	recorder = send(http.MethodDelete, url, "", `"2"`)
+	r.Equal(http.StatusNoContent, recorder.Code)
 }
 
 func (s *APITestSuite) TestUpdateTask() {
-	_, err = s.db.Collection(cfg.DB.Collections.Task).InsertOne(context.Background(), task)
+	err = s.insertTask(task)
 	s.NoError(err)
 
 	dtoTest := dto.TasksDTO{Title: "test2_update", ActiveAt: "2023-08-04"}
@@ -62,18 +211,18 @@ func (s *APITestSuite) TestUpdateTask() {
 	url := fmt.Sprintf("/api/todo-list/tasks/" + task.ID.Hex())
 	request, err := http.NewRequest(http.MethodPut, url, &buf)
 	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
 
 	router.ServeHTTP(recorder, request)
 
 	r := s.Require()
 
 	r.Equal(http.StatusNoContent, recorder.Code)
-	var task2 entity.Tasks
-	err = s.db.Collection(cfg.Test.DB.Collections.Task).FindOne(context.Background(), bson.M{"_id": task.ID}).Decode(&task2)
+	task2, err := s.getTask(task.ID)
 	s.NoError(err)
 
 	r.Equal(dtoTest.Title, task2.Title)
-	r.Equal(dtoTest.ActiveAt, task2.ActiveAt)
+	r.Equal(dtoTest.ActiveAt, task2.ActiveAt.String())
 }
 
 func (s *APITestSuite) TestUpdateTaskStatus() {
@@ -84,19 +233,53 @@ func (s *APITestSuite) TestUpdateTaskStatus() {
 	url := fmt.Sprintf("/api/todo-list/tasks/" + task.ID.Hex() + "/done")
 	request, err := http.NewRequest(http.MethodPut, url, nil)
 	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
 
 	router.ServeHTTP(recorder, request)
 
 	r := s.Require()
 
 	r.Equal(http.StatusNoContent, recorder.Code)
-	var task2 entity.Tasks
-	err = s.db.Collection(cfg.Test.DB.Collections.Task).FindOne(context.Background(), bson.M{"_id": task.ID}).Decode(&task2)
+	task2, err := s.getTask(task.ID)
 	s.NoError(err)
 
 	r.Equal("done", task2.Status)
 }
 
+func (s *APITestSuite) TestUpdateTaskStatusWorkflow() {
+	taskTest := entity.Tasks{ID: primitive.NewObjectID(), Title: "test_workflow", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: s.userID}
+	err = s.insertTask(taskTest)
+	s.NoError(err)
+
+	r := s.Require()
+
+	for _, step := range []struct {
+		status string
+		code   int
+	}{
+		{status: "in_progress", code: http.StatusNoContent},
+		{status: "archived", code: http.StatusNoContent},
+		{status: "done", code: http.StatusConflict},
+		{status: "todo", code: http.StatusNoContent},
+	} {
+		recorder := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/todo-list/tasks/" + taskTest.ID.Hex() + "/status")
+		request, err := http.NewRequest(http.MethodPatch, url, bytes.NewBufferString(`{"status":"`+step.status+`"}`))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		s.handler.InitRouter().ServeHTTP(recorder, request)
+
+		r.Equal(step.code, recorder.Code, step.status)
+	}
+
+	task2, err := s.getTask(taskTest.ID)
+	s.NoError(err)
+
+	r.Equal("todo", task2.Status)
+}
+
 func (s *APITestSuite) TestGetAllTasks() {
 	var buf bytes.Buffer
 
@@ -105,6 +288,7 @@ func (s *APITestSuite) TestGetAllTasks() {
 	url := fmt.Sprintf("/api/todo-list/tasks/")
 	request, err := http.NewRequest(http.MethodGet, url, &buf)
 	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
 
 	s.handler.InitRouter().ServeHTTP(recorder, request)
 
@@ -113,6 +297,748 @@ func (s *APITestSuite) TestGetAllTasks() {
 	r.Equal(http.StatusOK, recorder.Code)
 }
 
+func (s *APITestSuite) TestGetAllTasksPagination() {
+	for i := 0; i < 3; i++ {
+		taskTest := entity.Tasks{ID: primitive.NewObjectID(), Title: fmt.Sprintf("test_page_%d", i), ActiveAt: mustDate("2022-06-01"), Status: "archived", Owner: s.userID}
+		err = s.insertTask(taskTest)
+		s.NoError(err)
+	}
+
+	r := s.Require()
+
+	var titles []string
+	cursor := ""
+	for {
+		recorder := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/todo-list/tasks/?status=archived&from=2022-06-01&to=2022-06-01&limit=2&sort=-title&cursor=" + cursor)
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		s.handler.InitRouter().ServeHTTP(recorder, request)
+
+		r.Equal(http.StatusOK, recorder.Code)
+
+		var page dto.TasksPageDTO
+		err = json.NewDecoder(recorder.Body).Decode(&page)
+		s.NoError(err)
+		r.Equal(int64(3), page.Total)
+
+		for _, t := range page.Tasks {
+			titles = append(titles, t.Title)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	r.Equal([]string{"test_page_2", "test_page_1", "test_page_0"}, titles)
+}
+
+func (s *APITestSuite) TestGetAllTasksDateRange() {
+	for _, activeAt := range []string{"2022-07-31", "2022-08-01", "2022-08-15", "2022-09-01"} {
+		taskTest := entity.Tasks{ID: primitive.NewObjectID(), Title: "test_range_" + activeAt, ActiveAt: mustDate(activeAt), Status: "in_progress", Owner: s.userID}
+		err = s.insertTask(taskTest)
+		s.NoError(err)
+	}
+
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/api/todo-list/tasks/?status=in_progress&from=2022-08-01&to=2022-08-31&sort=activeAt")
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
+
+	s.handler.InitRouter().ServeHTTP(recorder, request)
+
+	r := s.Require()
+
+	r.Equal(http.StatusOK, recorder.Code)
+
+	var page dto.TasksPageDTO
+	err = json.NewDecoder(recorder.Body).Decode(&page)
+	s.NoError(err)
+
+	r.Equal(int64(2), page.Total)
+	r.Equal("test_range_2022-08-01", page.Tasks[0].Title)
+	r.Equal("test_range_2022-08-15", page.Tasks[1].Title)
+}
+
+func (s *APITestSuite) TestTaskDetails() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := send(http.MethodPost, "/api/todo-list/tasks/",
+		`{"title":"test_details","description":"Взять **паспорт** в пульсаре","activeAt":"2020-02-04","priority":"urgent","due":{"time":"18:30","timeZone":"Asia/Almaty"}}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	var created entity.Tasks
+	err = json.NewDecoder(recorder.Body).Decode(&created)
+	s.NoError(err)
+	url := "/api/todo-list/tasks/" + created.ID.Hex()
+
+	recorder = send(http.MethodPost, "/api/todo-list/tasks/", `{"title":"test_details_low","activeAt":"2020-02-04","priority":"low"}`)
+	r.Equal(http.StatusCreated, recorder.Code)
+
+	task2, err := s.getTask(created.ID)
+	s.NoError(err)
+	r.Equal("Взять **паспорт** в пульсаре", task2.Description)
+	r.Equal(entity.PriorityUrgent, task2.Priority)
+	r.Equal(&entity.DueTime{Time: "18:30", TimeZone: "Asia/Almaty"}, task2.Due)
+
+	// Фильтр и сортировка по приоритету
+	recorder = send(http.MethodGet, "/api/todo-list/tasks/?from=2020-02-04&to=2020-02-04&sort=-priority", "")
+	r.Equal(http.StatusOK, recorder.Code)
+
+	var page dto.TasksPageDTO
+	err = json.NewDecoder(recorder.Body).Decode(&page)
+	s.NoError(err)
+	r.Len(page.Tasks, 2)
+	r.Equal("test_details", page.Tasks[0].Title)
+	r.Equal("test_details_low", page.Tasks[1].Title)
+
+	recorder = send(http.MethodGet, "/api/todo-list/tasks/?from=2020-02-04&to=2020-02-04&priority=low,medium", "")
+	r.Equal(http.StatusOK, recorder.Code)
+
+	page = dto.TasksPageDTO{}
+	err = json.NewDecoder(recorder.Body).Decode(&page)
+	s.NoError(err)
+	r.Len(page.Tasks, 1)
+	r.Equal(entity.PriorityLow, page.Tasks[0].Priority)
+
+	// Описание участвует в поиске
+	recorder = send(http.MethodGet, "/api/todo-list/tasks/search?q=пульс+паспорт", "")
+	r.Equal(http.StatusOK, recorder.Code)
+
+	page = dto.TasksPageDTO{}
+	err = json.NewDecoder(recorder.Body).Decode(&page)
+	s.NoError(err)
+	r.Len(page.Tasks, 1)
+	r.Equal(created.ID, page.Tasks[0].ID)
+
+	recorder = send(http.MethodPatch, url, `{"due":null,"priority":"high"}`)
+	r.Equal(http.StatusNoContent, recorder.Code)
+
+	task2, err = s.getTask(created.ID)
+	s.NoError(err)
+	r.Nil(task2.Due)
+	r.Equal(entity.PriorityHigh, task2.Priority)
+	r.Equal("Взять **паспорт** в пульсаре", task2.Description)
+
+	recorder = send(http.MethodPatch, url, `{"due":{"time":"7:00"}}`)
+	r.Equal(http.StatusUnprocessableEntity, recorder.Code)
+}
+
+func (s *APITestSuite) TestTags() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	create := func(body string) primitive.ObjectID {
+		recorder := send(http.MethodPost, "/api/todo-list/tasks/", body)
+		r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+		var created entity.Tasks
+		err := json.NewDecoder(recorder.Body).Decode(&created)
+		s.NoError(err)
+		return created.ID
+	}
+
+	list := func(url string) []entity.Tasks {
+		recorder := send(http.MethodGet, url, "")
+		r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+		var page dto.TasksPageDTO
+		err := json.NewDecoder(recorder.Body).Decode(&page)
+		s.NoError(err)
+		return page.Tasks
+	}
+
+	tags := func() map[string]int64 {
+		recorder := send(http.MethodGet, "/api/todo-list/tasks/tags", "")
+		r.Equal(http.StatusOK, recorder.Code)
+
+		var result dto.TagsDTO
+		err := json.NewDecoder(recorder.Body).Decode(&result)
+		s.NoError(err)
+
+		counts := make(map[string]int64)
+		for _, tag := range result.Tags {
+			if strings.HasPrefix(tag.Tag, "t17-") {
+				counts[tag.Tag] = tag.Count
+			}
+		}
+		return counts
+	}
+
+	both := create(`{"title":"test_tags_both","activeAt":"2020-03-04","tags":["T17-Red","t17-blue"]}`)
+	red := create(`{"title":"test_tags_red","activeAt":"2020-03-04","tags":["t17-red"]}`)
+	create(`{"title":"test_tags_none","activeAt":"2020-03-04"}`)
+
+	task, err := s.getTask(both)
+	s.NoError(err)
+	r.Equal([]string{"t17-red", "t17-blue"}, task.Tags)
+
+	// По умолчанию задача должна иметь все метки
+	tasks := list("/api/todo-list/tasks/?tag=t17-red&tag=t17-blue")
+	r.Len(tasks, 1)
+	r.Equal(both, tasks[0].ID)
+
+	tasks = list("/api/todo-list/tasks/?tag=t17-red&tag=t17-blue&tagMatch=any&sort=title")
+	r.Len(tasks, 2)
+	r.Equal(both, tasks[0].ID)
+	r.Equal(red, tasks[1].ID)
+
+	recorder := send(http.MethodGet, "/api/todo-list/tasks/?tag=t17-red&tagMatch=none", "")
+	r.Equal(http.StatusBadRequest, recorder.Code)
+
+	r.Equal(map[string]int64{"t17-red": 2, "t17-blue": 1}, tags())
+
+	// Слияние: у both уже есть t17-red, метка не дублируется
+	recorder = send(http.MethodPut, "/api/todo-list/tasks/tags/t17-blue", `{"name":"t17-red"}`)
+	r.Equal(http.StatusOK, recorder.Code)
+	r.Equal(`{"tasks":1}`, recorder.Body.String())
+
+	task, err = s.getTask(both)
+	s.NoError(err)
+	r.Equal([]string{"t17-red"}, task.Tags)
+	r.Equal(int64(2), task.Version)
+
+	// Новая версия задачи попадает в историю
+	history, err := s.repos.GetHistory(context.Background(), s.userID, both)
+	s.NoError(err)
+	r.Len(history, 2)
+	r.Equal(entity.HistoryUpdate, history[1].Action)
+	r.Equal(int64(2), history[1].Version)
+	r.Equal([]string{"t17-red"}, history[1].Snapshot.Tags)
+
+	recorder = send(http.MethodPut, "/api/todo-list/tasks/tags/t17-red", `{"name":"t17-green"}`)
+	r.Equal(http.StatusOK, recorder.Code)
+	r.Equal(`{"tasks":2}`, recorder.Body.String())
+	r.Equal(map[string]int64{"t17-green": 2}, tags())
+
+	recorder = send(http.MethodPut, "/api/todo-list/tasks/tags/t17-red", `{"name":"t17-blue"}`)
+	r.Equal(http.StatusNotFound, recorder.Code)
+
+	// null в PATCH убирает все метки
+	recorder = send(http.MethodPatch, "/api/todo-list/tasks/"+red.Hex(), `{"tags":null}`)
+	r.Equal(http.StatusNoContent, recorder.Code)
+
+	task, err = s.getTask(red)
+	s.NoError(err)
+	r.Empty(task.Tags)
+	r.Equal(map[string]int64{"t17-green": 1}, tags())
+	// Переименование в то же название ничего не меняет, но метка должна быть
+	recorder = send(http.MethodPut, "/api/todo-list/tasks/tags/t17-green", `{"name":"t17-green"}`)
+	r.Equal(http.StatusOK, recorder.Code)
+	r.Equal(`{"tasks":0}`, recorder.Body.String())
+
+	recorder = send(http.MethodPut, "/api/todo-list/tasks/tags/t17-red", `{"name":"t17-red"}`)
+	r.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (s *APITestSuite) TestChecklist() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	decode := func(recorder *httptest.ResponseRecorder) entity.Tasks {
+		var task entity.Tasks
+		err := json.NewDecoder(recorder.Body).Decode(&task)
+		s.NoError(err)
+		return task
+	}
+
+	recorder := send(http.MethodPost, "/api/todo-list/tasks/", `{"title":"test_checklist","activeAt":"2020-04-04","autoComplete":true}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+	url := "/api/todo-list/tasks/" + decode(recorder).ID.Hex()
+
+	recorder = send(http.MethodPost, url+"/checklist", `{"title":"Паспорт"}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+	passport := decode(recorder).Checklist[0]
+
+	recorder = send(http.MethodPost, url+"/checklist", `{"title":"Билеты"}`)
+	r.Equal(http.StatusCreated, recorder.Code)
+	task := decode(recorder)
+	r.Len(task.Checklist, 2)
+	r.Equal(0, *task.Progress)
+	tickets := task.Checklist[1]
+
+	recorder = send(http.MethodPut, url+"/checklist/order", fmt.Sprintf(`{"ids":["%s","%s"]}`, tickets.ID.Hex(), passport.ID.Hex()))
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	task = decode(recorder)
+	r.Equal([]string{"Билеты", "Паспорт"}, []string{task.Checklist[0].Title, task.Checklist[1].Title})
+
+	recorder = send(http.MethodPatch, url+"/checklist/"+passport.ID.Hex(), `{"done":true}`)
+	r.Equal(http.StatusOK, recorder.Code)
+	task = decode(recorder)
+	r.Equal(50, *task.Progress)
+	r.Equal(entity.StatusTodo, task.Status)
+
+	// Последний выполненный пункт завершает задачу
+	recorder = send(http.MethodPatch, url+"/checklist/"+tickets.ID.Hex(), `{"done":true}`)
+	r.Equal(http.StatusOK, recorder.Code)
+	task = decode(recorder)
+	r.Equal(100, *task.Progress)
+	r.Equal(entity.StatusDone, task.Status)
+	r.Equal(fmt.Sprintf(`"%d"`, task.Version), recorder.Header().Get("ETag"))
+
+	recorder = send(http.MethodGet, url, "")
+	r.Equal(http.StatusOK, recorder.Code)
+	r.Equal(task, decode(recorder))
+
+	recorder = send(http.MethodDelete, url+"/checklist/"+tickets.ID.Hex(), "")
+	r.Equal(http.StatusOK, recorder.Code)
+	task = decode(recorder)
+	r.Len(task.Checklist, 1)
+
+	recorder = send(http.MethodDelete, url+"/checklist/"+tickets.ID.Hex(), "")
+	r.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (s *APITestSuite) TestRecurrence() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	series := func(status string) []entity.Tasks {
+		recorder := send(http.MethodGet, "/api/todo-list/tasks/?tag=t19-series&status="+status, "")
+		r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+		var page dto.TasksPageDTO
+		err := json.NewDecoder(recorder.Body).Decode(&page)
+		s.NoError(err)
+		return page.Tasks
+	}
+
+	recorder := send(http.MethodPost, "/api/todo-list/tasks/", `{"title":"test_recurrence","activeAt":"2020-04-04","tags":["t19-series"],"recurrence":"FREQ=HOURLY"}`)
+	r.Equal(http.StatusUnprocessableEntity, recorder.Code)
+
+	recorder = send(http.MethodPost, "/api/todo-list/tasks/", `{"title":"test_recurrence","activeAt":"2020-04-04","tags":["t19-series"],"recurrence":"FREQ=DAILY"}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	var first entity.Tasks
+	err := json.NewDecoder(recorder.Body).Decode(&first)
+	s.NoError(err)
+	url := "/api/todo-list/tasks/" + first.ID.Hex()
+
+	// Выполнение повторения создает следующее с тем же названием, пропущенные дни не создаются
+	recorder = send(http.MethodPatch, url+"/status", `{"status":"done"}`)
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	tasks := series(entity.StatusDone)
+	r.Len(tasks, 1)
+	done := tasks[0]
+
+	tasks = series(entity.StatusTodo)
+	r.Len(tasks, 1)
+	next := tasks[0]
+
+	r.Equal(entity.StatusDone, done.Status)
+	r.Empty(done.Recurrence)
+	r.Equal(first.ActiveAt, *done.Occurrence)
+	r.Equal(first.ID, *done.SeriesID)
+
+	r.True(strings.HasSuffix(next.Title, "test_recurrence"), next.Title)
+	r.Equal(entity.StatusTodo, next.Status)
+	r.Equal("FREQ=DAILY", next.Recurrence)
+	r.Equal(first.ID, *next.SeriesID)
+	r.Nil(next.Occurrence)
+	r.False(next.ActiveAt.Before(entity.DateOf(time.Now()).Time))
+
+	// Остановленная серия после выполнения не продолжается
+	url = "/api/todo-list/tasks/" + next.ID.Hex()
+	recorder = send(http.MethodDelete, url+"/recurrence", "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodPatch, url+"/status", `{"status":"done"}`)
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+	r.Len(series(entity.StatusTodo), 0)
+	r.Len(series(entity.StatusDone), 2)
+
+	recorder = send(http.MethodPut, url+"/recurrence", `{"recurrence":"FREQ=WEEKLY;BYDAY=XX"}`)
+	r.Equal(http.StatusUnprocessableEntity, recorder.Code)
+
+	// Автозавершение по чек-листу завершает повторение так же, как смена статуса
+	recorder = send(http.MethodPost, "/api/todo-list/tasks/", `{"title":"test_recurrence_checklist","activeAt":"2020-04-04","tags":["t19-auto"],"recurrence":"FREQ=DAILY","autoComplete":true}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	err = json.NewDecoder(recorder.Body).Decode(&first)
+	s.NoError(err)
+	url = "/api/todo-list/tasks/" + first.ID.Hex()
+
+	recorder = send(http.MethodPost, url+"/checklist", `{"title":"Паспорт"}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	var task entity.Tasks
+	err = json.NewDecoder(recorder.Body).Decode(&task)
+	s.NoError(err)
+
+	recorder = send(http.MethodPatch, url+"/checklist/"+task.Checklist[0].ID.Hex(), `{"done":true}`)
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var completed entity.Tasks
+	err = json.NewDecoder(recorder.Body).Decode(&completed)
+	s.NoError(err)
+	r.Equal(entity.StatusDone, completed.Status)
+	r.Empty(completed.Recurrence)
+	r.Equal(first.ID, *completed.SeriesID)
+	r.Equal(fmt.Sprintf(`"%d"`, completed.Version), recorder.Header().Get("ETag"))
+
+	recorder = send(http.MethodGet, "/api/todo-list/tasks/?tag=t19-auto", "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var page dto.TasksPageDTO
+	err = json.NewDecoder(recorder.Body).Decode(&page)
+	s.NoError(err)
+	r.Len(page.Tasks, 1)
+	r.Equal("FREQ=DAILY", page.Tasks[0].Recurrence)
+	r.False(page.Tasks[0].Checklist[0].Done)
+}
+
+func (s *APITestSuite) TestDependencies() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	create := func(title string) string {
+		recorder := send(http.MethodPost, "/api/todo-list/tasks/", `{"title":"`+title+`","activeAt":"2020-04-06"}`)
+		r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+		var task entity.Tasks
+		err := json.NewDecoder(recorder.Body).Decode(&task)
+		s.NoError(err)
+		return task.ID.Hex()
+	}
+
+	release, tests, build := create("test_dependency_release"), create("test_dependency_tests"), create("test_dependency_build")
+	url := "/api/todo-list/tasks/"
+
+	// release <- tests <- build
+	recorder := send(http.MethodPut, url+release+"/dependencies/"+tests, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+	recorder = send(http.MethodPut, url+tests+"/dependencies/"+build, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodPut, url+build+"/dependencies/"+release, "")
+	r.Equal(http.StatusConflict, recorder.Code)
+	r.Contains(recorder.Body.String(), "dependency_cycle")
+
+	recorder = send(http.MethodGet, url+release+"/graph", "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var graph entity.DependencyGraph
+	err := json.NewDecoder(recorder.Body).Decode(&graph)
+	s.NoError(err)
+	r.Equal(release, graph.Root.Hex())
+	r.Len(graph.Nodes, 3)
+	r.Equal([]string{release, tests, build}, []string{graph.Nodes[0].ID.Hex(), graph.Nodes[1].ID.Hex(), graph.Nodes[2].ID.Hex()})
+	r.Len(graph.Edges, 2)
+	r.Equal(tests, graph.Edges[0].BlockedBy.Hex())
+	r.Equal(build, graph.Edges[1].BlockedBy.Hex())
+
+	recorder = send(http.MethodPut, url+release+"/done", "")
+	r.Equal(http.StatusConflict, recorder.Code)
+	r.Contains(recorder.Body.String(), "task_blocked")
+
+	recorder = send(http.MethodPut, url+tests+"/done", "")
+	r.Equal(http.StatusConflict, recorder.Code)
+
+	recorder = send(http.MethodPut, url+build+"/done", "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+	recorder = send(http.MethodPut, url+tests+"/done", "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodDelete, url+release+"/dependencies/"+tests, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+	recorder = send(http.MethodDelete, url+release+"/dependencies/"+tests, "")
+	r.Equal(http.StatusNotFound, recorder.Code)
+
+	recorder = send(http.MethodPut, url+release+"/done", "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+}
+
+func (s *APITestSuite) TestLists() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	createList := func(title string) string {
+		recorder := send(http.MethodPost, "/api/todo-list/lists/", `{"title":"`+title+`"}`)
+		r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+		var list entity.List
+		err := json.NewDecoder(recorder.Body).Decode(&list)
+		s.NoError(err)
+		return list.ID.Hex()
+	}
+
+	createTask := func(title, listID string) string {
+		recorder := send(http.MethodPost, "/api/todo-list/tasks/", `{"title":"`+title+`","activeAt":"2020-04-06","listId":"`+listID+`"}`)
+		r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+		var task entity.Tasks
+		err := json.NewDecoder(recorder.Body).Decode(&task)
+		s.NoError(err)
+		return task.ID.Hex()
+	}
+
+	work, home := createList("test_list_work"), createList("test_list_home")
+
+	recorder := send(http.MethodPost, "/api/todo-list/lists/", `{"title":" test_list_work "}`)
+	r.Equal(http.StatusConflict, recorder.Code)
+	r.Contains(recorder.Body.String(), "duplicate_list")
+
+	// Одно и то же название можно использовать в разных списках и во входящих
+	inWork := createTask("test_list_report", work)
+	createTask("test_list_report", home)
+	inbox := createTask("test_list_report", "")
+
+	recorder = send(http.MethodGet, "/api/todo-list/lists/"+work+"/tasks?status=todo", "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var page dto.TasksPageDTO
+	err := json.NewDecoder(recorder.Body).Decode(&page)
+	s.NoError(err)
+	r.Len(page.Tasks, 1)
+	r.Equal(inWork, page.Tasks[0].ID.Hex())
+
+	recorder = send(http.MethodPut, "/api/todo-list/tasks/"+inbox+"/list", `{"listId":"`+work+`"}`)
+	r.Equal(http.StatusConflict, recorder.Code)
+	r.Contains(recorder.Body.String(), "duplicate_task")
+
+	recorder = send(http.MethodDelete, "/api/todo-list/lists/"+work, "")
+	r.Equal(http.StatusConflict, recorder.Code)
+	r.Contains(recorder.Body.String(), "list_not_empty")
+
+	recorder = send(http.MethodDelete, "/api/todo-list/tasks/"+inbox, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+	recorder = send(http.MethodPut, "/api/todo-list/tasks/"+inWork+"/list", `{"listId":""}`)
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodDelete, "/api/todo-list/lists/"+work, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(http.MethodGet, "/api/todo-list/lists/"+work+"/tasks", "")
+	r.Equal(http.StatusNotFound, recorder.Code)
+	r.Contains(recorder.Body.String(), "list_not_found")
+}
+
+func (s *APITestSuite) TestSearchTasks() {
+	exact := entity.Tasks{ID: primitive.NewObjectID(), Title: "Квазар: наблюдение", ActiveAt: mustDate("2021-03-01"), Status: "todo", Owner: s.userID, Version: 1}
+	prefix := entity.Tasks{ID: primitive.NewObjectID(), Title: "Квазарный отчет", ActiveAt: mustDate("2021-03-02"), Status: "done", Owner: s.userID, Version: 1}
+	other := entity.Tasks{ID: primitive.NewObjectID(), Title: "Отчет о звездах", ActiveAt: mustDate("2021-03-03"), Status: "todo", Owner: s.userID, Version: 1}
+	deleted := entity.Tasks{ID: primitive.NewObjectID(), Title: "Квазар в корзине", ActiveAt: mustDate("2021-03-04"), Status: "todo", Owner: s.userID, Version: 1}
+	for _, t := range []entity.Tasks{exact, prefix, other, deleted} {
+		err = s.insertTask(t)
+		s.NoError(err)
+	}
+	_, err = s.repos.DeleteTask(context.Background(), s.userID, deleted.ID, 0)
+	s.NoError(err)
+
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	search := func(query string) dto.TasksPageDTO {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodGet, "/api/todo-list/tasks/search?"+query, nil)
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		router.ServeHTTP(recorder, request)
+		r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+		var page dto.TasksPageDTO
+		err = json.NewDecoder(recorder.Body).Decode(&page)
+		s.NoError(err)
+		return page
+	}
+
+	titles := func(page dto.TasksPageDTO) []string {
+		result := make([]string, 0, len(page.Tasks))
+		for _, t := range page.Tasks {
+			result = append(result, t.Title)
+		}
+		return result
+	}
+
+	// Целое слово релевантнее начала слова, задачи из корзины не ищутся
+	page := search("q=квазар")
+	r.Equal([]string{exact.Title, prefix.Title}, titles(page))
+	r.Equal(int64(2), page.Total)
+
+	r.Equal([]string{prefix.Title}, titles(search("q=ОТЧ+квазар")))
+	r.Equal([]string{prefix.Title}, titles(search("q=квазар&status=done")))
+	r.Equal([]string{exact.Title}, titles(search("q=квазар&from=2021-03-01&to=2021-03-01")))
+
+	page = search("q=квазар&limit=1")
+	r.Equal([]string{exact.Title}, titles(page))
+	r.NotEmpty(page.NextCursor)
+
+	page = search("q=квазар&limit=1&cursor=" + page.NextCursor)
+	r.Equal([]string{prefix.Title}, titles(page))
+	r.Empty(page.NextCursor)
+
+	// Длинные слова и описание с тысячами слов сохраняются и находятся
+	words := []string{strings.Repeat("ж", 40)}
+	for i := 0; i < 1200; i++ {
+		words = append(words, fmt.Sprintf("ф%04d", i))
+	}
+	long := entity.Tasks{ID: primitive.NewObjectID(), Title: "Длинное описание", Description: strings.Join(words, " ") + " зюзюка",
+		ActiveAt: mustDate("2021-03-05"), Status: "todo", Owner: s.userID, Version: 1}
+	err = s.insertTask(long)
+	r.NoError(err)
+
+	r.Equal([]string{long.Title}, titles(search("q="+strings.Repeat("ж", 35))))
+	r.Equal([]string{long.Title}, titles(search("q=зюзюка")))
+}
+
+func (s *APITestSuite) TestGetTaskByID() {
+	taskTest := entity.Tasks{ID: primitive.NewObjectID(), Title: "test_get", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: s.userID}
+	err = s.insertTask(taskTest)
+	s.NoError(err)
+
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/api/todo-list/tasks/" + taskTest.ID.Hex())
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
+
+	s.handler.InitRouter().ServeHTTP(recorder, request)
+
+	r := s.Require()
+
+	r.Equal(http.StatusOK, recorder.Code)
+	var task2 entity.Tasks
+	err = json.NewDecoder(recorder.Body).Decode(&task2)
+	s.NoError(err)
+
+	r.Equal(taskTest, task2)
+}
+
+func (s *APITestSuite) TestGetTaskByIDNotFound() {
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/api/todo-list/tasks/" + primitive.NewObjectID().Hex())
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
+
+	s.handler.InitRouter().ServeHTTP(recorder, request)
+
+	r := s.Require()
+
+	r.Equal(http.StatusNotFound, recorder.Code)
+	r.Equal(`{"code":"task_not_found","message":"task not found"}`, recorder.Body.String())
+}
+
+func (s *APITestSuite) TestStatusAndDeleteNotFound() {
+	// Задача другого пользователя для текущего не существует
+	foreign := entity.Tasks{ID: primitive.NewObjectID(), Title: "test_foreign", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: primitive.NewObjectID(), Version: 1}
+	err = s.insertTask(foreign)
+	s.NoError(err)
+
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	for _, id := range []primitive.ObjectID{primitive.NewObjectID(), foreign.ID} {
+		url := "/api/todo-list/tasks/" + id.Hex()
+
+		requests := []struct {
+			method string
+			url    string
+			body   string
+		}{
+			{method: http.MethodPut, url: url + "/done"},
+			{method: http.MethodPatch, url: url + "/status", body: `{"status":"in_progress"}`},
+			{method: http.MethodDelete, url: url},
+		}
+
+		for _, req := range requests {
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(req.method, req.url, bytes.NewBufferString(req.body))
+			s.NoError(err)
+			request.Header.Set("Authorization", "Bearer "+s.token)
+
+			router.ServeHTTP(recorder, request)
+
+			r.Equal(http.StatusNotFound, recorder.Code, req.method+" "+req.url)
+			r.Equal(`{"code":"task_not_found","message":"task not found"}`, recorder.Body.String())
+		}
+	}
+
+	task2, err := s.repos.GetTaskByID(context.Background(), foreign.Owner, foreign.ID)
+	s.NoError(err)
+	r.Equal("todo", task2.Status)
+}
+
 func (s *APITestSuite) TestDeleteTask() {
 	var buf bytes.Buffer
 
@@ -121,10 +1047,145 @@ func (s *APITestSuite) TestDeleteTask() {
 	url := fmt.Sprintf("/api/todo-list/tasks/" + task.ID.Hex())
 	request, err := http.NewRequest(http.MethodDelete, url, &buf)
 	s.NoError(err)
+	request.Header.Set("Authorization", "Bearer "+s.token)
 
 	s.handler.InitRouter().ServeHTTP(recorder, request)
 
 	r := s.Require()
 
 	r.Equal(http.StatusNoContent, recorder.Code)
+
+	_, err = s.getTask(task.ID)
+	r.ErrorIs(err, custom_error.ErrTaskNotFound)
+
+	// Очистка корзины удаляет задачу окончательно, и ее можно создать заново
+	purged, _, err := s.repos.PurgeDeletedTasks(context.Background(), time.Now().Add(time.Minute))
+	s.NoError(err)
+	r.GreaterOrEqual(purged, int64(1))
+}
+
+func (s *APITestSuite) TestTrashAndRestore() {
+	taskTest := entity.Tasks{ID: primitive.NewObjectID(), Title: "test_trash", ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: s.userID, Version: 1}
+	err = s.insertTask(taskTest)
+	s.NoError(err)
+
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	send := func(method, url string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, nil)
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+s.token)
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	inPage := func(url string) bool {
+		recorder := send(http.MethodGet, url)
+		r.Equal(http.StatusOK, recorder.Code)
+
+		var page dto.TasksPageDTO
+		err := json.NewDecoder(recorder.Body).Decode(&page)
+		s.NoError(err)
+
+		for _, t := range page.Tasks {
+			if t.ID == taskTest.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	url := "/api/todo-list/tasks/" + taskTest.ID.Hex()
+
+	recorder := send(http.MethodDelete, url)
+	r.Equal(http.StatusNoContent, recorder.Code)
+
+	r.Equal(http.StatusNotFound, send(http.MethodGet, url).Code)
+	r.False(inPage("/api/todo-list/tasks/?limit=100"))
+	r.True(inPage("/api/todo-list/tasks/trash?limit=100"))
+
+	// Удаленную задачу нельзя изменить, а ее название снова свободно
+	r.Equal(http.StatusNotFound, send(http.MethodPut, url+"/done").Code)
+
+	duplicate := entity.Tasks{ID: primitive.NewObjectID(), Title: taskTest.Title, ActiveAt: mustDate("2023-08-04"), Status: "todo", Owner: s.userID}
+	err = s.insertTask(duplicate)
+	s.NoError(err)
+
+	r.Equal(http.StatusConflict, send(http.MethodPost, url+"/restore").Code)
+
+	r.Equal(http.StatusNoContent, send(http.MethodDelete, "/api/todo-list/tasks/"+duplicate.ID.Hex()).Code)
+
+	recorder = send(http.MethodPost, url+"/restore")
+	r.Equal(http.StatusNoContent, recorder.Code)
+
+	r.Equal(http.StatusOK, send(http.MethodGet, url).Code)
+	r.False(inPage("/api/todo-list/tasks/trash?limit=100"))
+
+	// Восстановить можно только задачу из корзины
+	r.Equal(http.StatusNotFound, send(http.MethodPost, url+"/restore").Code)
+}
+
+func (s *APITestSuite) TestCreateTaskConcurrentDuplicates() {
+	const parallel = 2
+
+	dtoTest := dto.TasksDTO{Title: "test_concurrent_" + primitive.NewObjectID().Hex(), ActiveAt: "2023-08-04"}
+
+	router := s.handler.InitRouter()
+
+	codes := make(chan int, parallel)
+	start := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var buf bytes.Buffer
+			_ = json.NewEncoder(&buf).Encode(dtoTest)
+
+			request, _ := http.NewRequest(http.MethodPost, "/api/todo-list/tasks/", &buf)
+			request.Header.Set("Authorization", "Bearer "+s.token)
+
+			recorder := httptest.NewRecorder()
+
+			<-start
+			router.ServeHTTP(recorder, request)
+			codes <- recorder.Code
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	close(codes)
+
+	r := s.Require()
+
+	created, conflicts := 0, 0
+	for code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+			conflicts++
+		}
+	}
+
+	r.Equal(1, created)
+	r.Equal(parallel-1, conflicts)
+
+	tasks, _, err := s.repos.GetAllTasks(context.Background(), &entity.TaskFilter{Owner: s.userID})
+	s.NoError(err)
+
+	count := 0
+	for _, t := range tasks {
+		if t.Title == dtoTest.Title {
+			count++
+		}
+	}
+	r.Equal(1, count)
 }
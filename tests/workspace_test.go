@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/khussa1n/todo-list/internal/entity"
+	"github.com/khussa1n/todo-list/internal/entity/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (s *APITestSuite) TestWorkspaces() {
+	router := s.handler.InitRouter()
+	r := s.Require()
+
+	guestReq := &dto.UserDTO{Username: "test_guest_" + primitive.NewObjectID().Hex(), Password: "password123"}
+	guest, err := s.service.SignUp(context.Background(), guestReq)
+	s.NoError(err)
+	guestToken, err := s.service.SignIn(context.Background(), guestReq)
+	s.NoError(err)
+
+	send := func(token, workspace, method, url, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		s.NoError(err)
+		request.Header.Set("Authorization", "Bearer "+token)
+		if workspace != "" {
+			request.Header.Set("X-Workspace-ID", workspace)
+		}
+
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := send(s.token, "", http.MethodPost, "/api/todo-list/workspaces/", `{"title":"test_team"}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	var workspace entity.Workspace
+	err = json.NewDecoder(recorder.Body).Decode(&workspace)
+	s.NoError(err)
+	r.Equal(entity.RoleOwner, workspace.Role)
+	ws := workspace.ID.Hex()
+	url := "/api/todo-list/workspaces/" + ws
+
+	recorder = send(guestToken.Token, ws, http.MethodGet, "/api/todo-list/tasks/", "")
+	r.Equal(http.StatusNotFound, recorder.Code)
+	r.Contains(recorder.Body.String(), "workspace_not_found")
+
+	recorder = send(s.token, "", http.MethodPost, url+"/invitations", `{"role":"viewer"}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	var invitation entity.Invitation
+	err = json.NewDecoder(recorder.Body).Decode(&invitation)
+	s.NoError(err)
+
+	recorder = send(guestToken.Token, "", http.MethodPost, "/api/todo-list/invitations/"+invitation.Token+"/accept", "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	recorder = send(guestToken.Token, "", http.MethodPost, "/api/todo-list/invitations/"+invitation.Token+"/accept", "")
+	r.Equal(http.StatusNotFound, recorder.Code)
+	r.Contains(recorder.Body.String(), "invitation_not_found")
+
+	recorder = send(s.token, ws, http.MethodPost, "/api/todo-list/tasks/", `{"title":"test_workspace_task","activeAt":"2020-04-06"}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+	r.Contains(recorder.Body.String(), `"workspaceId":"`+ws+`"`)
+
+	// Задачи пространства не смешиваются с личными задачами участников
+	recorder = send(s.token, "", http.MethodGet, "/api/todo-list/tasks/search?q=test_workspace_task", "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	r.NotContains(recorder.Body.String(), "test_workspace_task")
+
+	recorder = send(guestToken.Token, ws, http.MethodGet, "/api/todo-list/tasks/?status=todo", "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var page dto.TasksPageDTO
+	err = json.NewDecoder(recorder.Body).Decode(&page)
+	s.NoError(err)
+	r.Len(page.Tasks, 1)
+	r.Equal("test_workspace_task", page.Tasks[0].Title)
+
+	recorder = send(guestToken.Token, ws, http.MethodPost, "/api/todo-list/tasks/", `{"title":"test_viewer_task","activeAt":"2020-04-06"}`)
+	r.Equal(http.StatusForbidden, recorder.Code)
+	r.Contains(recorder.Body.String(), "forbidden")
+
+	recorder = send(s.token, "", http.MethodPut, url+"/members/"+guest.ID.Hex(), `{"role":"editor"}`)
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(guestToken.Token, ws, http.MethodPost, "/api/todo-list/tasks/", `{"title":"test_editor_task","activeAt":"2020-04-06"}`)
+	r.Equal(http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	recorder = send(guestToken.Token, "", http.MethodGet, url+"/members", "")
+	r.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var members dto.MembersDTO
+	err = json.NewDecoder(recorder.Body).Decode(&members)
+	s.NoError(err)
+	r.Len(members.Members, 2)
+
+	recorder = send(s.token, "", http.MethodDelete, url+"/members/"+s.userID.Hex(), "")
+	r.Equal(http.StatusConflict, recorder.Code)
+	r.Contains(recorder.Body.String(), "workspace_owner")
+
+	recorder = send(guestToken.Token, "", http.MethodDelete, url, "")
+	r.Equal(http.StatusForbidden, recorder.Code)
+
+	recorder = send(s.token, "", http.MethodDelete, url, "")
+	r.Equal(http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	recorder = send(guestToken.Token, ws, http.MethodGet, "/api/todo-list/tasks/", "")
+	r.Equal(http.StatusNotFound, recorder.Code)
+}